@@ -3,7 +3,7 @@ package httpexpect
 import (
 	"errors"
 	"fmt"
-	"reflect"
+	"strconv"
 )
 
 // Array provides methods to inspect attached []interface{} object
@@ -12,6 +12,7 @@ type Array struct {
 	noCopy noCopy
 	chain  *chain
 	value  []interface{}
+	paths  []string
 }
 
 // NewArray returns a new Array instance.
@@ -59,6 +60,15 @@ func newArray(parent *chain, val []interface{}) *Array {
 	return a
 }
 
+// Format element index for chain path. If array was returned by
+// Value.PathAll, normalized path of the element is used instead.
+func (a *Array) elemIndex(index int) string {
+	if index >= 0 && index < len(a.paths) {
+		return a.paths[index]
+	}
+	return strconv.Itoa(index)
+}
+
 // Raw returns underlying value attached to Array.
 // This is the value originally passed to NewArray, converted to canonical form.
 //
@@ -116,6 +126,27 @@ func (a *Array) Alias(name string) *Array {
 	return a
 }
 
+// Chain returns public handle to assertion chain of Array, which may be
+// used to implement custom matchers. See Chain for details.
+func (a *Array) Chain() *Chain {
+	return &Chain{chain: a.chain}
+}
+
+// AsWarning is similar to Value.AsWarning.
+func (a *Array) AsWarning() *Array {
+	opChain := a.chain.enter("AsWarning()")
+	defer opChain.leave()
+
+	warnChain := a.chain.clone()
+	warnChain.setWarning()
+
+	return &Array{
+		chain: warnChain,
+		value: a.value,
+		paths: a.paths,
+	}
+}
+
 // Path is similar to Value.Path.
 func (a *Array) Path(path string) *Value {
 	opChain := a.chain.enter("Path(%q)", path)
@@ -161,7 +192,7 @@ func (a *Array) Length() *Number {
 //	array.Value(0).String().IsEqual("foo")
 //	array.Value(1).Number().IsEqual(123)
 func (a *Array) Value(index int) *Value {
-	opChain := a.chain.enter("Value(%d)", index)
+	opChain := a.chain.enter("Value(%s)", a.elemIndex(index))
 	defer opChain.leave()
 
 	if opChain.failed() {
@@ -228,7 +259,7 @@ func (a *Array) HasValue(index int, value interface{}) *Array {
 		return a
 	}
 
-	if !reflect.DeepEqual(expected, a.value[index]) {
+	if !canonEqual(a.value[index], expected) {
 		opChain.fail(AssertionFailure{
 			Type:     AssertEqual,
 			Actual:   &AssertionValue{a.value[index]},
@@ -282,7 +313,7 @@ func (a *Array) NotHasValue(index int, value interface{}) *Array {
 		return a
 	}
 
-	if reflect.DeepEqual(expected, a.value[index]) {
+	if canonEqual(a.value[index], expected) {
 		opChain.fail(AssertionFailure{
 			Type:     AssertNotEqual,
 			Actual:   &AssertionValue{a.value[index]},
@@ -367,7 +398,7 @@ func (a *Array) Iter() []Value {
 
 	for index, element := range a.value {
 		func() {
-			valueChain := opChain.replace("Iter[%d]", index)
+			valueChain := opChain.replace("Iter[%s]", a.elemIndex(index))
 			defer valueChain.leave()
 
 			ret = append(ret, *newValue(valueChain, element))
@@ -411,7 +442,7 @@ func (a *Array) Every(fn func(index int, value *Value)) *Array {
 
 	for index, element := range a.value {
 		func() {
-			valueChain := opChain.replace("Every[%d]", index)
+			valueChain := opChain.replace("Every[%s]", a.elemIndex(index))
 			defer valueChain.leave()
 
 			fn(index, newValue(valueChain, element))
@@ -461,7 +492,7 @@ func (a *Array) Filter(fn func(index int, value *Value) bool) *Array {
 
 	for index, element := range a.value {
 		func() {
-			valueChain := opChain.replace("Filter[%d]", index)
+			valueChain := opChain.replace("Filter[%s]", a.elemIndex(index))
 			defer valueChain.leave()
 
 			valueChain.setRoot()
@@ -552,7 +583,7 @@ func (a *Array) Find(fn func(index int, value *Value) bool) *Value {
 		found := false
 
 		func() {
-			valueChain := opChain.replace("Find[%d]", index)
+			valueChain := opChain.replace("Find[%s]", a.elemIndex(index))
 			defer valueChain.leave()
 
 			valueChain.setRoot()
@@ -620,7 +651,7 @@ func (a *Array) FindAll(fn func(index int, value *Value) bool) []*Value {
 
 	for index, element := range a.value {
 		func() {
-			valueChain := opChain.replace("FindAll[%d]", index)
+			valueChain := opChain.replace("FindAll[%s]", a.elemIndex(index))
 			defer valueChain.leave()
 
 			valueChain.setRoot()
@@ -673,7 +704,7 @@ func (a *Array) NotFind(fn func(index int, value *Value) bool) *Array {
 		found := false
 
 		func() {
-			valueChain := opChain.replace("NotFind[%d]", index)
+			valueChain := opChain.replace("NotFind[%s]", a.elemIndex(index))
 			defer valueChain.leave()
 
 			valueChain.setRoot()
@@ -788,13 +819,98 @@ func (a *Array) IsEqual(value interface{}) *Array {
 		return a
 	}
 
-	if !reflect.DeepEqual(expected, a.value) {
+	if equal, diffs := equalValues(a.value, expected); !equal {
 		opChain.fail(AssertionFailure{
 			Type:     AssertEqual,
 			Actual:   &AssertionValue{a.value},
 			Expected: &AssertionValue{expected},
-			Errors: []error{
+			Errors: append([]error{
 				errors.New("expected: arrays are equal"),
+			}, diffs...),
+		})
+	}
+
+	return a
+}
+
+// IsEqualWith succeeds if array is equal to given value, taking into
+// account given options. Before comparison, both values are converted to
+// canonical form.
+//
+// See EqualOption for supported options and path syntax.
+//
+// Example:
+//
+//	array := NewArray(t, []interface{}{"b", "a", 9.999})
+//	array.IsEqualWith([]interface{}{"a", "b", 10},
+//		UnorderedAt("$"), Tolerance("$[*]", 0.01))
+func (a *Array) IsEqualWith(value interface{}, options ...EqualOption) *Array {
+	opChain := a.chain.enter("IsEqualWith()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return a
+	}
+
+	opts, ok := buildEqualOptions(opChain, options)
+	if !ok {
+		return a
+	}
+
+	expected, ok := canonArray(opChain, value)
+	if !ok {
+		return a
+	}
+
+	if diffs := compareWithOptions(opts, a.value, expected); len(diffs) != 0 {
+		opChain.fail(AssertionFailure{
+			Type:     AssertEqual,
+			Actual:   &AssertionValue{a.value},
+			Expected: &AssertionValue{expected},
+			Errors: append([]error{
+				errors.New("expected: values are equal with given options"),
+			}, diffs...),
+		})
+	}
+
+	return a
+}
+
+// NotEqualWith succeeds if array is not equal to given value, taking into
+// account given options. Before comparison, both values are converted to
+// canonical form.
+//
+// See EqualOption for supported options and path syntax.
+//
+// Example:
+//
+//	array := NewArray(t, []interface{}{"b", "a", 9.999})
+//	array.NotEqualWith([]interface{}{"a", "b", 10}, UnorderedAt("$"))
+func (a *Array) NotEqualWith(value interface{}, options ...EqualOption) *Array {
+	opChain := a.chain.enter("NotEqualWith()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return a
+	}
+
+	opts, ok := buildEqualOptions(opChain, options)
+	if !ok {
+		return a
+	}
+
+	expected, ok := canonArray(opChain, value)
+	if !ok {
+		return a
+	}
+
+	if diffs := compareWithOptions(opts, a.value, expected); len(diffs) == 0 {
+		opChain.fail(AssertionFailure{
+			Type:     AssertNotEqual,
+			Actual:   &AssertionValue{a.value},
+			Expected: &AssertionValue{expected},
+			Errors: []error{
+				errors.New("expected: values are non-equal with given options"),
 			},
 		})
 	}
@@ -824,7 +940,7 @@ func (a *Array) NotEqual(value interface{}) *Array {
 		return a
 	}
 
-	if reflect.DeepEqual(expected, a.value) {
+	if equal, _ := equalValues(a.value, expected); equal {
 		opChain.fail(AssertionFailure{
 			Type:     AssertNotEqual,
 			Actual:   &AssertionValue{a.value},
@@ -1033,7 +1149,7 @@ func (a *Array) InList(values ...interface{}) *Array {
 			return a
 		}
 
-		if reflect.DeepEqual(expected, a.value) {
+		if canonEqual(a.value, expected) {
 			isListed = true
 			// continue loop to check that all values are correct
 		}
@@ -1088,7 +1204,7 @@ func (a *Array) NotInList(values ...interface{}) *Array {
 			return a
 		}
 
-		if reflect.DeepEqual(expected, a.value) {
+		if canonEqual(a.value, expected) {
 			opChain.fail(AssertionFailure{
 				Type:     AssertNotBelongs,
 				Actual:   &AssertionValue{a.value},
@@ -1129,7 +1245,7 @@ func (a *Array) ConsistsOf(values ...interface{}) *Array {
 		return a
 	}
 
-	if !reflect.DeepEqual(expected, a.value) {
+	if !canonEqual(a.value, expected) {
 		opChain.fail(AssertionFailure{
 			Type:     AssertEqual,
 			Actual:   &AssertionValue{a.value},
@@ -1169,7 +1285,7 @@ func (a *Array) NotConsistsOf(values ...interface{}) *Array {
 		return a
 	}
 
-	if reflect.DeepEqual(expected, a.value) {
+	if canonEqual(a.value, expected) {
 		opChain.fail(AssertionFailure{
 			Type:     AssertNotEqual,
 			Actual:   &AssertionValue{a.value},
@@ -1734,7 +1850,7 @@ func (a *Array) NotOrdered(less ...func(x, y *Value) bool) *Array {
 func countElement(array []interface{}, element interface{}) int {
 	count := 0
 	for _, e := range array {
-		if reflect.DeepEqual(element, e) {
+		if canonEqual(e, element) {
 			count++
 		}
 	}
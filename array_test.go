@@ -27,6 +27,8 @@ func TestArray_FailedChain(t *testing.T) {
 		value.NotEmpty()
 		value.IsEqual([]interface{}{})
 		value.NotEqual([]interface{}{})
+		value.IsEqualWith([]interface{}{})
+		value.NotEqualWith([]interface{}{})
 		value.IsEqualUnordered([]interface{}{})
 		value.NotEqualUnordered([]interface{}{})
 		value.InList([]interface{}{})
@@ -358,6 +360,44 @@ func TestArray_IsEmpty(t *testing.T) {
 	})
 }
 
+func TestArray_IsEqualWith(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	value := NewArray(reporter, []interface{}{
+		map[string]interface{}{"id": 1, "name": "foo"},
+		map[string]interface{}{"id": 2, "name": "bar"},
+	})
+
+	expected := []interface{}{
+		map[string]interface{}{"id": 10, "name": "bar"},
+		map[string]interface{}{"id": 20, "name": "foo"},
+	}
+
+	value.IsEqualWith(expected, UnorderedAt("$"), IgnorePaths("$[*].id"))
+	value.chain.assertNotFailed(t)
+	value.chain.clearFailed()
+
+	value.NotEqualWith(expected, UnorderedAt("$"), IgnorePaths("$[*].id"))
+	value.chain.assertFailed(t)
+	value.chain.clearFailed()
+
+	value.IsEqualWith(expected, IgnorePaths("$[*].id"))
+	value.chain.assertFailed(t)
+	value.chain.clearFailed()
+
+	value.NotEqualWith(expected, IgnorePaths("$[*].id"))
+	value.chain.assertNotFailed(t)
+	value.chain.clearFailed()
+
+	value.IsEqualWith(expected, nil)
+	value.chain.assertFailed(t)
+	value.chain.clearFailed()
+
+	value.IsEqualWith(map[string]interface{}{})
+	value.chain.assertFailed(t)
+	value.chain.clearFailed()
+}
+
 func TestArray_IsEqual(t *testing.T) {
 	t.Run("empty", func(t *testing.T) {
 		reporter := newMockReporter(t)
@@ -1,5 +1,9 @@
 package httpexpect
 
+import (
+	"fmt"
+)
+
 // AssertionType defines type of performed assertion.
 type AssertionType uint
 
@@ -123,6 +127,12 @@ type AssertionContext struct {
 	//   {`foo`, `NotNull()`} // alias named foo
 	AliasedPath []string
 
+	// Source location of the assertion
+	// Points to the first caller outside of httpexpect, typically the line
+	// of the test or helper function that invoked the assertion
+	// Set only for failed assertions; zero if location is unknown
+	Location AssertionLocation
+
 	// Request being sent
 	// May be nil if request was not yet sent
 	Request *Request
@@ -136,6 +146,25 @@ type AssertionContext struct {
 	Environment *Environment
 }
 
+// AssertionLocation defines source code location of assertion.
+type AssertionLocation struct {
+	// Full path to source file
+	File string
+
+	// Line number in source file
+	Line int
+}
+
+// String returns location in "file:line" format, or empty string if
+// location is unknown.
+func (l AssertionLocation) String() string {
+	if l.File == "" {
+		return ""
+	}
+
+	return fmt.Sprintf("%s:%d", l.File, l.Line)
+}
+
 // AssertionFailure provides detailed information about failed assertion.
 //
 // [Type] and [Errors] fields are set for all assertions.
@@ -0,0 +1,150 @@
+package httpexpect
+
+import (
+	"fmt"
+	"sync"
+)
+
+// AssertionTypeSpec defines custom assertion type.
+//
+// Custom assertion types allow matchers built on top of httpexpect (see Chain)
+// to report failures that are formatted as nicely as failures of built-in
+// assertions, instead of reusing AssertValid with ad-hoc error messages.
+//
+// Use RegisterAssertionType to register spec and obtain AssertionType value,
+// which then may be used in AssertionFailure.Type.
+type AssertionTypeSpec struct {
+	// Name of assertion type, e.g. "AssertCurrency".
+	// Reported in FormatData.AssertType. Must be non-empty and unique.
+	Name string
+
+	// Whether AssertionFailure of this type should have Actual field.
+	// Default is FieldOptional.
+	Actual FieldRequirement
+
+	// Whether AssertionFailure of this type should have Expected field.
+	// Default is FieldOptional.
+	Expected FieldRequirement
+
+	// Label of expected value used in failure message,
+	// e.g. "currency" produces "expected currency:".
+	// If empty, "value" is used.
+	ExpectedKind string
+
+	// If true, failure message uses "denied" instead of "expected",
+	// like for AssertNotEqual, AssertNotBelongs, etc.
+	IsNegation bool
+
+	// If true, failure message uses "compared" instead of "expected",
+	// like for AssertLt, AssertGt, etc.
+	IsComparison bool
+
+	// If true, DefaultFormatter includes diff between expected and actual
+	// values, like for AssertEqual.
+	IsDiffable bool
+
+	// Optional hook to format actual value.
+	// If nil, value is formatted in the same way as for built-in assertions.
+	FormatActual func(value interface{}) string
+
+	// Optional hook to format expected value.
+	// May return multiple strings, which are printed on separate lines.
+	// If nil, value is formatted in the same way as for built-in assertions,
+	// and elements of AssertionList are printed on separate lines.
+	FormatExpected func(value interface{}) []string
+}
+
+// FieldRequirement defines whether AssertionFailure field is required
+// for custom assertion type.
+type FieldRequirement uint
+
+const (
+	// Field may be set or unset.
+	FieldOptional FieldRequirement = iota
+
+	// Field must be set.
+	FieldRequired
+
+	// Field must not be set.
+	FieldDenied
+)
+
+// RegisterAssertionType registers custom assertion type and returns
+// AssertionType value for it.
+//
+// Registration is global and usually happens during package initialization.
+// Panics if spec Name is empty or if a type with the same name is already
+// registered.
+//
+// Note that AssertionType.String() is generated for built-in types and
+// does not know about custom types; DefaultFormatter uses spec Name instead.
+//
+// Example:
+//
+//	var AssertCurrency = httpexpect.RegisterAssertionType(
+//	    httpexpect.AssertionTypeSpec{
+//	        Name:         "AssertCurrency",
+//	        Actual:       httpexpect.FieldRequired,
+//	        Expected:     httpexpect.FieldRequired,
+//	        ExpectedKind: "currency",
+//	    })
+//
+//	opChain.Fail(httpexpect.AssertionFailure{
+//	    Type:     AssertCurrency,
+//	    Actual:   &httpexpect.AssertionValue{Value: "USD"},
+//	    Expected: &httpexpect.AssertionValue{Value: "EUR"},
+//	    Errors: []error{
+//	        errors.New("expected: currencies are equal"),
+//	    },
+//	})
+func RegisterAssertionType(spec AssertionTypeSpec) AssertionType {
+	if spec.Name == "" {
+		panic("RegisterAssertionType: spec Name should be non-empty")
+	}
+
+	assertionRegistry.mu.Lock()
+	defer assertionRegistry.mu.Unlock()
+
+	for _, other := range assertionRegistry.specs {
+		if other.Name == spec.Name {
+			panic(fmt.Sprintf(
+				"RegisterAssertionType: type %q is already registered", spec.Name))
+		}
+	}
+
+	assertionRegistry.specs = append(assertionRegistry.specs, spec)
+
+	return assertionTypeCustom + AssertionType(len(assertionRegistry.specs)-1)
+}
+
+// first value used for custom assertion types
+const assertionTypeCustom AssertionType = 1 << 16
+
+var assertionRegistry struct {
+	mu    sync.RWMutex
+	specs []AssertionTypeSpec
+}
+
+func lookupAssertionType(assertType AssertionType) (AssertionTypeSpec, bool) {
+	if assertType < assertionTypeCustom {
+		return AssertionTypeSpec{}, false
+	}
+
+	assertionRegistry.mu.RLock()
+	defer assertionRegistry.mu.RUnlock()
+
+	index := int(assertType - assertionTypeCustom)
+	if index >= len(assertionRegistry.specs) {
+		return AssertionTypeSpec{}, false
+	}
+
+	return assertionRegistry.specs[index], true
+}
+
+func assertionTypeName(assertType AssertionType) string {
+	if spec, ok := lookupAssertionType(assertType); ok {
+		return spec.Name
+	}
+
+	return assertType.String()
+}
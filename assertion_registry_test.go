@@ -0,0 +1,308 @@
+package httpexpect
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testAssertCurrency = RegisterAssertionType(AssertionTypeSpec{
+		Name:         "AssertCurrency",
+		Actual:       FieldRequired,
+		Expected:     FieldRequired,
+		ExpectedKind: "currency",
+	})
+
+	testAssertNotCurrency = RegisterAssertionType(AssertionTypeSpec{
+		Name:         "AssertNotCurrency",
+		Actual:       FieldRequired,
+		Expected:     FieldRequired,
+		ExpectedKind: "currency",
+		IsNegation:   true,
+	})
+
+	testAssertBalance = RegisterAssertionType(AssertionTypeSpec{
+		Name:         "AssertBalance",
+		Actual:       FieldRequired,
+		Expected:     FieldRequired,
+		IsComparison: true,
+		FormatActual: func(value interface{}) string {
+			return "$" + value.(string)
+		},
+		FormatExpected: func(value interface{}) []string {
+			return []string{"$" + value.(string), "(inclusive)"}
+		},
+	})
+
+	testAssertLedger = RegisterAssertionType(AssertionTypeSpec{
+		Name:       "AssertLedger",
+		Actual:     FieldRequired,
+		Expected:   FieldOptional,
+		IsDiffable: true,
+	})
+
+	testAssertAudit = RegisterAssertionType(AssertionTypeSpec{
+		Name:     "AssertAudit",
+		Actual:   FieldDenied,
+		Expected: FieldDenied,
+	})
+)
+
+func TestAssertionRegistry_Register(t *testing.T) {
+	t.Run("distinct types", func(t *testing.T) {
+		types := []AssertionType{
+			testAssertCurrency,
+			testAssertNotCurrency,
+			testAssertBalance,
+			testAssertLedger,
+			testAssertAudit,
+		}
+
+		seen := map[AssertionType]bool{}
+		for _, typ := range types {
+			assert.False(t, seen[typ])
+			assert.GreaterOrEqual(t, uint(typ), uint(AssertNotBelongs)+1)
+			seen[typ] = true
+		}
+	})
+
+	t.Run("lookup", func(t *testing.T) {
+		spec, ok := lookupAssertionType(testAssertCurrency)
+		require.True(t, ok)
+		assert.Equal(t, "AssertCurrency", spec.Name)
+		assert.Equal(t, "currency", spec.ExpectedKind)
+
+		_, ok = lookupAssertionType(AssertEqual)
+		assert.False(t, ok)
+
+		_, ok = lookupAssertionType(assertionTypeCustom + 9999)
+		assert.False(t, ok)
+	})
+
+	t.Run("name", func(t *testing.T) {
+		assert.Equal(t, "AssertCurrency", assertionTypeName(testAssertCurrency))
+		assert.Equal(t, "AssertEqual", assertionTypeName(AssertEqual))
+	})
+
+	t.Run("empty name", func(t *testing.T) {
+		assert.Panics(t, func() {
+			RegisterAssertionType(AssertionTypeSpec{})
+		})
+	})
+
+	t.Run("duplicate name", func(t *testing.T) {
+		assert.Panics(t, func() {
+			RegisterAssertionType(AssertionTypeSpec{
+				Name: "AssertCurrency",
+			})
+		})
+	})
+}
+
+func TestAssertionRegistry_Validate(t *testing.T) {
+	errs := []error{
+		errors.New("test"),
+	}
+
+	tests := []struct {
+		name    string
+		failure AssertionFailure
+		wantErr string
+	}{
+		{
+			name: "valid",
+			failure: AssertionFailure{
+				Type:     testAssertCurrency,
+				Errors:   errs,
+				Actual:   &AssertionValue{"USD"},
+				Expected: &AssertionValue{"EUR"},
+			},
+		},
+		{
+			name: "missing actual",
+			failure: AssertionFailure{
+				Type:     testAssertCurrency,
+				Errors:   errs,
+				Expected: &AssertionValue{"EUR"},
+			},
+			wantErr: "Actual",
+		},
+		{
+			name: "missing expected",
+			failure: AssertionFailure{
+				Type:   testAssertCurrency,
+				Errors: errs,
+				Actual: &AssertionValue{"USD"},
+			},
+			wantErr: "Expected",
+		},
+		{
+			name: "optional expected",
+			failure: AssertionFailure{
+				Type:   testAssertLedger,
+				Errors: errs,
+				Actual: &AssertionValue{"USD"},
+			},
+		},
+		{
+			name: "denied actual",
+			failure: AssertionFailure{
+				Type:   testAssertAudit,
+				Errors: errs,
+				Actual: &AssertionValue{"USD"},
+			},
+			wantErr: "Actual",
+		},
+		{
+			name: "missing errors",
+			failure: AssertionFailure{
+				Type: testAssertAudit,
+			},
+			wantErr: "Errors",
+		},
+		{
+			name: "unregistered",
+			failure: AssertionFailure{
+				Type:   assertionTypeCustom + 9999,
+				Errors: errs,
+			},
+			wantErr: "unknown assertion type",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateAssertion(&tc.failure)
+			if tc.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestAssertionRegistry_Format(t *testing.T) {
+	t.Run("expected kind", func(t *testing.T) {
+		formatter := DefaultFormatter{}
+
+		data := formatter.buildFormatData(&AssertionContext{}, &AssertionFailure{
+			Type:     testAssertCurrency,
+			Actual:   &AssertionValue{"USD"},
+			Expected: &AssertionValue{"EUR"},
+		})
+
+		assert.Equal(t, "AssertCurrency", data.AssertType)
+		assert.True(t, data.HaveActual)
+		assert.Equal(t, `"USD"`, data.Actual)
+		assert.True(t, data.HaveExpected)
+		assert.Equal(t, "currency", data.ExpectedKind)
+		assert.Equal(t, []string{`"EUR"`}, data.Expected)
+		assert.False(t, data.IsNegation)
+		assert.False(t, data.IsComparison)
+		assert.False(t, data.HaveDiff)
+	})
+
+	t.Run("negation", func(t *testing.T) {
+		formatter := DefaultFormatter{}
+
+		data := formatter.buildFormatData(&AssertionContext{}, &AssertionFailure{
+			Type:     testAssertNotCurrency,
+			Actual:   &AssertionValue{"USD"},
+			Expected: &AssertionValue{AssertionList{"USD", "EUR"}},
+		})
+
+		assert.True(t, data.IsNegation)
+		assert.Equal(t, []string{`"USD"`, `"EUR"`}, data.Expected)
+	})
+
+	t.Run("hooks", func(t *testing.T) {
+		formatter := DefaultFormatter{}
+
+		data := formatter.buildFormatData(&AssertionContext{}, &AssertionFailure{
+			Type:     testAssertBalance,
+			Actual:   &AssertionValue{"10"},
+			Expected: &AssertionValue{"20"},
+		})
+
+		assert.True(t, data.IsComparison)
+		assert.Equal(t, "$10", data.Actual)
+		assert.Equal(t, "value", data.ExpectedKind)
+		assert.Equal(t, []string{"$20", "(inclusive)"}, data.Expected)
+	})
+
+	t.Run("diff", func(t *testing.T) {
+		failure := &AssertionFailure{
+			Type:     testAssertLedger,
+			Actual:   &AssertionValue{map[string]interface{}{"a": 1.0}},
+			Expected: &AssertionValue{map[string]interface{}{"a": 2.0}},
+		}
+
+		formatter := DefaultFormatter{}
+
+		data := formatter.buildFormatData(&AssertionContext{}, failure)
+		assert.True(t, data.HaveDiff)
+		assert.NotEmpty(t, data.Diff)
+
+		formatter = DefaultFormatter{DisableDiffs: true}
+
+		data = formatter.buildFormatData(&AssertionContext{}, failure)
+		assert.False(t, data.HaveDiff)
+	})
+
+	t.Run("no fields", func(t *testing.T) {
+		formatter := DefaultFormatter{}
+
+		data := formatter.buildFormatData(&AssertionContext{}, &AssertionFailure{
+			Type: testAssertAudit,
+		})
+
+		assert.Equal(t, "AssertAudit", data.AssertType)
+		assert.False(t, data.HaveActual)
+		assert.False(t, data.HaveExpected)
+	})
+
+	t.Run("message", func(t *testing.T) {
+		formatter := DefaultFormatter{}
+
+		msg := formatter.FormatFailure(&AssertionContext{}, &AssertionFailure{
+			Type:     testAssertNotCurrency,
+			Actual:   &AssertionValue{"USD"},
+			Expected: &AssertionValue{"USD"},
+			Errors: []error{
+				errors.New("expected: currencies are different"),
+			},
+		})
+
+		assert.True(t, strings.Contains(msg, "denied currency:\n  \"USD\""))
+		assert.True(t, strings.Contains(msg, "actual value:\n  \"USD\""))
+	})
+}
+
+func TestAssertionRegistry_Chain(t *testing.T) {
+	handler := &mockAssertionHandler{}
+
+	root := NewChainC("Money()", Config{
+		AssertionHandler: handler,
+	})
+
+	opChain := root.Enter("HasCurrency()")
+	opChain.Fail(AssertionFailure{
+		Type:     testAssertCurrency,
+		Actual:   &AssertionValue{"USD"},
+		Expected: &AssertionValue{"EUR"},
+		Errors: []error{
+			errors.New("expected: currencies are equal"),
+		},
+	})
+	opChain.Leave()
+
+	require.NotNil(t, handler.failure)
+	assert.Equal(t, testAssertCurrency, handler.failure.Type)
+	assert.True(t, root.Failed())
+}
@@ -81,6 +81,13 @@ func validateType(failure *AssertionFailure) error {
 		})
 	}
 
+	if spec, ok := lookupAssertionType(failure.Type); ok {
+		return validateTraits(failure, fieldTraits{
+			Actual:   convertFieldRequirement(spec.Actual),
+			Expected: convertFieldRequirement(spec.Expected),
+		})
+	}
+
 	return fmt.Errorf("unknown assertion type %s", failure.Type)
 }
 
@@ -92,6 +99,17 @@ const (
 	fieldDenied
 )
 
+func convertFieldRequirement(req FieldRequirement) fieldRequirement {
+	switch req {
+	case FieldRequired:
+		return fieldRequired
+	case FieldDenied:
+		return fieldDenied
+	default:
+		return fieldOptional
+	}
+}
+
 type fieldTraits struct {
 	Actual   fieldRequirement
 	Expected fieldRequirement
@@ -86,6 +86,26 @@ func (b *Boolean) Alias(name string) *Boolean {
 	return b
 }
 
+// Chain returns public handle to assertion chain of Boolean, which may be
+// used to implement custom matchers. See Chain for details.
+func (b *Boolean) Chain() *Chain {
+	return &Chain{chain: b.chain}
+}
+
+// AsWarning is similar to Value.AsWarning.
+func (b *Boolean) AsWarning() *Boolean {
+	opChain := b.chain.enter("AsWarning()")
+	defer opChain.leave()
+
+	warnChain := b.chain.clone()
+	warnChain.setWarning()
+
+	return &Boolean{
+		chain: warnChain,
+		value: b.value,
+	}
+}
+
 // Path is similar to Value.Path.
 func (b *Boolean) Path(path string) *Value {
 	opChain := b.chain.enter("Path(%q)", path)
@@ -0,0 +1,357 @@
+package httpexpect
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CacheControl provides methods to inspect directives of "Cache-Control"
+// header (RFC 9111).
+type CacheControl struct {
+	noCopy noCopy
+	chain  *chain
+	value  map[string]string
+}
+
+func newCacheControl(parent *chain, val map[string]string) *CacheControl {
+	c := &CacheControl{chain: parent.clone(), value: nil}
+
+	opChain := c.chain.enter("")
+	defer opChain.leave()
+
+	if val == nil {
+		opChain.fail(AssertionFailure{
+			Type:   AssertNotNil,
+			Actual: &AssertionValue{val},
+			Errors: []error{
+				errors.New("expected: non-nil cache control directives"),
+			},
+		})
+	} else {
+		c.value = val
+	}
+
+	return c
+}
+
+// Raw returns underlying map of directives, with lower-cased directive
+// names as keys and unquoted directive arguments as values.
+//
+// Directives without arguments have empty values.
+//
+// Example:
+//
+//	cc := resp.CacheControl()
+//	assert.Equal(t, map[string]string{"max-age": "60"}, cc.Raw())
+func (c *CacheControl) Raw() map[string]string {
+	return c.value
+}
+
+// Alias is similar to Value.Alias.
+func (c *CacheControl) Alias(name string) *CacheControl {
+	opChain := c.chain.enter("Alias(%q)", name)
+	defer opChain.leave()
+
+	c.chain.setAlias(name)
+	return c
+}
+
+// Chain returns public handle to assertion chain of CacheControl, which may be
+// used to implement custom matchers. See Chain for details.
+func (c *CacheControl) Chain() *Chain {
+	return &Chain{chain: c.chain}
+}
+
+// AsWarning is similar to Value.AsWarning.
+func (c *CacheControl) AsWarning() *CacheControl {
+	opChain := c.chain.enter("AsWarning()")
+	defer opChain.leave()
+
+	warnChain := c.chain.clone()
+	warnChain.setWarning()
+
+	return &CacheControl{
+		chain: warnChain,
+		value: c.value,
+	}
+}
+
+// Directives returns a new Object instance with all directives.
+//
+// Keys are lower-cased directive names, values are strings with directive
+// arguments, or empty strings for directives without arguments.
+//
+// Example:
+//
+//	cc := resp.CacheControl()
+//	cc.Directives().IsEqual(map[string]interface{}{
+//		"public":  "",
+//		"max-age": "60",
+//	})
+func (c *CacheControl) Directives() *Object {
+	opChain := c.chain.enter("Directives()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newObject(opChain, nil)
+	}
+
+	directives := map[string]interface{}{}
+	for k, v := range c.value {
+		directives[k] = v
+	}
+
+	return newObject(opChain, directives)
+}
+
+// HasDirective succeeds if given directive is present.
+//
+// Directive names are compared case-insensitively.
+//
+// Example:
+//
+//	cc := resp.CacheControl()
+//	cc.HasDirective("no-store")
+func (c *CacheControl) HasDirective(name string) *CacheControl {
+	opChain := c.chain.enter("HasDirective(%q)", name)
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return c
+	}
+
+	if _, ok := c.value[strings.ToLower(name)]; !ok {
+		opChain.fail(AssertionFailure{
+			Type:     AssertContainsKey,
+			Actual:   &AssertionValue{c.value},
+			Expected: &AssertionValue{name},
+			Errors: []error{
+				errors.New("expected: cache control contains given directive"),
+			},
+		})
+	}
+
+	return c
+}
+
+// NotHasDirective succeeds if given directive is not present.
+//
+// Directive names are compared case-insensitively.
+//
+// Example:
+//
+//	cc := resp.CacheControl()
+//	cc.NotHasDirective("private")
+func (c *CacheControl) NotHasDirective(name string) *CacheControl {
+	opChain := c.chain.enter("NotHasDirective(%q)", name)
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return c
+	}
+
+	if _, ok := c.value[strings.ToLower(name)]; ok {
+		opChain.fail(AssertionFailure{
+			Type:     AssertNotContainsKey,
+			Actual:   &AssertionValue{c.value},
+			Expected: &AssertionValue{name},
+			Errors: []error{
+				errors.New("expected: cache control does not contain given directive"),
+			},
+		})
+	}
+
+	return c
+}
+
+// Directive returns a new String instance with argument of given directive.
+//
+// If directive is present but has no argument, returned String is empty.
+// If directive is not present, failure is reported.
+//
+// Example:
+//
+//	cc := resp.CacheControl()
+//	cc.Directive("private").IsEqual("Set-Cookie")
+func (c *CacheControl) Directive(name string) *String {
+	opChain := c.chain.enter("Directive(%q)", name)
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newString(opChain, "")
+	}
+
+	value, ok := c.lookup(opChain, name)
+	if !ok {
+		return newString(opChain, "")
+	}
+
+	return newString(opChain, value)
+}
+
+// MaxAge returns a new Duration instance with value of "max-age" directive.
+//
+// If directive is not present or is not a non-negative integer number
+// of seconds, failure is reported.
+//
+// Example:
+//
+//	cc := resp.CacheControl()
+//	cc.MaxAge().IsEqual(time.Minute)
+func (c *CacheControl) MaxAge() *Duration {
+	opChain := c.chain.enter("MaxAge()")
+	defer opChain.leave()
+
+	return c.duration(opChain, "max-age")
+}
+
+// SMaxAge returns a new Duration instance with value of "s-maxage" directive,
+// which applies to shared caches like CDNs and proxies.
+//
+// If directive is not present or is not a non-negative integer number
+// of seconds, failure is reported.
+//
+// Example:
+//
+//	cc := resp.CacheControl()
+//	cc.SMaxAge().IsEqual(time.Hour)
+func (c *CacheControl) SMaxAge() *Duration {
+	opChain := c.chain.enter("SMaxAge()")
+	defer opChain.leave()
+
+	return c.duration(opChain, "s-maxage")
+}
+
+func (c *CacheControl) duration(opChain *chain, name string) *Duration {
+	if opChain.failed() {
+		return newDuration(opChain, nil)
+	}
+
+	value, ok := c.lookup(opChain, name)
+	if !ok {
+		return newDuration(opChain, nil)
+	}
+
+	seconds, err := parseDeltaSeconds(value)
+	if err != nil {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{value},
+			Errors: []error{
+				fmt.Errorf("invalid %q cache control directive", name),
+				err,
+			},
+		})
+		return newDuration(opChain, nil)
+	}
+
+	return newDuration(opChain, &seconds)
+}
+
+func (c *CacheControl) lookup(opChain *chain, name string) (string, bool) {
+	value, ok := c.value[strings.ToLower(name)]
+	if !ok {
+		opChain.fail(AssertionFailure{
+			Type:     AssertContainsKey,
+			Actual:   &AssertionValue{c.value},
+			Expected: &AssertionValue{name},
+			Errors: []error{
+				errors.New("expected: cache control contains given directive"),
+			},
+		})
+		return "", false
+	}
+
+	return value, true
+}
+
+// Parse values of "Cache-Control" header fields into map of directives.
+// If directive is repeated, first occurrence wins.
+func parseCacheControl(values []string) (map[string]string, error) {
+	directives := map[string]string{}
+
+	for _, value := range values {
+		p := headerParser{input: value}
+
+		for {
+			p.skipSpaceAnd(',')
+			if p.done() {
+				break
+			}
+
+			name := strings.ToLower(p.parseToken())
+			if name == "" {
+				return nil, fmt.Errorf("expected directive name at position %d in %q",
+					p.pos, p.input)
+			}
+
+			var arg string
+
+			p.skipSpaceAnd(0)
+			if p.peek() == '=' {
+				p.pos++
+				p.skipSpaceAnd(0)
+
+				if p.peek() == '"' {
+					var err error
+					arg, err = p.parseQuoted()
+					if err != nil {
+						return nil, err
+					}
+				} else {
+					arg = p.parseToken()
+				}
+			}
+
+			p.skipSpaceAnd(0)
+			if !p.done() && p.peek() != ',' {
+				return nil, fmt.Errorf("expected ',' at position %d in %q",
+					p.pos, p.input)
+			}
+
+			if _, ok := directives[name]; !ok {
+				directives[name] = arg
+			}
+		}
+	}
+
+	return directives, nil
+}
+
+const maxDeltaSeconds = 2147483648
+
+// Parse non-negative integer number of seconds, used in "max-age"
+// directive and "Age" header.
+func parseDeltaSeconds(value string) (time.Duration, error) {
+	if value == "" || strings.TrimLeft(value, "0123456789") != "" {
+		return 0, fmt.Errorf("expected non-negative integer, got %q", value)
+	}
+
+	// too large values are capped, see RFC 9111, section 1.2.2
+	n, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || n > maxDeltaSeconds {
+		n = maxDeltaSeconds
+	}
+
+	return time.Duration(n) * time.Second, nil
+}
+
+// Check that value is a strong or weak entity tag, see RFC 9110, section 8.8.3.
+func isValidETag(value string) bool {
+	value = strings.TrimPrefix(value, "W/")
+
+	if len(value) < 2 || value[0] != '"' || value[len(value)-1] != '"' {
+		return false
+	}
+
+	for _, c := range []byte(value[1 : len(value)-1]) {
+		if c == '"' || c < 0x21 || c == 0x7f {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,201 @@
+package httpexpect
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCacheControl_FailedChain(t *testing.T) {
+	check := func(value *CacheControl) {
+		value.chain.assertFailed(t)
+
+		value.Alias("foo")
+
+		value.Directives().chain.assertFailed(t)
+		value.HasDirective("public")
+		value.NotHasDirective("public")
+		value.Directive("public").chain.assertFailed(t)
+		value.MaxAge().chain.assertFailed(t)
+		value.SMaxAge().chain.assertFailed(t)
+	}
+
+	t.Run("failed chain", func(t *testing.T) {
+		chain := newMockChain(t)
+		chain.setFailed()
+
+		value := newCacheControl(chain, map[string]string{})
+
+		check(value)
+	})
+
+	t.Run("nil value", func(t *testing.T) {
+		chain := newMockChain(t)
+
+		value := newCacheControl(chain, nil)
+
+		check(value)
+	})
+}
+
+func TestCacheControl_Alias(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	parent := newChainWithDefaults("CacheControl()", reporter)
+	value := newCacheControl(parent, map[string]string{})
+	assert.Equal(t, []string{"CacheControl()"}, value.chain.context.Path)
+	assert.Equal(t, []string{"CacheControl()"}, value.chain.context.AliasedPath)
+
+	value.Alias("foo")
+	assert.Equal(t, []string{"CacheControl()"}, value.chain.context.Path)
+	assert.Equal(t, []string{"foo"}, value.chain.context.AliasedPath)
+
+	childValue := value.Directives()
+	assert.Equal(t, []string{"CacheControl()", "Directives()"},
+		childValue.chain.context.Path)
+	assert.Equal(t, []string{"foo", "Directives()"},
+		childValue.chain.context.AliasedPath)
+}
+
+func TestCacheControl_Directives(t *testing.T) {
+	directives, err := parseCacheControl([]string{
+		`public, max-age=60, s-maxage="3600"`,
+		`No-Transform`,
+	})
+	require.NoError(t, err)
+
+	value := newCacheControl(newMockChain(t), directives)
+
+	assert.Equal(t, directives, value.Raw())
+
+	value.Directives().IsEqual(map[string]interface{}{
+		"public":       "",
+		"max-age":      "60",
+		"s-maxage":     "3600",
+		"no-transform": "",
+	})
+
+	value.HasDirective("public")
+	value.HasDirective("NO-TRANSFORM")
+	value.NotHasDirective("private")
+	value.NotHasDirective("no-store")
+
+	value.Directive("public").IsEmpty()
+	value.Directive("max-age").IsEqual("60")
+
+	value.MaxAge().IsEqual(time.Minute)
+	value.SMaxAge().IsEqual(time.Hour)
+
+	value.chain.assertNotFailed(t)
+
+	t.Run("missing", func(t *testing.T) {
+		cases := []func(*CacheControl){
+			func(c *CacheControl) { c.HasDirective("private") },
+			func(c *CacheControl) { c.NotHasDirective("public") },
+			func(c *CacheControl) { c.Directive("private") },
+		}
+
+		for _, fn := range cases {
+			value := newCacheControl(newMockChain(t), directives)
+			fn(value)
+			value.chain.assertFailed(t)
+		}
+	})
+}
+
+func TestCacheControl_Durations(t *testing.T) {
+	cases := []struct {
+		name   string
+		header string
+		result time.Duration
+		fail   bool
+	}{
+		{name: "zero", header: "max-age=0", result: 0},
+		{name: "seconds", header: "max-age=10", result: 10 * time.Second},
+		{name: "quoted", header: `max-age="10"`, result: 10 * time.Second},
+		{name: "capped", header: "max-age=99999999999999999999",
+			result: 2147483648 * time.Second},
+		{name: "missing", header: "no-cache", fail: true},
+		{name: "empty", header: "max-age", fail: true},
+		{name: "negative", header: "max-age=-1", fail: true},
+		{name: "float", header: "max-age=1.5", fail: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			directives, err := parseCacheControl([]string{tc.header})
+			require.NoError(t, err)
+
+			value := newCacheControl(newMockChain(t), directives)
+			maxAge := value.MaxAge()
+
+			if tc.fail {
+				value.chain.assertFailed(t)
+				maxAge.chain.assertFailed(t)
+			} else {
+				value.chain.assertNotFailed(t)
+				assert.Equal(t, tc.result, maxAge.Raw())
+			}
+		})
+	}
+}
+
+func TestCacheControl_Parse(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		cases := []struct {
+			name   string
+			values []string
+			result map[string]string
+		}{
+			{
+				name:   "empty",
+				values: nil,
+				result: map[string]string{},
+			},
+			{
+				name:   "spaces and empty items",
+				values: []string{` , no-store ,, private `},
+				result: map[string]string{"no-store": "", "private": ""},
+			},
+			{
+				name:   "quoted list",
+				values: []string{`private="Set-Cookie, Authorization", max-age=5`},
+				result: map[string]string{
+					"private": "Set-Cookie, Authorization",
+					"max-age": "5",
+				},
+			},
+			{
+				name:   "first wins",
+				values: []string{`max-age=5, MAX-AGE=10`, `max-age=20`},
+				result: map[string]string{"max-age": "5"},
+			},
+		}
+
+		for _, tc := range cases {
+			t.Run(tc.name, func(t *testing.T) {
+				directives, err := parseCacheControl(tc.values)
+				require.NoError(t, err)
+				assert.Equal(t, tc.result, directives)
+			})
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		cases := []string{
+			`=10`,
+			`max-age=10 public`,
+			`max-age=10; public`,
+			`private="unterminated`,
+		}
+
+		for _, value := range cases {
+			t.Run(value, func(t *testing.T) {
+				_, err := parseCacheControl([]string{value})
+				assert.Error(t, err)
+			})
+		}
+	})
+}
@@ -1,13 +1,38 @@
 package httpexpect
 
 import (
+	"bytes"
+	"encoding"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"math"
+	"math/big"
 	"reflect"
+	"sort"
+	"strconv"
+	"strings"
 )
 
 func canonNumber(opChain *chain, in interface{}) (out float64, ok bool) {
+	switch num := in.(type) {
+	case json.Number, *big.Int, *big.Float, *big.Rat:
+		if r, valid := canonRat(in); valid {
+			out, _ = r.Float64()
+			return out, true
+		}
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{in},
+			Errors: []error{
+				errors.New("expected: valid number"),
+				fmt.Errorf("can't parse %v", num),
+			},
+		})
+		return 0, false
+	}
+
 	ok = true
 	defer func() {
 		if err := recover(); err != nil {
@@ -26,6 +51,45 @@ func canonNumber(opChain *chain, in interface{}) (out float64, ok bool) {
 	return
 }
 
+// Convert number to canonical form, i.e. float64, or json.Number if number
+// can't be represented by float64 without loss of precision, like uint64
+// above 2^53 or json.Number with many significant digits.
+func canonNumberExact(opChain *chain, in interface{}) (interface{}, bool) {
+	switch in.(type) {
+	case json.Number, *big.Int, *big.Float, *big.Rat:
+		if num, ok := canonRatNumber(in); ok {
+			return num, true
+		}
+
+	default:
+		switch reflect.ValueOf(in).Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+			reflect.Uintptr:
+			if num, ok := canonRatNumber(in); ok {
+				return num, true
+			}
+		}
+	}
+
+	return canonNumber(opChain, in)
+}
+
+func canonRatNumber(in interface{}) (interface{}, bool) {
+	r, ok := canonRat(in)
+	if !ok {
+		return nil, false
+	}
+
+	// numbers without finite decimal expansion are rounded to float64
+	str := formatRat(r)
+	if sr, ok := new(big.Rat).SetString(str); !ok || sr.Cmp(r) != 0 {
+		return nil, false
+	}
+
+	return canonJSONNumber(json.Number(str)), true
+}
+
 func canonArray(opChain *chain, in interface{}) ([]interface{}, bool) {
 	var out []interface{}
 	data, ok := canonValue(opChain, in)
@@ -63,7 +127,7 @@ func canonMap(opChain *chain, in interface{}) (map[string]interface{}, bool) {
 }
 
 func canonValue(opChain *chain, in interface{}) (interface{}, bool) {
-	b, err := json.Marshal(in)
+	out, err := canonWalk(reflect.ValueOf(in), nil)
 	if err != nil {
 		opChain.fail(AssertionFailure{
 			Type:   AssertValid,
@@ -76,20 +140,170 @@ func canonValue(opChain *chain, in interface{}) (interface{}, bool) {
 		return nil, false
 	}
 
+	return out, true
+}
+
+// Convert value to canonical form, keeping placeholders as is.
+//
+// Sub-values that can't contain placeholders are converted by marshaling
+// them to JSON and back. Other sub-values are traversed, so that
+// placeholders found inside them are preserved in canonical value.
+func canonWalk(v reflect.Value, seen map[uintptr]bool) (interface{}, error) {
+	if !v.IsValid() {
+		return nil, nil
+	}
+
+	if !mayHavePlaceholders(v.Type()) {
+		return canonLeaf(v)
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return nil, nil
+		}
+		if p, ok := v.Interface().(*Placeholder); ok {
+			return p, nil
+		}
+		if seen[v.Pointer()] {
+			return nil, fmt.Errorf("json: unsupported value: encountered a cycle via %s",
+				v.Type())
+		}
+		if seen == nil {
+			seen = map[uintptr]bool{}
+		}
+		seen[v.Pointer()] = true
+		defer delete(seen, v.Pointer())
+
+		return canonWalk(v.Elem(), seen)
+
+	case reflect.Interface:
+		if v.IsNil() {
+			return nil, nil
+		}
+		return canonWalk(v.Elem(), seen)
+
+	case reflect.Map:
+		if v.IsNil() {
+			return nil, nil
+		}
+		out := make(map[string]interface{}, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			key, err := canonMapKey(iter.Key())
+			if err != nil {
+				return nil, err
+			}
+			if out[key], err = canonWalk(iter.Value(), seen); err != nil {
+				return nil, err
+			}
+		}
+		return out, nil
+
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			return nil, nil
+		}
+		out := make([]interface{}, v.Len())
+		for n := range out {
+			var err error
+			if out[n], err = canonWalk(v.Index(n), seen); err != nil {
+				return nil, err
+			}
+		}
+		return out, nil
+
+	case reflect.Struct:
+		// let encoding/json handle field names, omitempty, etc., and then
+		// replace members that may contain placeholders
+		leaf, err := canonLeaf(v)
+		if err != nil {
+			return nil, err
+		}
+		out, ok := leaf.(map[string]interface{})
+		if !ok {
+			return leaf, nil
+		}
+		for _, field := range strictDecodeFields(v.Type()) {
+			if _, ok := out[field.name]; !ok || !mayHavePlaceholders(field.typ) {
+				continue
+			}
+			fv, ok := fieldByIndex(v, field.index)
+			if !ok {
+				continue
+			}
+			if out[field.name], err = canonWalk(fv, seen); err != nil {
+				return nil, err
+			}
+		}
+		return out, nil
+	}
+
+	return canonLeaf(v)
+}
+
+// Convert value to canonical form by marshaling it to JSON and back.
+func canonLeaf(v reflect.Value) (interface{}, error) {
+	var in interface{}
+	if v.CanAddr() {
+		// like encoding/json, use pointer receiver methods when possible
+		in = v.Addr().Interface()
+	} else {
+		in = v.Interface()
+	}
+
+	b, err := json.Marshal(in)
+	if err != nil {
+		return nil, err
+	}
+
 	var out interface{}
-	if err := json.Unmarshal(b, &out); err != nil {
-		opChain.fail(AssertionFailure{
-			Type:   AssertValid,
-			Actual: &AssertionValue{in},
-			Errors: []error{
-				errors.New("expected: unmarshalable value"),
-				err,
-			},
-		})
-		return nil, false
+	if err := unmarshalUseNumber(b, &out); err != nil {
+		return nil, err
 	}
 
-	return out, true
+	return canonJSONNumbers(out), nil
+}
+
+// Convert map key to string in the same way as encoding/json does.
+func canonMapKey(k reflect.Value) (string, error) {
+	if k.Kind() == reflect.String {
+		return k.String(), nil
+	}
+
+	if tm, ok := k.Interface().(encoding.TextMarshaler); ok {
+		if k.Kind() == reflect.Ptr && k.IsNil() {
+			return "", nil
+		}
+		b, err := tm.MarshalText()
+		return string(b), err
+	}
+
+	switch k.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(k.Int(), 10), nil
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Uintptr:
+		return strconv.FormatUint(k.Uint(), 10), nil
+	}
+
+	return "", fmt.Errorf("json: unsupported type: %s", k.Type())
+}
+
+// Get struct field by index sequence; returns false if it goes through
+// nil embedded pointer.
+func fieldByIndex(v reflect.Value, index []int) (reflect.Value, bool) {
+	for n, i := range index {
+		if n > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				return reflect.Value{}, false
+			}
+			v = v.Elem()
+		}
+		v = v.Field(i)
+	}
+	return v, true
 }
 
 func canonDecode(opChain *chain, value interface{}, target interface{}) {
@@ -126,3 +340,425 @@ func canonDecode(opChain *chain, value interface{}, target interface{}) {
 		return
 	}
 }
+
+func canonDecodeStrict(opChain *chain, value interface{}, target interface{}) {
+	if target == nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected nil target argument"),
+			},
+		})
+		return
+	}
+
+	canonDecode(opChain, value, target)
+
+	if opChain.failed() {
+		return
+	}
+
+	var diffs []error
+	strictDecodeValue(&diffs, nil, value, reflect.TypeOf(target))
+
+	if len(diffs) != 0 {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{value},
+			Errors: append([]error{
+				errors.New("expected: value can be strictly decoded into target argument"),
+			}, diffs...),
+		})
+	}
+}
+
+var jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+
+// Check that canonical value has no members that are not present in
+// target type, and has all members required by target type.
+func strictDecodeValue(
+	diffs *[]error, path []interface{}, value interface{}, typ reflect.Type,
+) {
+	for typ.Kind() == reflect.Ptr {
+		if typ.Implements(jsonUnmarshalerType) {
+			return
+		}
+		typ = typ.Elem()
+	}
+
+	if reflect.PtrTo(typ).Implements(jsonUnmarshalerType) {
+		return
+	}
+
+	switch typ.Kind() {
+	case reflect.Struct:
+		if obj, ok := value.(map[string]interface{}); ok {
+			strictDecodeStruct(diffs, path, obj, typ)
+		}
+
+	case reflect.Map:
+		if obj, ok := value.(map[string]interface{}); ok {
+			keys := make([]string, 0, len(obj))
+			for k := range obj {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+
+			for _, k := range keys {
+				strictDecodeValue(diffs, appendEqualPath(path, k), obj[k], typ.Elem())
+			}
+		}
+
+	case reflect.Slice, reflect.Array:
+		if arr, ok := value.([]interface{}); ok {
+			for n, elem := range arr {
+				strictDecodeValue(diffs, appendEqualPath(path, n), elem, typ.Elem())
+			}
+		}
+	}
+}
+
+func strictDecodeStruct(
+	diffs *[]error, path []interface{}, obj map[string]interface{}, typ reflect.Type,
+) {
+	fields := strictDecodeFields(typ)
+
+	keys := make([]string, 0, len(obj))
+	for k := range obj {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	matched := map[string]bool{}
+
+	for _, k := range keys {
+		keyPath := appendEqualPath(path, k)
+
+		field, ok := strictDecodeLookup(fields, k)
+		if !ok {
+			*diffs = append(*diffs, fmt.Errorf("at %s: unknown field",
+				formatEqualPath(keyPath)))
+			continue
+		}
+
+		matched[field.name] = true
+		strictDecodeValue(diffs, keyPath, obj[k], field.typ)
+	}
+
+	for _, field := range fields {
+		if field.required && !matched[field.name] {
+			*diffs = append(*diffs, fmt.Errorf("at %s: missing required field",
+				formatEqualPath(appendEqualPath(path, field.name))))
+		}
+	}
+}
+
+type strictDecodeField struct {
+	name      string
+	index     []int
+	typ       reflect.Type
+	required  bool // "required" option in json tag or rule in validate tag
+	nonEmpty  bool // "required" rule in validate tag
+	omitEmpty bool
+	quoted    bool
+}
+
+// Collect JSON fields of struct type, including fields of embedded
+// structs, in the same way as encoding/json does.
+//
+// Field is required if it has "required" option in json tag or "required"
+// rule in validate tag (github.com/go-playground/validator).
+func strictDecodeFields(typ reflect.Type) []strictDecodeField {
+	var fields []strictDecodeField
+
+	for i := 0; i < typ.NumField(); i++ {
+		sf := typ.Field(i)
+
+		tag := sf.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+
+		name, opts := tag, ""
+		if idx := strings.Index(tag, ","); idx >= 0 {
+			name, opts = tag[:idx], tag[idx+1:]
+		}
+
+		ft := sf.Type
+		if sf.Anonymous && name == "" {
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				for _, field := range strictDecodeFields(ft) {
+					field.index = append([]int{i}, field.index...)
+					fields = append(fields, field)
+				}
+				continue
+			}
+		}
+
+		if sf.PkgPath != "" {
+			continue
+		}
+
+		if name == "" {
+			name = sf.Name
+		}
+
+		field := strictDecodeField{
+			name:  name,
+			index: []int{i},
+			typ:   sf.Type,
+		}
+
+		for _, opt := range strings.Split(opts, ",") {
+			switch opt {
+			case "required":
+				field.required = true
+			case "omitempty":
+				field.omitEmpty = true
+			case "string":
+				field.quoted = true
+			}
+		}
+
+		for _, rule := range strings.Split(sf.Tag.Get("validate"), ",") {
+			if rule == "required" {
+				field.required = true
+				field.nonEmpty = true
+			}
+		}
+
+		fields = append(fields, field)
+	}
+
+	return fields
+}
+
+// Find field for JSON key; like encoding/json, prefer exact match,
+// but fall back to case-insensitive match.
+func strictDecodeLookup(
+	fields []strictDecodeField, key string,
+) (strictDecodeField, bool) {
+	for _, field := range fields {
+		if field.name == key {
+			return field, true
+		}
+	}
+	for _, field := range fields {
+		if strings.EqualFold(field.name, key) {
+			return field, true
+		}
+	}
+	return strictDecodeField{}, false
+}
+
+// Decode JSON, keeping numbers as json.Number.
+func unmarshalUseNumber(data []byte, target interface{}) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	if err := dec.Decode(target); err != nil {
+		return err
+	}
+
+	if _, err := dec.Token(); err != io.EOF {
+		return errors.New("invalid character after top-level value")
+	}
+
+	return nil
+}
+
+// Convert json.Number values to float64, unless conversion would lose
+// precision; such numbers are kept as json.Number in normalized form.
+//
+// Thanks to normalization, two numbers are equal if and only if their
+// canonical forms are equal.
+func canonJSONNumbers(value interface{}) interface{} {
+	switch v := value.(type) {
+	case json.Number:
+		return canonJSONNumber(v)
+
+	case []interface{}:
+		for n := range v {
+			v[n] = canonJSONNumbers(v[n])
+		}
+
+	case map[string]interface{}:
+		for k := range v {
+			v[k] = canonJSONNumbers(v[k])
+		}
+	}
+
+	return value
+}
+
+func canonJSONNumber(num json.Number) interface{} {
+	r, ok := new(big.Rat).SetString(string(num))
+	if !ok {
+		return num
+	}
+
+	// keep float64 if its shortest representation is the same number
+	f, err := strconv.ParseFloat(string(num), 64)
+	if err == nil {
+		fr, ok := new(big.Rat).SetString(strconv.FormatFloat(f, 'g', -1, 64))
+		if ok && fr.Cmp(r) == 0 {
+			return f
+		}
+	}
+
+	return json.Number(formatRat(r))
+}
+
+// Check if canonical values are equal.
+//
+// If actual number is float64 and expected number is json.Number, i.e.
+// actual value was decoded without UseNumber option and has already lost
+// precision, expected number is rounded to float64 before comparison.
+// Otherwise, numbers are compared exactly.
+func canonEqual(actual, expected interface{}) bool {
+	switch av := actual.(type) {
+	case float64:
+		if ev, ok := expected.(json.Number); ok {
+			return av == canonFloat(ev)
+		}
+
+	case []interface{}:
+		ev, ok := expected.([]interface{})
+		if !ok || len(av) != len(ev) || (av == nil) != (ev == nil) {
+			return false
+		}
+		for n := range av {
+			if !canonEqual(av[n], ev[n]) {
+				return false
+			}
+		}
+		return true
+
+	case map[string]interface{}:
+		ev, ok := expected.(map[string]interface{})
+		if !ok || len(av) != len(ev) || (av == nil) != (ev == nil) {
+			return false
+		}
+		for k, ae := range av {
+			ee, ok := ev[k]
+			if !ok || !canonEqual(ae, ee) {
+				return false
+			}
+		}
+		return true
+	}
+
+	return reflect.DeepEqual(actual, expected)
+}
+
+// Round json.Number to nearest float64.
+func canonFloat(num json.Number) float64 {
+	r, ok := new(big.Rat).SetString(string(num))
+	if !ok {
+		return math.NaN()
+	}
+	f, _ := r.Float64()
+	return f
+}
+
+// Check if canonical value is a number.
+func isNumber(value interface{}) bool {
+	switch value.(type) {
+	case float64, json.Number:
+		return true
+	}
+	return false
+}
+
+// Convert number in canonical or arbitrary-precision form to big.Rat.
+func canonRat(value interface{}) (*big.Rat, bool) {
+	switch v := value.(type) {
+	case float64:
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return nil, false
+		}
+		return new(big.Rat).SetFloat64(v), true
+
+	case json.Number:
+		return new(big.Rat).SetString(string(v))
+
+	case string:
+		return new(big.Rat).SetString(v)
+
+	case *big.Int:
+		if v == nil {
+			return nil, false
+		}
+		return new(big.Rat).SetInt(v), true
+
+	case *big.Float:
+		if v == nil || v.IsInf() {
+			return nil, false
+		}
+		r, _ := v.Rat(nil)
+		return r, true
+
+	case *big.Rat:
+		if v == nil {
+			return nil, false
+		}
+		return new(big.Rat).Set(v), true
+	}
+
+	rv := reflect.ValueOf(value)
+
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return new(big.Rat).SetInt64(rv.Int()), true
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Uintptr:
+		return new(big.Rat).SetInt(new(big.Int).SetUint64(rv.Uint())), true
+
+	case reflect.Float32, reflect.Float64:
+		return canonRat(rv.Float())
+	}
+
+	return nil, false
+}
+
+// Format rational number with finite decimal expansion, without exponent.
+func formatRat(r *big.Rat) string {
+	if r.IsInt() {
+		return r.Num().String()
+	}
+
+	// number of fractional digits is the maximum power of 2 and 5
+	// in the denominator
+	twos, fives := 0, 0
+
+	d := new(big.Int).Set(r.Denom())
+	rem := new(big.Int)
+
+	for _, f := range []struct {
+		factor *big.Int
+		count  *int
+	}{
+		{big.NewInt(2), &twos},
+		{big.NewInt(5), &fives},
+	} {
+		for {
+			q, m := new(big.Int).QuoRem(d, f.factor, rem)
+			if m.Sign() != 0 {
+				break
+			}
+			d = q
+			*f.count++
+		}
+	}
+
+	digits := twos
+	if fives > digits {
+		digits = fives
+	}
+
+	return r.FloatString(digits)
+}
@@ -1,9 +1,13 @@
 package httpexpect
 
 import (
+	"encoding/json"
+	"math/big"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestCanon_Number(t *testing.T) {
@@ -180,3 +184,232 @@ func TestCannon_Decode(t *testing.T) {
 		chain.assertFailed(t)
 	})
 }
+
+type strictCustom struct {
+	raw string
+}
+
+func (c *strictCustom) UnmarshalJSON(b []byte) error {
+	c.raw = string(b)
+	return nil
+}
+
+func TestCanon_DecodeStrict(t *testing.T) {
+	type Base struct {
+		ID int `json:"id,required"`
+	}
+
+	type Item struct {
+		Name  string `json:"name,required"`
+		Price int    `json:"price"`
+	}
+
+	type S struct {
+		Base
+		Title  string          `json:"title"`
+		Items  []Item          `json:"items"`
+		Index  map[string]Item `json:"index"`
+		Custom strictCustom    `json:"custom"`
+		Any    interface{}     `json:"any"`
+		Hidden int             `json:"-"`
+		Plain  *Item
+		Owner  string `json:"owner" validate:"required,email"`
+	}
+
+	cases := []struct {
+		name   string
+		value  interface{}
+		errors []string
+	}{
+		{
+			name: "valid",
+			value: map[string]interface{}{
+				"id":    1.0,
+				"title": "foo",
+				"items": []interface{}{
+					map[string]interface{}{"name": "a", "price": 1.0},
+				},
+				"index": map[string]interface{}{
+					"a": map[string]interface{}{"name": "a"},
+				},
+				"custom": map[string]interface{}{"anything": true},
+				"any":    map[string]interface{}{"anything": true},
+				"Plain":  map[string]interface{}{"name": "b"},
+				"owner":  "john@example.com",
+			},
+		},
+		{
+			name: "case-insensitive keys",
+			value: map[string]interface{}{
+				"ID":    1.0,
+				"Title": "foo",
+				"plain": map[string]interface{}{"Name": "b"},
+				"Owner": "john@example.com",
+			},
+		},
+		{
+			name: "unknown fields",
+			value: map[string]interface{}{
+				"id":     1.0,
+				"owner":  "john@example.com",
+				"Hidden": 1.0,
+				"extra":  true,
+				"items": []interface{}{
+					map[string]interface{}{"name": "a"},
+					map[string]interface{}{"name": "b", "color": "red"},
+				},
+			},
+			errors: []string{
+				"at $.Hidden: unknown field",
+				"at $.extra: unknown field",
+				"at $.items[1].color: unknown field",
+			},
+		},
+		{
+			name: "missing required fields",
+			value: map[string]interface{}{
+				"index": map[string]interface{}{
+					"a b": map[string]interface{}{"price": 1.0},
+				},
+			},
+			errors: []string{
+				`at $.index["a b"].name: missing required field`,
+				"at $.id: missing required field",
+				"at $.owner: missing required field",
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			handler := &mockAssertionHandler{}
+			chain := newChainWithConfig("test", Config{
+				AssertionHandler: handler,
+			}.withDefaults())
+
+			opChain := chain.enter("test")
+
+			var target S
+			canonDecodeStrict(opChain, tc.value, &target)
+
+			opChain.leave()
+
+			if len(tc.errors) == 0 {
+				opChain.assertNotFailed(t)
+				return
+			}
+
+			opChain.assertFailed(t)
+
+			require.NotNil(t, handler.failure)
+			require.Equal(t, len(tc.errors)+1, len(handler.failure.Errors))
+
+			for n, msg := range tc.errors {
+				assert.Equal(t, msg, handler.failure.Errors[n+1].Error())
+			}
+		})
+	}
+
+	t.Run("target is nil", func(t *testing.T) {
+		chain := newMockChain(t).enter("test")
+		defer chain.leave()
+
+		canonDecodeStrict(chain, 123, nil)
+
+		chain.assertFailed(t)
+	})
+
+	t.Run("value is not unmarshallable into target", func(t *testing.T) {
+		chain := newMockChain(t).enter("test")
+		defer chain.leave()
+
+		var target int
+		canonDecodeStrict(chain, "foo", &target)
+
+		chain.assertFailed(t)
+	})
+}
+
+func TestCanon_JSONNumber(t *testing.T) {
+	t.Run("normalization", func(t *testing.T) {
+		cases := []struct {
+			in  json.Number
+			out interface{}
+		}{
+			{"0", 0.0},
+			{"123", 123.0},
+			{"-1.5", -1.5},
+			{"0.1", 0.1},
+			{"19.99", 19.99},
+			{"1e300", 1e300},
+			{"9007199254740992", 9007199254740992.0},
+			{"9007199254740993", json.Number("9007199254740993")},
+			{"12345678901234567890", json.Number("12345678901234567890")},
+			{"1.2345678901234567890e19", json.Number("12345678901234567890")},
+			{"0.10000000000000000001", json.Number("0.10000000000000000001")},
+			{"1e400", json.Number("1" + strings.Repeat("0", 400))},
+		}
+
+		for _, tc := range cases {
+			assert.Equal(t, tc.out, canonJSONNumber(tc.in), "input: %s", tc.in)
+		}
+	})
+
+	t.Run("canonValue", func(t *testing.T) {
+		chain := newMockChain(t).enter("test")
+		defer chain.leave()
+
+		value, ok := canonValue(chain, map[string]interface{}{
+			"small":   int64(123),
+			"large":   int64(9007199254740993),
+			"unsign":  uint64(18446744073709551615),
+			"number":  json.Number("0.10000000000000000001"),
+			"float":   0.1,
+			"numbers": []interface{}{json.Number("1"), json.Number("1.50")},
+		})
+		require.True(t, ok)
+
+		assert.Equal(t, map[string]interface{}{
+			"small":   123.0,
+			"large":   json.Number("9007199254740993"),
+			"unsign":  json.Number("18446744073709551615"),
+			"number":  json.Number("0.10000000000000000001"),
+			"float":   0.1,
+			"numbers": []interface{}{1.0, 1.5},
+		}, value)
+	})
+
+	t.Run("canonNumber", func(t *testing.T) {
+		chain := newMockChain(t).enter("test")
+		defer chain.leave()
+
+		for _, in := range []interface{}{
+			json.Number("1.5"),
+			big.NewInt(3),
+			big.NewFloat(1.5),
+			big.NewRat(3, 2),
+		} {
+			_, ok := canonNumber(chain, in)
+			assert.True(t, ok)
+		}
+
+		num, ok := canonNumber(chain, json.Number("12345678901234567890"))
+		assert.True(t, ok)
+		assert.Equal(t, 12345678901234567890.0, num)
+
+		chain.assertNotFailed(t)
+
+		_, ok = canonNumber(chain, json.Number("abc"))
+		assert.False(t, ok)
+
+		chain.assertFailed(t)
+	})
+
+	t.Run("formatRat", func(t *testing.T) {
+		assert.Equal(t, "10", formatRat(big.NewRat(10, 1)))
+		assert.Equal(t, "-0.5", formatRat(big.NewRat(-1, 2)))
+		assert.Equal(t, "0.125", formatRat(big.NewRat(1, 8)))
+		assert.Equal(t, "0.04", formatRat(big.NewRat(1, 25)))
+		assert.Equal(t, "0.001", formatRat(big.NewRat(1, 1000)))
+	})
+}
@@ -2,6 +2,9 @@ package httpexpect
 
 import (
 	"fmt"
+	"reflect"
+	"runtime"
+	"strings"
 	"sync"
 	"testing"
 
@@ -79,6 +82,16 @@ type chain struct {
 	handler  AssertionHandler
 	severity AssertionSeverity
 	failure  *AssertionFailure
+
+	warning  bool
+	warnings *warningList
+}
+
+// List of failures reported by chains switched to warning mode.
+// Shared by all chains of the tree.
+type warningList struct {
+	mu       sync.Mutex
+	messages []string
 }
 
 // If enabled, chain will panic if used incorrectly or gets illformed AssertionFailure.
@@ -108,6 +121,7 @@ func newChainWithConfig(name string, config Config) *chain {
 		context:  AssertionContext{},
 		handler:  config.AssertionHandler,
 		severity: SeverityError,
+		warnings: &warningList{},
 	}
 
 	c.context.TestName = config.TestName
@@ -142,6 +156,7 @@ func newChainWithDefaults(name string, reporter Reporter) *chain {
 			Reporter:  reporter,
 		},
 		severity: SeverityError,
+		warnings: &warningList{},
 	}
 
 	if name != "" {
@@ -192,6 +207,53 @@ func (c *chain) setSeverity(severity AssertionSeverity) {
 	}
 
 	c.severity = severity
+	c.warning = false
+}
+
+// Switch chain to warning mode.
+// Failures are reported with SeverityLog, are not propagated to the upper
+// chains, and are remembered in the warning list.
+// Child chains inherit warning mode from parent.
+func (c *chain) setWarning() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if chainValidation && c.state == stateLeaved {
+		panic("can't use chain after leave")
+	}
+
+	c.parent = nil
+	c.severity = SeverityLog
+	c.warning = true
+}
+
+// Get formatted failures reported by chains in warning mode.
+func (c *chain) warningMessages() []string {
+	c.mu.Lock()
+	warnings := c.warnings
+	c.mu.Unlock()
+
+	if warnings == nil {
+		return nil
+	}
+
+	warnings.mu.Lock()
+	defer warnings.mu.Unlock()
+
+	return append([]string(nil), warnings.messages...)
+}
+
+// Set handler of reported assertions.
+// Child chains inherit handler from parent.
+func (c *chain) setHandler(handler AssertionHandler) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if chainValidation && c.state == stateLeaved {
+		panic("can't use chain after leave")
+	}
+
+	c.handler = handler
 }
 
 // Reset aliased path to given string.
@@ -282,7 +344,9 @@ func (c *chain) clone() *chain {
 		severity: c.severity,
 		// failure is not inherited because it should be reported only once
 		// by the chain where it happened
-		failure: nil,
+		failure:  nil,
+		warning:  c.warning,
+		warnings: c.warnings,
 	}
 }
 
@@ -302,6 +366,63 @@ func (c *chain) enter(name string, args ...interface{}) *chain {
 	return chainCopy
 }
 
+// Find the first caller outside of httpexpect.
+// Frames from our own tests are not skipped, since they're in the same package.
+func callerLocation() AssertionLocation {
+	var pcs [32]uintptr
+
+	n := runtime.Callers(3, pcs[:])
+
+	for _, pc := range pcs[:n] {
+		var loc AssertionLocation
+
+		// resolving frames is expensive, so we cache result for every pc;
+		// zero location means that pc belongs to httpexpect
+		if cached, ok := callerCache.Load(pc); ok {
+			loc = cached.(AssertionLocation)
+		} else {
+			loc = resolveLocation(pc)
+			callerCache.Store(pc, loc)
+		}
+
+		if loc.File != "" {
+			return loc
+		}
+	}
+
+	return AssertionLocation{}
+}
+
+var callerCache sync.Map
+
+// Prefix of qualified function names from this package.
+var callerPkgPrefix = reflect.TypeOf(chain{}).PkgPath() + "."
+
+// Single pc may correspond to multiple frames if functions were inlined.
+func resolveLocation(pc uintptr) AssertionLocation {
+	frames := runtime.CallersFrames([]uintptr{pc})
+
+	for {
+		frame, more := frames.Next()
+
+		isInternal := strings.HasPrefix(frame.Function, callerPkgPrefix) &&
+			!strings.HasSuffix(frame.File, "_test.go")
+
+		if frame.Function != "" && !isInternal {
+			return AssertionLocation{
+				File: frame.File,
+				Line: frame.Line,
+			}
+		}
+
+		if !more {
+			break
+		}
+	}
+
+	return AssertionLocation{}
+}
+
 // Like enter(), but it replaces last element of the path instead appending to it.
 // Must be called between enter() and leave().
 func (c *chain) replace(name string, args ...interface{}) *chain {
@@ -345,11 +466,12 @@ func (c *chain) replace(name string, args ...interface{}) *chain {
 // Chain can't be used after this call.
 func (c *chain) leave() {
 	var (
-		parent  *chain
-		flags   chainFlags
-		context AssertionContext
-		handler AssertionHandler
-		failure *AssertionFailure
+		parent   *chain
+		flags    chainFlags
+		context  AssertionContext
+		handler  AssertionHandler
+		failure  *AssertionFailure
+		warnings *warningList
 	)
 	func() {
 		c.mu.Lock()
@@ -367,6 +489,9 @@ func (c *chain) leave() {
 		handler = c.handler
 		failure = c.failure
 
+		if c.warning {
+			warnings = c.warnings
+		}
 	}()
 
 	if flags&(flagFailed|flagFailedChildren) == 0 {
@@ -381,6 +506,14 @@ func (c *chain) leave() {
 				panic(err)
 			}
 		}
+
+		if warnings != nil {
+			msg := formatFailure(handler, &context, failure)
+
+			warnings.mu.Lock()
+			warnings.messages = append(warnings.messages, msg)
+			warnings.mu.Unlock()
+		}
 	}
 
 	if flags&(flagFailed|flagFailedChildren) != 0 && parent != nil {
@@ -416,6 +549,9 @@ func (c *chain) fail(failure AssertionFailure) {
 	}
 	c.flags |= flagFailed
 
+	// location is resolved only on failure because it's expensive
+	c.context.Location = callerLocation()
+
 	failure.Severity = c.severity
 	if c.severity == SeverityError {
 		failure.IsFatal = true
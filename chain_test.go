@@ -2,10 +2,13 @@ package httpexpect
 
 import (
 	"errors"
+	"fmt"
+	"runtime"
 	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestChain_Basic(t *testing.T) {
@@ -536,6 +539,68 @@ func TestChain_Path(t *testing.T) {
 	assert.Equal(t, "root.foo.bar.yyy", path(opChain3r))
 }
 
+func TestChain_Location(t *testing.T) {
+	t.Run("fail", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+
+		rootChain := newChainWithConfig("root", Config{
+			AssertionHandler: handler,
+		}.withDefaults())
+
+		assert.Equal(t, AssertionLocation{}, rootChain.context.Location)
+
+		opChain := rootChain.enter("foo")
+
+		assert.Equal(t, AssertionLocation{}, opChain.context.Location)
+
+		_, file, line, _ := runtime.Caller(0)
+		opChain.fail(mockFailure())
+
+		assert.Equal(t, file, opChain.context.Location.File)
+		assert.Equal(t, line+1, opChain.context.Location.Line)
+		assert.Equal(t, fmt.Sprintf("%s:%d", file, line+1),
+			opChain.context.Location.String())
+
+		opChain.leave()
+
+		require.NotNil(t, handler.failure)
+		assert.Equal(t, opChain.context.Location, handler.ctx.Location)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+
+		rootChain := newChainWithConfig("root", Config{
+			AssertionHandler: handler,
+		}.withDefaults())
+
+		opChain := rootChain.enter("foo")
+		opChain.leave()
+
+		require.NotNil(t, handler.ctx)
+		assert.Equal(t, AssertionLocation{}, handler.ctx.Location)
+	})
+
+	t.Run("matcher", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+
+		value := NewValueC(Config{
+			AssertionHandler: handler,
+		}, map[string]interface{}{"foo": "bar"})
+
+		_, file, line, _ := runtime.Caller(0)
+		value.Object().Value("foo").String().IsEqual("baz")
+
+		require.NotNil(t, handler.failure)
+		assert.Equal(t, file, handler.ctx.Location.File)
+		assert.Equal(t, line+1, handler.ctx.Location.Line)
+	})
+
+	t.Run("unknown", func(t *testing.T) {
+		assert.Equal(t, "", AssertionLocation{}.String())
+	})
+}
+
 func TestChain_AliasedPath(t *testing.T) {
 	path := func(c *chain) string {
 		return strings.Join(c.context.Path, ".")
@@ -665,6 +730,85 @@ func TestChain_Severity(t *testing.T) {
 	})
 }
 
+func TestChain_Warning(t *testing.T) {
+	t.Run("severity", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+
+		rootChain := newChainWithConfig("test", Config{
+			AssertionHandler: handler,
+		}.withDefaults())
+
+		childChain := rootChain.clone()
+		childChain.setWarning()
+
+		opChain := childChain.enter("test")
+		opChain.fail(mockFailure())
+		opChain.leave()
+
+		require.NotNil(t, handler.failure)
+		assert.Equal(t, SeverityLog, handler.failure.Severity)
+
+		childChain.assertFailed(t)
+		rootChain.assertNotFailed(t)
+		assert.False(t, rootChain.treeFailed())
+
+		messages := rootChain.warningMessages()
+		require.Equal(t, 1, len(messages))
+		assert.Contains(t, messages[0], "test.test")
+	})
+
+	t.Run("inherited", func(t *testing.T) {
+		rootChain := newChainWithDefaults("test", newMockReporter(t))
+
+		warningChain := rootChain.clone()
+		warningChain.setWarning()
+
+		opChain := warningChain.enter("foo")
+		childChain := opChain.clone()
+		opChain.leave()
+
+		opChain = childChain.enter("bar")
+		opChain.fail(mockFailure())
+		opChain.leave()
+
+		assert.Equal(t, 1, len(rootChain.warningMessages()))
+		rootChain.assertNotFailed(t)
+	})
+
+	t.Run("severity override", func(t *testing.T) {
+		rootChain := newChainWithDefaults("test", newMockReporter(t))
+
+		warningChain := rootChain.clone()
+		warningChain.setWarning()
+
+		opChain := warningChain.enter("Filter()")
+		predicateChain := opChain.replace("Filter[0]")
+		predicateChain.setRoot()
+		predicateChain.setSeverity(SeverityLog)
+
+		failChain := predicateChain.enter("test")
+		failChain.fail(mockFailure())
+		failChain.leave()
+
+		predicateChain.leave()
+		opChain.leave()
+
+		assert.Equal(t, 0, len(rootChain.warningMessages()))
+	})
+
+	t.Run("no failures", func(t *testing.T) {
+		rootChain := newChainWithDefaults("test", newMockReporter(t))
+
+		warningChain := rootChain.clone()
+		warningChain.setWarning()
+
+		opChain := warningChain.enter("test")
+		opChain.leave()
+
+		assert.Equal(t, 0, len(rootChain.warningMessages()))
+	})
+}
+
 func TestChain_Reporting(t *testing.T) {
 	handler := &mockAssertionHandler{}
 
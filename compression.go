@@ -0,0 +1,129 @@
+package httpexpect
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+
+	"github.com/andybalholm/brotli"
+	"github.com/klauspost/compress/zstd"
+)
+
+// Content codings supported by Request.WithCompression and by automatic
+// decompression of response body.
+var supportedEncodings = []string{
+	"gzip",
+	"deflate",
+	"br",
+	"zstd",
+}
+
+func isSupportedEncoding(encoding string) bool {
+	for _, e := range supportedEncodings {
+		if e == encoding {
+			return true
+		}
+	}
+	return false
+}
+
+// Parse values of "Content-Encoding" header into list of codings,
+// in order in which they were applied.
+func parseContentEncoding(values []string) []string {
+	var encodings []string
+
+	for _, value := range values {
+		for _, enc := range strings.Split(value, ",") {
+			enc = strings.ToLower(strings.TrimSpace(enc))
+			if enc != "" && enc != "identity" {
+				encodings = append(encodings, enc)
+			}
+		}
+	}
+
+	return encodings
+}
+
+func compressContent(encoding string, data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	var w io.WriteCloser
+
+	switch encoding {
+	case "gzip":
+		w = gzip.NewWriter(&buf)
+
+	case "deflate":
+		w = zlib.NewWriter(&buf)
+
+	case "br":
+		w = brotli.NewWriter(&buf)
+
+	case "zstd":
+		enc, err := zstd.NewWriter(nil)
+		if err != nil {
+			return nil, err
+		}
+		defer enc.Close()
+		return enc.EncodeAll(data, nil), nil
+
+	default:
+		return nil, fmt.Errorf("unsupported content encoding %q", encoding)
+	}
+
+	if _, err := w.Write(data); err != nil {
+		return nil, err
+	}
+
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func decompressContent(encoding string, data []byte) ([]byte, error) {
+	var r io.Reader
+
+	switch encoding {
+	case "gzip":
+		gr, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+		defer gr.Close()
+		r = gr
+
+	case "deflate":
+		// "deflate" coding is zlib format, but some servers send raw deflate
+		zr, err := zlib.NewReader(bytes.NewReader(data))
+		if err != nil {
+			fr := flate.NewReader(bytes.NewReader(data))
+			defer fr.Close()
+			r = fr
+		} else {
+			defer zr.Close()
+			r = zr
+		}
+
+	case "br":
+		r = brotli.NewReader(bytes.NewReader(data))
+
+	case "zstd":
+		dec, err := zstd.NewReader(nil)
+		if err != nil {
+			return nil, err
+		}
+		defer dec.Close()
+		return dec.DecodeAll(data, nil)
+
+	default:
+		return nil, fmt.Errorf("unsupported content encoding %q", encoding)
+	}
+
+	return ioutil.ReadAll(r)
+}
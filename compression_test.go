@@ -0,0 +1,64 @@
+package httpexpect
+
+import (
+	"bytes"
+	"compress/flate"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompression_RoundTrip(t *testing.T) {
+	data := bytes.Repeat([]byte("hello, world! "), 100)
+
+	for _, encoding := range supportedEncodings {
+		t.Run(encoding, func(t *testing.T) {
+			compressed, err := compressContent(encoding, data)
+			require.NoError(t, err)
+			assert.NotEqual(t, data, compressed)
+			assert.Less(t, len(compressed), len(data))
+
+			decompressed, err := decompressContent(encoding, compressed)
+			require.NoError(t, err)
+			assert.Equal(t, data, decompressed)
+		})
+	}
+}
+
+func TestCompression_RawDeflate(t *testing.T) {
+	var buf bytes.Buffer
+
+	w, err := flate.NewWriter(&buf, flate.DefaultCompression)
+	require.NoError(t, err)
+	_, _ = w.Write([]byte("hello"))
+	require.NoError(t, w.Close())
+
+	decompressed, err := decompressContent("deflate", buf.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, []byte("hello"), decompressed)
+}
+
+func TestCompression_Errors(t *testing.T) {
+	_, err := compressContent("compress", []byte("hello"))
+	assert.Error(t, err)
+
+	_, err = decompressContent("compress", []byte("hello"))
+	assert.Error(t, err)
+
+	for _, encoding := range supportedEncodings {
+		t.Run(encoding, func(t *testing.T) {
+			_, err := decompressContent(encoding, []byte("not compressed"))
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestCompression_ParseContentEncoding(t *testing.T) {
+	assert.Nil(t, parseContentEncoding(nil))
+	assert.Nil(t, parseContentEncoding([]string{"identity"}))
+
+	assert.Equal(t,
+		[]string{"gzip", "br", "zstd"},
+		parseContentEncoding([]string{"GZip, identity,br", " zstd "}))
+}
@@ -0,0 +1,280 @@
+package httpexpect
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ContentRange provides methods to inspect "Content-Range" header
+// (RFC 9110, section 14.4).
+type ContentRange struct {
+	noCopy noCopy
+	chain  *chain
+	value  *contentRange
+}
+
+type contentRange struct {
+	raw   string
+	unit  string
+	start int64 // -1 for unsatisfied range ("*/total")
+	end   int64 // -1 for unsatisfied range ("*/total")
+	total int64 // -1 for unknown complete length ("start-end/*")
+}
+
+func newContentRange(parent *chain, val *contentRange) *ContentRange {
+	c := &ContentRange{chain: parent.clone(), value: nil}
+
+	opChain := c.chain.enter("")
+	defer opChain.leave()
+
+	if val == nil {
+		opChain.fail(AssertionFailure{
+			Type:   AssertNotNil,
+			Actual: &AssertionValue{val},
+			Errors: []error{
+				errors.New("expected: non-nil content range"),
+			},
+		})
+	} else {
+		c.value = val
+	}
+
+	return c
+}
+
+// Raw returns underlying header value.
+//
+// Example:
+//
+//	cr := resp.ContentRange()
+//	assert.Equal(t, "bytes 0-99/1000", cr.Raw())
+func (c *ContentRange) Raw() string {
+	if c.value == nil {
+		return ""
+	}
+	return c.value.raw
+}
+
+// Alias is similar to Value.Alias.
+func (c *ContentRange) Alias(name string) *ContentRange {
+	opChain := c.chain.enter("Alias(%q)", name)
+	defer opChain.leave()
+
+	c.chain.setAlias(name)
+	return c
+}
+
+// Chain returns public handle to assertion chain of ContentRange, which may be
+// used to implement custom matchers. See Chain for details.
+func (c *ContentRange) Chain() *Chain {
+	return &Chain{chain: c.chain}
+}
+
+// AsWarning is similar to Value.AsWarning.
+func (c *ContentRange) AsWarning() *ContentRange {
+	opChain := c.chain.enter("AsWarning()")
+	defer opChain.leave()
+
+	warnChain := c.chain.clone()
+	warnChain.setWarning()
+
+	return &ContentRange{
+		chain: warnChain,
+		value: c.value,
+	}
+}
+
+// Unit returns a new String instance with range unit, typically "bytes".
+//
+// Example:
+//
+//	cr := resp.ContentRange()
+//	cr.Unit().IsEqual("bytes")
+func (c *ContentRange) Unit() *String {
+	opChain := c.chain.enter("Unit()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newString(opChain, "")
+	}
+
+	return newString(opChain, c.value.unit)
+}
+
+// Start returns a new Number instance with position of the first byte
+// of the range, inclusive.
+//
+// If range is unsatisfied (e.g. "bytes */1000"), failure is reported.
+//
+// Example:
+//
+//	cr := resp.ContentRange()
+//	cr.Start().IsEqual(0)
+func (c *ContentRange) Start() *Number {
+	opChain := c.chain.enter("Start()")
+	defer opChain.leave()
+
+	if opChain.failed() || !c.checkSatisfied(opChain) {
+		return newNumber(opChain, 0)
+	}
+
+	return newNumber(opChain, float64(c.value.start))
+}
+
+// End returns a new Number instance with position of the last byte
+// of the range, inclusive.
+//
+// If range is unsatisfied (e.g. "bytes */1000"), failure is reported.
+//
+// Example:
+//
+//	cr := resp.ContentRange()
+//	cr.End().IsEqual(99)
+func (c *ContentRange) End() *Number {
+	opChain := c.chain.enter("End()")
+	defer opChain.leave()
+
+	if opChain.failed() || !c.checkSatisfied(opChain) {
+		return newNumber(opChain, 0)
+	}
+
+	return newNumber(opChain, float64(c.value.end))
+}
+
+// Total returns a new Number instance with complete length of the
+// representation.
+//
+// If complete length is unknown (e.g. "bytes 0-99/*"), failure is reported.
+//
+// Example:
+//
+//	cr := resp.ContentRange()
+//	cr.Total().IsEqual(1000)
+func (c *ContentRange) Total() *Number {
+	opChain := c.chain.enter("Total()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newNumber(opChain, 0)
+	}
+
+	if c.value.total < 0 {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{c.value.raw},
+			Errors: []error{
+				errors.New("expected: content range with known complete length"),
+			},
+		})
+		return newNumber(opChain, 0)
+	}
+
+	return newNumber(opChain, float64(c.value.total))
+}
+
+func (c *ContentRange) checkSatisfied(opChain *chain) bool {
+	if c.value.start < 0 {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{c.value.raw},
+			Errors: []error{
+				errors.New("expected: satisfied content range"),
+			},
+		})
+		return false
+	}
+
+	return true
+}
+
+// Parse "Content-Range" header value:
+//
+//	Content-Range       = range-unit SP ( range-resp / unsatisfied-range )
+//	range-resp          = incl-range "/" ( complete-length / "*" )
+//	incl-range          = first-pos "-" last-pos
+//	unsatisfied-range   = "*/" complete-length
+func parseContentRange(value string) (*contentRange, error) {
+	value = strings.TrimSpace(value)
+
+	sp := strings.IndexByte(value, ' ')
+	if sp <= 0 {
+		return nil, errors.New("missing range unit")
+	}
+
+	cr := &contentRange{
+		raw:  value,
+		unit: value[:sp],
+	}
+
+	resp := strings.TrimSpace(value[sp+1:])
+
+	slash := strings.IndexByte(resp, '/')
+	if slash < 0 {
+		return nil, errors.New(`missing "/" separator`)
+	}
+
+	rangeStr, totalStr := resp[:slash], resp[slash+1:]
+
+	if totalStr == "*" {
+		cr.total = -1
+	} else {
+		total, err := parseRangePos(totalStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid complete length: %s", err)
+		}
+		cr.total = total
+	}
+
+	if rangeStr == "*" {
+		if cr.total < 0 {
+			return nil, errors.New("unsatisfied range without complete length")
+		}
+		cr.start, cr.end = -1, -1
+		return cr, nil
+	}
+
+	dash := strings.IndexByte(rangeStr, '-')
+	if dash < 0 {
+		return nil, fmt.Errorf("invalid range %q", rangeStr)
+	}
+
+	start, err := parseRangePos(rangeStr[:dash])
+	if err != nil {
+		return nil, fmt.Errorf("invalid first position: %s", err)
+	}
+
+	end, err := parseRangePos(rangeStr[dash+1:])
+	if err != nil {
+		return nil, fmt.Errorf("invalid last position: %s", err)
+	}
+
+	if end < start {
+		return nil, fmt.Errorf("last position %d is less than first position %d",
+			end, start)
+	}
+
+	if cr.total >= 0 && end >= cr.total {
+		return nil, fmt.Errorf("last position %d exceeds complete length %d",
+			end, cr.total)
+	}
+
+	cr.start, cr.end = start, end
+
+	return cr, nil
+}
+
+func parseRangePos(s string) (int64, error) {
+	if s == "" {
+		return 0, errors.New("empty value")
+	}
+
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return 0, fmt.Errorf("invalid value %q", s)
+		}
+	}
+
+	return strconv.ParseInt(s, 10, 64)
+}
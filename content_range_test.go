@@ -0,0 +1,187 @@
+package httpexpect
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContentRange_FailedChain(t *testing.T) {
+	check := func(value *ContentRange) {
+		value.chain.assertFailed(t)
+
+		value.Alias("foo")
+
+		value.Unit().chain.assertFailed(t)
+		value.Start().chain.assertFailed(t)
+		value.End().chain.assertFailed(t)
+		value.Total().chain.assertFailed(t)
+	}
+
+	t.Run("failed chain", func(t *testing.T) {
+		chain := newMockChain(t)
+		chain.setFailed()
+
+		value := newContentRange(chain, &contentRange{})
+
+		check(value)
+	})
+
+	t.Run("nil value", func(t *testing.T) {
+		chain := newMockChain(t)
+
+		value := newContentRange(chain, nil)
+
+		check(value)
+		assert.Equal(t, "", value.Raw())
+	})
+}
+
+func TestContentRange_Alias(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	parent := newChainWithDefaults("ContentRange()", reporter)
+	value := newContentRange(parent, &contentRange{unit: "bytes"})
+	assert.Equal(t, []string{"ContentRange()"}, value.chain.context.Path)
+	assert.Equal(t, []string{"ContentRange()"}, value.chain.context.AliasedPath)
+
+	value.Alias("foo")
+	assert.Equal(t, []string{"ContentRange()"}, value.chain.context.Path)
+	assert.Equal(t, []string{"foo"}, value.chain.context.AliasedPath)
+
+	childValue := value.Unit()
+	assert.Equal(t, []string{"ContentRange()", "Unit()"},
+		childValue.chain.context.Path)
+	assert.Equal(t, []string{"foo", "Unit()"},
+		childValue.chain.context.AliasedPath)
+}
+
+func TestContentRange_Getters(t *testing.T) {
+	t.Run("complete", func(t *testing.T) {
+		cr, err := parseContentRange("bytes 0-99/1000")
+		require.NoError(t, err)
+
+		value := newContentRange(newMockChain(t), cr)
+
+		assert.Equal(t, "bytes 0-99/1000", value.Raw())
+		assert.Equal(t, "bytes", value.Unit().Raw())
+		assert.Equal(t, 0.0, value.Start().Raw())
+		assert.Equal(t, 99.0, value.End().Raw())
+		assert.Equal(t, 1000.0, value.Total().Raw())
+
+		value.chain.assertNotFailed(t)
+	})
+
+	t.Run("unknown total", func(t *testing.T) {
+		cr, err := parseContentRange("bytes 10-19/*")
+		require.NoError(t, err)
+
+		value := newContentRange(newMockChain(t), cr)
+
+		value.Start().IsEqual(10)
+		value.End().IsEqual(19)
+		value.chain.assertNotFailed(t)
+
+		value.Total().chain.assertFailed(t)
+		value.chain.assertFailed(t)
+	})
+
+	t.Run("unsatisfied", func(t *testing.T) {
+		cr, err := parseContentRange("bytes */1000")
+		require.NoError(t, err)
+
+		value := newContentRange(newMockChain(t), cr)
+
+		value.Total().IsEqual(1000)
+		value.chain.assertNotFailed(t)
+
+		value.Start().chain.assertFailed(t)
+		value.End().chain.assertFailed(t)
+		value.chain.assertFailed(t)
+	})
+}
+
+func TestContentRange_Parse(t *testing.T) {
+	cases := []struct {
+		value string
+		want  *contentRange
+	}{
+		{
+			value: "bytes 0-0/1",
+			want:  &contentRange{unit: "bytes", start: 0, end: 0, total: 1},
+		},
+		{
+			value: "bytes 21010-47021/47022",
+			want: &contentRange{
+				unit: "bytes", start: 21010, end: 47021, total: 47022,
+			},
+		},
+		{
+			value: "bytes 5-9/*",
+			want:  &contentRange{unit: "bytes", start: 5, end: 9, total: -1},
+		},
+		{
+			value: "bytes */47022",
+			want:  &contentRange{unit: "bytes", start: -1, end: -1, total: 47022},
+		},
+		{
+			value: " items 1-2/3 ",
+			want:  &contentRange{unit: "items", start: 1, end: 2, total: 3},
+		},
+		{value: ""},
+		{value: "bytes"},
+		{value: "bytes 0-99"},
+		{value: "bytes */*"},
+		{value: "bytes 0/100"},
+		{value: "bytes -1-5/100"},
+		{value: "bytes 0-/100"},
+		{value: "bytes 0-x/100"},
+		{value: "bytes 10-5/100"},
+		{value: "bytes 0-100/100"},
+		{value: "bytes 0-99/+100"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.value, func(t *testing.T) {
+			cr, err := parseContentRange(tc.value)
+
+			if tc.want == nil {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+
+			tc.want.raw = cr.raw
+			assert.Equal(t, tc.want, cr)
+		})
+	}
+}
+
+func TestContentRange_Header(t *testing.T) {
+	t.Run("missing", func(t *testing.T) {
+		chain := newMockChain(t)
+
+		opChain := chain.enter("test")
+		cr := getContentRange(opChain, http.Header{})
+		opChain.leave()
+
+		assert.Nil(t, cr)
+		chain.assertFailed(t)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		chain := newMockChain(t)
+
+		opChain := chain.enter("test")
+		cr := getContentRange(opChain, http.Header{
+			"Content-Range": {"bytes 0-99"},
+		})
+		opChain.leave()
+
+		assert.Nil(t, cr)
+		chain.assertFailed(t)
+	})
+}
@@ -80,6 +80,26 @@ func (c *Cookie) Alias(name string) *Cookie {
 	return c
 }
 
+// Chain returns public handle to assertion chain of Cookie, which may be
+// used to implement custom matchers. See Chain for details.
+func (c *Cookie) Chain() *Chain {
+	return &Chain{chain: c.chain}
+}
+
+// AsWarning is similar to Value.AsWarning.
+func (c *Cookie) AsWarning() *Cookie {
+	opChain := c.chain.enter("AsWarning()")
+	defer opChain.leave()
+
+	warnChain := c.chain.clone()
+	warnChain.setWarning()
+
+	return &Cookie{
+		chain: warnChain,
+		value: c.value,
+	}
+}
+
 // Name returns a new String instance with cookie name.
 //
 // Example:
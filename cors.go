@@ -0,0 +1,521 @@
+package httpexpect
+
+import (
+	"errors"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// CORS provides methods to inspect CORS (Cross-Origin Resource Sharing)
+// headers of a response.
+//
+// Checks follow browser semantics defined by Fetch standard, e.g. wildcard
+// values are not honored when credentials are allowed.
+type CORS struct {
+	noCopy noCopy
+	chain  *chain
+	value  http.Header
+}
+
+func newCORS(parent *chain, val http.Header) *CORS {
+	c := &CORS{chain: parent.clone(), value: nil}
+
+	opChain := c.chain.enter("")
+	defer opChain.leave()
+
+	if val == nil {
+		opChain.fail(AssertionFailure{
+			Type:   AssertNotNil,
+			Actual: &AssertionValue{val},
+			Errors: []error{
+				errors.New("expected: non-nil response header"),
+			},
+		})
+	} else {
+		c.value = val
+	}
+
+	return c
+}
+
+// Raw returns underlying http.Header of response.
+//
+// Example:
+//
+//	cors := e.Preflight("/users", "https://example.com", "PUT")
+//	assert.Equal(t, "true", cors.Raw().Get("Access-Control-Allow-Credentials"))
+func (c *CORS) Raw() http.Header {
+	return c.value
+}
+
+// Alias is similar to Value.Alias.
+func (c *CORS) Alias(name string) *CORS {
+	opChain := c.chain.enter("Alias(%q)", name)
+	defer opChain.leave()
+
+	c.chain.setAlias(name)
+	return c
+}
+
+// Chain returns public handle to assertion chain of CORS, which may be
+// used to implement custom matchers. See Chain for details.
+func (c *CORS) Chain() *Chain {
+	return &Chain{chain: c.chain}
+}
+
+// AsWarning is similar to Value.AsWarning.
+func (c *CORS) AsWarning() *CORS {
+	opChain := c.chain.enter("AsWarning()")
+	defer opChain.leave()
+
+	warnChain := c.chain.clone()
+	warnChain.setWarning()
+
+	return &CORS{
+		chain: warnChain,
+		value: c.value,
+	}
+}
+
+// AllowsOrigin succeeds if "Access-Control-Allow-Origin" header permits
+// given origin.
+//
+// Header permits origin if it's equal to origin, or if it's "*" and
+// credentials are not allowed.
+//
+// Example:
+//
+//	cors := e.Preflight("/users", "https://example.com", "PUT")
+//	cors.AllowsOrigin("https://example.com")
+func (c *CORS) AllowsOrigin(origin string) *CORS {
+	opChain := c.chain.enter("AllowsOrigin(%q)", origin)
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return c
+	}
+
+	if !c.allowsOrigin(origin) {
+		opChain.fail(AssertionFailure{
+			Type:     AssertEqual,
+			Actual:   &AssertionValue{c.value.Get("Access-Control-Allow-Origin")},
+			Expected: &AssertionValue{origin},
+			Errors: []error{
+				errors.New("expected: CORS allows given origin"),
+			},
+		})
+	}
+
+	return c
+}
+
+// NotAllowsOrigin succeeds if "Access-Control-Allow-Origin" header does not
+// permit given origin.
+//
+// Example:
+//
+//	cors := e.Preflight("/users", "https://evil.com", "PUT")
+//	cors.NotAllowsOrigin("https://evil.com")
+func (c *CORS) NotAllowsOrigin(origin string) *CORS {
+	opChain := c.chain.enter("NotAllowsOrigin(%q)", origin)
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return c
+	}
+
+	if c.allowsOrigin(origin) {
+		opChain.fail(AssertionFailure{
+			Type:     AssertNotEqual,
+			Actual:   &AssertionValue{c.value.Get("Access-Control-Allow-Origin")},
+			Expected: &AssertionValue{origin},
+			Errors: []error{
+				errors.New("expected: CORS does not allow given origin"),
+			},
+		})
+	}
+
+	return c
+}
+
+// AllowsMethod succeeds if "Access-Control-Allow-Methods" header permits
+// given method.
+//
+// Header permits method if it lists the method, or if it's "*" and
+// credentials are not allowed. CORS-safelisted methods (GET, HEAD, POST)
+// are always permitted.
+//
+// Example:
+//
+//	cors := e.Preflight("/users", "https://example.com", "PUT")
+//	cors.AllowsMethod("PUT")
+func (c *CORS) AllowsMethod(method string) *CORS {
+	opChain := c.chain.enter("AllowsMethod(%q)", method)
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return c
+	}
+
+	if !c.allowsMethod(method) {
+		opChain.fail(AssertionFailure{
+			Type:     AssertContainsElement,
+			Actual:   &AssertionValue{c.list("Access-Control-Allow-Methods")},
+			Expected: &AssertionValue{method},
+			Errors: []error{
+				errors.New("expected: CORS allows given method"),
+			},
+		})
+	}
+
+	return c
+}
+
+// NotAllowsMethod succeeds if "Access-Control-Allow-Methods" header does not
+// permit given method.
+//
+// Example:
+//
+//	cors := e.Preflight("/users", "https://example.com", "DELETE")
+//	cors.NotAllowsMethod("DELETE")
+func (c *CORS) NotAllowsMethod(method string) *CORS {
+	opChain := c.chain.enter("NotAllowsMethod(%q)", method)
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return c
+	}
+
+	if c.allowsMethod(method) {
+		opChain.fail(AssertionFailure{
+			Type:     AssertNotContainsElement,
+			Actual:   &AssertionValue{c.list("Access-Control-Allow-Methods")},
+			Expected: &AssertionValue{method},
+			Errors: []error{
+				errors.New("expected: CORS does not allow given method"),
+			},
+		})
+	}
+
+	return c
+}
+
+// AllowsHeader succeeds if "Access-Control-Allow-Headers" header permits
+// given request header.
+//
+// Header permits request header if it lists the header (case-insensitively),
+// or if it's "*" and credentials are not allowed. Note that "*" never
+// permits "Authorization" header.
+//
+// Example:
+//
+//	cors := e.Preflight("/users", "https://example.com", "PUT", "X-Token")
+//	cors.AllowsHeader("X-Token")
+func (c *CORS) AllowsHeader(header string) *CORS {
+	opChain := c.chain.enter("AllowsHeader(%q)", header)
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return c
+	}
+
+	if !c.allowsHeader(header) {
+		opChain.fail(AssertionFailure{
+			Type:     AssertContainsElement,
+			Actual:   &AssertionValue{c.list("Access-Control-Allow-Headers")},
+			Expected: &AssertionValue{header},
+			Errors: []error{
+				errors.New("expected: CORS allows given request header"),
+			},
+		})
+	}
+
+	return c
+}
+
+// NotAllowsHeader succeeds if "Access-Control-Allow-Headers" header does not
+// permit given request header.
+//
+// Example:
+//
+//	cors := e.Preflight("/users", "https://example.com", "PUT", "X-Debug")
+//	cors.NotAllowsHeader("X-Debug")
+func (c *CORS) NotAllowsHeader(header string) *CORS {
+	opChain := c.chain.enter("NotAllowsHeader(%q)", header)
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return c
+	}
+
+	if c.allowsHeader(header) {
+		opChain.fail(AssertionFailure{
+			Type:     AssertNotContainsElement,
+			Actual:   &AssertionValue{c.list("Access-Control-Allow-Headers")},
+			Expected: &AssertionValue{header},
+			Errors: []error{
+				errors.New("expected: CORS does not allow given request header"),
+			},
+		})
+	}
+
+	return c
+}
+
+// AllowsCredentials succeeds if "Access-Control-Allow-Credentials" header
+// is "true".
+//
+// Example:
+//
+//	cors := e.Preflight("/users", "https://example.com", "PUT")
+//	cors.AllowsCredentials()
+func (c *CORS) AllowsCredentials() *CORS {
+	opChain := c.chain.enter("AllowsCredentials()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return c
+	}
+
+	if !c.allowsCredentials() {
+		opChain.fail(AssertionFailure{
+			Type:     AssertEqual,
+			Actual:   &AssertionValue{c.value.Get("Access-Control-Allow-Credentials")},
+			Expected: &AssertionValue{"true"},
+			Errors: []error{
+				errors.New("expected: CORS allows credentials"),
+			},
+		})
+	}
+
+	return c
+}
+
+// NotAllowsCredentials succeeds if "Access-Control-Allow-Credentials" header
+// is not "true".
+//
+// Example:
+//
+//	cors := e.Preflight("/public", "https://example.com", "GET")
+//	cors.NotAllowsCredentials()
+func (c *CORS) NotAllowsCredentials() *CORS {
+	opChain := c.chain.enter("NotAllowsCredentials()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return c
+	}
+
+	if c.allowsCredentials() {
+		opChain.fail(AssertionFailure{
+			Type:     AssertNotEqual,
+			Actual:   &AssertionValue{c.value.Get("Access-Control-Allow-Credentials")},
+			Expected: &AssertionValue{"true"},
+			Errors: []error{
+				errors.New("expected: CORS does not allow credentials"),
+			},
+		})
+	}
+
+	return c
+}
+
+// MaxAge returns a new Duration instance with value of "Access-Control-Max-Age"
+// header, which defines how long preflight result can be cached.
+//
+// If header is missing or is not a non-negative integer number of seconds,
+// failure is reported.
+//
+// Example:
+//
+//	cors := e.Preflight("/users", "https://example.com", "PUT")
+//	cors.MaxAge().IsEqual(10 * time.Minute)
+func (c *CORS) MaxAge() *Duration {
+	opChain := c.chain.enter("MaxAge()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newDuration(opChain, nil)
+	}
+
+	value := c.value.Get("Access-Control-Max-Age")
+
+	if value == "" {
+		opChain.fail(AssertionFailure{
+			Type:     AssertContainsKey,
+			Actual:   &AssertionValue{c.value},
+			Expected: &AssertionValue{"Access-Control-Max-Age"},
+			Errors: []error{
+				errors.New(
+					`expected: response contains "Access-Control-Max-Age" header`),
+			},
+		})
+		return newDuration(opChain, nil)
+	}
+
+	maxAge, err := parseDeltaSeconds(strings.TrimSpace(value))
+	if err != nil {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{value},
+			Errors: []error{
+				errors.New(`invalid "Access-Control-Max-Age" response header`),
+				err,
+			},
+		})
+		return newDuration(opChain, nil)
+	}
+
+	return newDuration(opChain, &maxAge)
+}
+
+// ExposesHeader succeeds if "Access-Control-Expose-Headers" header permits
+// given response header to be read by scripts.
+//
+// Header permits response header if it lists the header (case-insensitively),
+// or if it's "*" and credentials are not allowed.
+//
+// Example:
+//
+//	resp := e.GET("/users").WithHeader("Origin", "https://example.com").
+//	    Expect()
+//	resp.CORS().ExposesHeader("X-Total-Count")
+func (c *CORS) ExposesHeader(header string) *CORS {
+	opChain := c.chain.enter("ExposesHeader(%q)", header)
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return c
+	}
+
+	if !c.exposesHeader(header) {
+		opChain.fail(AssertionFailure{
+			Type:     AssertContainsElement,
+			Actual:   &AssertionValue{c.list("Access-Control-Expose-Headers")},
+			Expected: &AssertionValue{header},
+			Errors: []error{
+				errors.New("expected: CORS exposes given response header"),
+			},
+		})
+	}
+
+	return c
+}
+
+// NotExposesHeader succeeds if "Access-Control-Expose-Headers" header does
+// not permit given response header to be read by scripts.
+//
+// Example:
+//
+//	resp := e.GET("/users").WithHeader("Origin", "https://example.com").
+//	    Expect()
+//	resp.CORS().NotExposesHeader("X-Internal-Id")
+func (c *CORS) NotExposesHeader(header string) *CORS {
+	opChain := c.chain.enter("NotExposesHeader(%q)", header)
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return c
+	}
+
+	if c.exposesHeader(header) {
+		opChain.fail(AssertionFailure{
+			Type:     AssertNotContainsElement,
+			Actual:   &AssertionValue{c.list("Access-Control-Expose-Headers")},
+			Expected: &AssertionValue{header},
+			Errors: []error{
+				errors.New("expected: CORS does not expose given response header"),
+			},
+		})
+	}
+
+	return c
+}
+
+func (c *CORS) allowsOrigin(origin string) bool {
+	allowed := strings.TrimSpace(c.value.Get("Access-Control-Allow-Origin"))
+
+	if allowed == "*" {
+		return !c.allowsCredentials()
+	}
+
+	return allowed != "" && allowed == origin
+}
+
+func (c *CORS) allowsMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPost:
+		return true
+	}
+
+	for _, m := range c.list("Access-Control-Allow-Methods") {
+		if m == "*" && !c.allowsCredentials() {
+			return true
+		}
+		if strings.EqualFold(m, method) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (c *CORS) allowsHeader(header string) bool {
+	for _, h := range c.list("Access-Control-Allow-Headers") {
+		if h == "*" && !c.allowsCredentials() &&
+			!strings.EqualFold(header, "Authorization") {
+			return true
+		}
+		if strings.EqualFold(h, header) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (c *CORS) allowsCredentials() bool {
+	return strings.TrimSpace(c.value.Get("Access-Control-Allow-Credentials")) == "true"
+}
+
+func (c *CORS) exposesHeader(header string) bool {
+	for _, h := range c.list("Access-Control-Expose-Headers") {
+		if h == "*" && !c.allowsCredentials() {
+			return true
+		}
+		if strings.EqualFold(h, header) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Get comma-separated list from all values of given header.
+func (c *CORS) list(name string) []string {
+	list := []string{}
+
+	for _, value := range c.value.Values(name) {
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				list = append(list, item)
+			}
+		}
+	}
+
+	return list
+}
+
+// Build value of "Access-Control-Request-Headers" header, as browsers do:
+// lower-cased, sorted and comma-separated.
+func corsRequestHeaders(headers []string) string {
+	names := make([]string, 0, len(headers))
+	for _, h := range headers {
+		names = append(names, strings.ToLower(strings.TrimSpace(h)))
+	}
+
+	sort.Strings(names)
+
+	return strings.Join(names, ",")
+}
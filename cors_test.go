@@ -0,0 +1,333 @@
+package httpexpect
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCORS_FailedChain(t *testing.T) {
+	check := func(value *CORS) {
+		value.chain.assertFailed(t)
+
+		value.Alias("foo")
+
+		value.AllowsOrigin("http://example.com")
+		value.NotAllowsOrigin("http://example.com")
+		value.AllowsMethod("GET")
+		value.NotAllowsMethod("GET")
+		value.AllowsHeader("X-Foo")
+		value.NotAllowsHeader("X-Foo")
+		value.AllowsCredentials()
+		value.NotAllowsCredentials()
+		value.MaxAge().chain.assertFailed(t)
+		value.ExposesHeader("X-Foo")
+		value.NotExposesHeader("X-Foo")
+	}
+
+	t.Run("failed chain", func(t *testing.T) {
+		chain := newMockChain(t)
+		chain.setFailed()
+
+		value := newCORS(chain, http.Header{})
+
+		check(value)
+	})
+
+	t.Run("nil value", func(t *testing.T) {
+		chain := newMockChain(t)
+
+		value := newCORS(chain, nil)
+
+		check(value)
+	})
+}
+
+func TestCORS_Alias(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	parent := newChainWithDefaults("CORS()", reporter)
+	value := newCORS(parent, http.Header{})
+	assert.Equal(t, []string{"CORS()"}, value.chain.context.Path)
+	assert.Equal(t, []string{"CORS()"}, value.chain.context.AliasedPath)
+
+	value.Alias("foo")
+	assert.Equal(t, []string{"CORS()"}, value.chain.context.Path)
+	assert.Equal(t, []string{"foo"}, value.chain.context.AliasedPath)
+
+	childValue := value.MaxAge()
+	assert.Equal(t, []string{"CORS()", "MaxAge()"}, childValue.chain.context.Path)
+	assert.Equal(t, []string{"foo", "MaxAge()"}, childValue.chain.context.AliasedPath)
+}
+
+func TestCORS_Origin(t *testing.T) {
+	cases := []struct {
+		name        string
+		header      http.Header
+		origin      string
+		wantAllowed bool
+	}{
+		{
+			name:        "no header",
+			header:      http.Header{},
+			origin:      "http://a.com",
+			wantAllowed: false,
+		},
+		{
+			name: "same origin",
+			header: http.Header{
+				"Access-Control-Allow-Origin": {"http://a.com"},
+			},
+			origin:      "http://a.com",
+			wantAllowed: true,
+		},
+		{
+			name: "other origin",
+			header: http.Header{
+				"Access-Control-Allow-Origin": {"http://a.com"},
+			},
+			origin:      "http://b.com",
+			wantAllowed: false,
+		},
+		{
+			name: "wildcard",
+			header: http.Header{
+				"Access-Control-Allow-Origin": {"*"},
+			},
+			origin:      "http://b.com",
+			wantAllowed: true,
+		},
+		{
+			name: "wildcard with credentials",
+			header: http.Header{
+				"Access-Control-Allow-Origin":      {"*"},
+				"Access-Control-Allow-Credentials": {"true"},
+			},
+			origin:      "http://b.com",
+			wantAllowed: false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			value := newCORS(newMockChain(t), tc.header)
+			value.AllowsOrigin(tc.origin)
+			if tc.wantAllowed {
+				value.chain.assertNotFailed(t)
+			} else {
+				value.chain.assertFailed(t)
+			}
+
+			value = newCORS(newMockChain(t), tc.header)
+			value.NotAllowsOrigin(tc.origin)
+			if tc.wantAllowed {
+				value.chain.assertFailed(t)
+			} else {
+				value.chain.assertNotFailed(t)
+			}
+		})
+	}
+}
+
+func TestCORS_Method(t *testing.T) {
+	cases := []struct {
+		name        string
+		header      http.Header
+		method      string
+		wantAllowed bool
+	}{
+		{
+			name:        "safelisted",
+			header:      http.Header{},
+			method:      "POST",
+			wantAllowed: true,
+		},
+		{
+			name: "listed",
+			header: http.Header{
+				"Access-Control-Allow-Methods": {"GET, PUT", "PATCH"},
+			},
+			method:      "PATCH",
+			wantAllowed: true,
+		},
+		{
+			name: "not listed",
+			header: http.Header{
+				"Access-Control-Allow-Methods": {"GET, PUT"},
+			},
+			method:      "DELETE",
+			wantAllowed: false,
+		},
+		{
+			name: "wildcard",
+			header: http.Header{
+				"Access-Control-Allow-Methods": {"*"},
+			},
+			method:      "DELETE",
+			wantAllowed: true,
+		},
+		{
+			name: "wildcard with credentials",
+			header: http.Header{
+				"Access-Control-Allow-Methods":     {"*"},
+				"Access-Control-Allow-Credentials": {"true"},
+			},
+			method:      "DELETE",
+			wantAllowed: false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			value := newCORS(newMockChain(t), tc.header)
+			value.AllowsMethod(tc.method)
+			if tc.wantAllowed {
+				value.chain.assertNotFailed(t)
+			} else {
+				value.chain.assertFailed(t)
+			}
+
+			value = newCORS(newMockChain(t), tc.header)
+			value.NotAllowsMethod(tc.method)
+			if tc.wantAllowed {
+				value.chain.assertFailed(t)
+			} else {
+				value.chain.assertNotFailed(t)
+			}
+		})
+	}
+}
+
+func TestCORS_Headers(t *testing.T) {
+	cases := []struct {
+		name        string
+		header      http.Header
+		check       string
+		wantAllowed bool
+		wantExposed bool
+	}{
+		{
+			name:        "no header",
+			header:      http.Header{},
+			check:       "X-Foo",
+			wantAllowed: false,
+			wantExposed: false,
+		},
+		{
+			name: "listed",
+			header: http.Header{
+				"Access-Control-Allow-Headers":  {"x-foo, X-Bar"},
+				"Access-Control-Expose-Headers": {"X-Foo"},
+			},
+			check:       "X-FOO",
+			wantAllowed: true,
+			wantExposed: true,
+		},
+		{
+			name: "not listed",
+			header: http.Header{
+				"Access-Control-Allow-Headers":  {"X-Bar"},
+				"Access-Control-Expose-Headers": {"X-Bar"},
+			},
+			check:       "X-Foo",
+			wantAllowed: false,
+			wantExposed: false,
+		},
+		{
+			name: "wildcard",
+			header: http.Header{
+				"Access-Control-Allow-Headers":  {"*"},
+				"Access-Control-Expose-Headers": {"*"},
+			},
+			check:       "X-Foo",
+			wantAllowed: true,
+			wantExposed: true,
+		},
+		{
+			name: "wildcard with credentials",
+			header: http.Header{
+				"Access-Control-Allow-Headers":     {"*"},
+				"Access-Control-Expose-Headers":    {"*"},
+				"Access-Control-Allow-Credentials": {"true"},
+			},
+			check:       "X-Foo",
+			wantAllowed: false,
+			wantExposed: false,
+		},
+		{
+			name: "wildcard and authorization",
+			header: http.Header{
+				"Access-Control-Allow-Headers":  {"*"},
+				"Access-Control-Expose-Headers": {"*"},
+			},
+			check:       "Authorization",
+			wantAllowed: false,
+			wantExposed: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			check := func(value *CORS, wantFailed bool) {
+				if wantFailed {
+					value.chain.assertFailed(t)
+				} else {
+					value.chain.assertNotFailed(t)
+				}
+			}
+
+			check(newCORS(newMockChain(t), tc.header).
+				AllowsHeader(tc.check), !tc.wantAllowed)
+			check(newCORS(newMockChain(t), tc.header).
+				NotAllowsHeader(tc.check), tc.wantAllowed)
+			check(newCORS(newMockChain(t), tc.header).
+				ExposesHeader(tc.check), !tc.wantExposed)
+			check(newCORS(newMockChain(t), tc.header).
+				NotExposesHeader(tc.check), tc.wantExposed)
+		})
+	}
+}
+
+func TestCORS_Credentials(t *testing.T) {
+	value := newCORS(newMockChain(t), http.Header{
+		"Access-Control-Allow-Credentials": {"true"},
+	})
+	value.AllowsCredentials()
+	value.chain.assertNotFailed(t)
+	value.NotAllowsCredentials()
+	value.chain.assertFailed(t)
+
+	value = newCORS(newMockChain(t), http.Header{
+		"Access-Control-Allow-Credentials": {"false"},
+	})
+	value.NotAllowsCredentials()
+	value.chain.assertNotFailed(t)
+	value.AllowsCredentials()
+	value.chain.assertFailed(t)
+}
+
+func TestCORS_MaxAge(t *testing.T) {
+	value := newCORS(newMockChain(t), http.Header{
+		"Access-Control-Max-Age": {"600"},
+	})
+	value.MaxAge().IsEqual(10 * time.Minute)
+	value.chain.assertNotFailed(t)
+
+	value = newCORS(newMockChain(t), http.Header{})
+	value.MaxAge().chain.assertFailed(t)
+	value.chain.assertFailed(t)
+
+	value = newCORS(newMockChain(t), http.Header{
+		"Access-Control-Max-Age": {"ten"},
+	})
+	value.MaxAge().chain.assertFailed(t)
+	value.chain.assertFailed(t)
+}
+
+func TestCORS_RequestHeaders(t *testing.T) {
+	assert.Equal(t, "", corsRequestHeaders(nil))
+	assert.Equal(t, "content-type,x-b,x-token",
+		corsRequestHeaders([]string{"X-Token", " Content-Type", "x-b"}))
+}
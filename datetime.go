@@ -60,6 +60,26 @@ func (dt *DateTime) Alias(name string) *DateTime {
 	return dt
 }
 
+// Chain returns public handle to assertion chain of DateTime, which may be
+// used to implement custom matchers. See Chain for details.
+func (dt *DateTime) Chain() *Chain {
+	return &Chain{chain: dt.chain}
+}
+
+// AsWarning is similar to Value.AsWarning.
+func (dt *DateTime) AsWarning() *DateTime {
+	opChain := dt.chain.enter("AsWarning()")
+	defer opChain.leave()
+
+	warnChain := dt.chain.clone()
+	warnChain.setWarning()
+
+	return &DateTime{
+		chain: warnChain,
+		value: dt.value,
+	}
+}
+
 // Zone returns a new String instance with datetime zone.
 //
 // Example:
@@ -63,6 +63,26 @@ func (d *Duration) Alias(name string) *Duration {
 	return d
 }
 
+// Chain returns public handle to assertion chain of Duration, which may be
+// used to implement custom matchers. See Chain for details.
+func (d *Duration) Chain() *Chain {
+	return &Chain{chain: d.chain}
+}
+
+// AsWarning is similar to Value.AsWarning.
+func (d *Duration) AsWarning() *Duration {
+	opChain := d.chain.enter("AsWarning()")
+	defer opChain.leave()
+
+	warnChain := d.chain.clone()
+	warnChain.setWarning()
+
+	return &Duration{
+		chain: warnChain,
+		value: d.value,
+	}
+}
+
 // Deprecated: support for unset durations will be removed. The only method that
 // can create unset duration is Cookie.MaxAge. Instead of Cookie.MaxAge().IsSet(),
 // please use Cookie.HasMaxAge().
@@ -0,0 +1,162 @@
+package httpexpect
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type cachingStub struct {
+	mu sync.Mutex
+
+	etag         string
+	lastModified time.Time
+	ignoreConds  bool
+
+	requests []*http.Request
+	bodies   []string
+}
+
+func (s *cachingStub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	body, _ := ioutil.ReadAll(r.Body)
+
+	s.requests = append(s.requests, r)
+	s.bodies = append(s.bodies, string(body))
+
+	w.Header().Set("Cache-Control", "public, max-age=60")
+
+	if s.etag != "" {
+		w.Header().Set("ETag", s.etag)
+	}
+	if !s.lastModified.IsZero() {
+		w.Header().Set("Last-Modified", s.lastModified.UTC().Format(http.TimeFormat))
+	}
+
+	if !s.ignoreConds {
+		if s.etag != "" && r.Header.Get("If-None-Match") == s.etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+
+		if since, err := http.ParseTime(r.Header.Get("If-Modified-Since")); err == nil {
+			if !s.lastModified.IsZero() && !s.lastModified.After(since) {
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+		}
+	}
+
+	_, _ = w.Write([]byte("content"))
+}
+
+func TestE2ECaching_NotModified(t *testing.T) {
+	t.Run("etag", func(t *testing.T) {
+		stub := &cachingStub{
+			etag: `"v1"`,
+		}
+
+		server := httptest.NewServer(stub)
+		defer server.Close()
+
+		e := Default(t, server.URL)
+
+		resp := e.POST("/resource").
+			WithHeader("X-Test", "test").
+			WithText("payload").
+			ExpectNotModified()
+
+		resp.Status(http.StatusOK)
+		resp.ETag().IsEqual(`"v1"`)
+		resp.CacheControl().MaxAge().IsEqual(time.Minute)
+		resp.Body().IsEqual("content")
+
+		assert.Equal(t, 2, len(stub.requests))
+
+		assert.Equal(t, "", stub.requests[0].Header.Get("If-None-Match"))
+		assert.Equal(t, `"v1"`, stub.requests[1].Header.Get("If-None-Match"))
+		assert.Equal(t, "", stub.requests[1].Header.Get("If-Modified-Since"))
+
+		for n, r := range stub.requests {
+			assert.Equal(t, "POST", r.Method)
+			assert.Equal(t, "/resource", r.URL.Path)
+			assert.Equal(t, "test", r.Header.Get("X-Test"))
+			assert.Equal(t, "payload", stub.bodies[n])
+		}
+	})
+
+	t.Run("last modified", func(t *testing.T) {
+		stub := &cachingStub{
+			lastModified: time.Now().Add(-time.Hour).Truncate(time.Second),
+		}
+
+		server := httptest.NewServer(stub)
+		defer server.Close()
+
+		e := Default(t, server.URL)
+
+		e.GET("/resource").
+			WithQuery("q", "1").
+			ExpectNotModified().
+			Status(http.StatusOK).
+			LastModified().Lt(time.Now())
+
+		assert.Equal(t, 2, len(stub.requests))
+
+		assert.Equal(t, "", stub.requests[1].Header.Get("If-None-Match"))
+		assert.NotEqual(t, "", stub.requests[1].Header.Get("If-Modified-Since"))
+		assert.Equal(t, "q=1", stub.requests[1].URL.RawQuery)
+	})
+
+	t.Run("not modified ignored", func(t *testing.T) {
+		stub := &cachingStub{
+			etag:        `"v1"`,
+			ignoreConds: true,
+		}
+
+		server := httptest.NewServer(stub)
+		defer server.Close()
+
+		reporter := newMockReporter(t)
+
+		e := WithConfig(Config{
+			BaseURL:  server.URL,
+			Reporter: reporter,
+		})
+
+		req := e.GET("/resource")
+		req.ExpectNotModified()
+
+		req.chain.assertFailed(t)
+		assert.True(t, reporter.reported)
+		assert.Equal(t, 2, len(stub.requests))
+	})
+
+	t.Run("no validators", func(t *testing.T) {
+		stub := &cachingStub{}
+
+		server := httptest.NewServer(stub)
+		defer server.Close()
+
+		reporter := newMockReporter(t)
+
+		e := WithConfig(Config{
+			BaseURL:  server.URL,
+			Reporter: reporter,
+		})
+
+		req := e.GET("/resource")
+		req.ExpectNotModified()
+
+		req.chain.assertFailed(t)
+		assert.True(t, reporter.reported)
+		assert.Equal(t, 1, len(stub.requests))
+	})
+}
@@ -0,0 +1,131 @@
+package httpexpect
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func corsHandler(allowedOrigin string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		origin := r.Header.Get("Origin")
+
+		if origin == allowedOrigin {
+			w.Header().Set("Access-Control-Allow-Origin", origin)
+			w.Header().Set("Access-Control-Allow-Credentials", "true")
+			w.Header().Add("Vary", "Origin")
+		}
+
+		if r.Method == http.MethodOptions {
+			if origin == allowedOrigin {
+				w.Header().Set("Access-Control-Allow-Methods", "GET, PUT, PATCH")
+				w.Header().Set("Access-Control-Allow-Headers",
+					r.Header.Get("Access-Control-Request-Headers"))
+				w.Header().Set("Access-Control-Max-Age", "600")
+			}
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		w.Header().Set("Access-Control-Expose-Headers", "X-Total-Count")
+		w.Header().Set("X-Total-Count", "10")
+		w.WriteHeader(http.StatusOK)
+	})
+}
+
+func TestE2ECORS_Preflight(t *testing.T) {
+	server := httptest.NewServer(corsHandler("https://app.example.com"))
+	defer server.Close()
+
+	e := Default(t, server.URL)
+
+	e.Preflight("/users", "https://app.example.com", "PUT", "X-Token", "Content-Type").
+		AllowsOrigin("https://app.example.com").
+		AllowsMethod("PUT").
+		NotAllowsMethod("DELETE").
+		AllowsHeader("x-token").
+		AllowsHeader("Content-Type").
+		NotAllowsHeader("X-Other").
+		AllowsCredentials().
+		MaxAge().IsEqual(10 * time.Minute)
+
+	e.Preflight("/users", "https://evil.example.com", "PUT").
+		NotAllowsOrigin("https://evil.example.com").
+		NotAllowsMethod("PUT").
+		NotAllowsCredentials()
+
+	e.GET("/users").
+		WithHeader("Origin", "https://app.example.com").
+		Expect().
+		CORS().
+		AllowsOrigin("https://app.example.com").
+		ExposesHeader("X-Total-Count").
+		NotExposesHeader("X-Internal")
+}
+
+func TestE2ECORS_Failures(t *testing.T) {
+	server := httptest.NewServer(corsHandler("https://app.example.com"))
+	defer server.Close()
+
+	newExpect := func(reporter Reporter) *Expect {
+		return WithConfig(Config{
+			BaseURL:  server.URL,
+			Reporter: reporter,
+		})
+	}
+
+	t.Run("not allowed", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		e := newExpect(reporter)
+
+		cors := e.Preflight("/users", "https://evil.example.com", "PUT")
+		cors.AllowsOrigin("https://evil.example.com")
+
+		cors.chain.assertFailed(t)
+		assert.True(t, reporter.reported)
+	})
+
+	t.Run("context path", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+
+		e := WithConfig(Config{
+			BaseURL:          server.URL,
+			AssertionHandler: handler,
+		})
+
+		cors := e.Preflight("/users", "https://evil.example.com", "PUT")
+		cors.AllowsOrigin("https://evil.example.com")
+
+		require.NotNil(t, handler.failure)
+		assert.Equal(t, []string{
+			`Preflight("/users")`,
+			"Expect()",
+			"CORS()",
+			`AllowsOrigin("https://evil.example.com")`,
+		}, handler.ctx.Path)
+	})
+
+	t.Run("empty origin", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		e := newExpect(reporter)
+
+		cors := e.Preflight("/users", "", "PUT")
+
+		cors.chain.assertFailed(t)
+		assert.True(t, reporter.reported)
+	})
+
+	t.Run("empty method", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		e := newExpect(reporter)
+
+		cors := e.Preflight("/users", "https://app.example.com", "")
+
+		cors.chain.assertFailed(t)
+		assert.True(t, reporter.reported)
+	})
+}
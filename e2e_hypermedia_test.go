@@ -0,0 +1,186 @@
+package httpexpect
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func createHypermediaHandler() http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/api/articles", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/vnd.api+json")
+
+		if r.URL.Query().Get("page") == "2" {
+			_, _ = w.Write([]byte(`{
+				"links": {"prev": "articles?page=1"},
+				"data": [{"type": "articles", "id": "2"}]
+			}`))
+			return
+		}
+
+		_, _ = w.Write([]byte(`{
+			"links": {"next": "articles?page=2"},
+			"data": [{"type": "articles", "id": "1"}],
+			"meta": {"total": 2}
+		}`))
+	})
+
+	mux.HandleFunc("/api/orders", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/hal+json")
+		_, _ = w.Write([]byte(`{
+			"_links": {
+				"self": {"href": "/api/orders"},
+				"first": {"href": "/api/orders/1"}
+			},
+			"_embedded": {
+				"orders": [
+					{"_links": {"self": {"href": "/api/orders/1"}}, "total": 30}
+				]
+			}
+		}`))
+	})
+
+	mux.HandleFunc("/api/orders/1", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/hal+json")
+		_, _ = w.Write([]byte(`{
+			"_links": {"self": {"href": "/api/orders/1"}},
+			"total": 30
+		}`))
+	})
+
+	mux.HandleFunc("/api/items", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/vnd.api+json")
+
+		query := r.URL.Query()
+		if query.Get("token") != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		if query.Get("page") == "2" {
+			_, _ = w.Write([]byte(`{
+				"data": [{"type": "items", "id": "2"}]
+			}`))
+			return
+		}
+
+		_, _ = w.Write([]byte(`{
+			"links": {"next": "items?page=2"},
+			"data": [{"type": "items", "id": "1"}]
+		}`))
+	})
+
+	mux.HandleFunc("/api/pages", func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+
+		w.Header().Set("Content-Type", "application/hal+json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"_links": map[string]interface{}{
+				"next": map[string]interface{}{"href": "/api/pages?page=2"},
+			},
+			"page":  query.Get("page"),
+			"limit": query.Get("limit"),
+		})
+	})
+
+	return mux
+}
+
+func TestE2EHypermedia_JSONAPI(t *testing.T) {
+	server := httptest.NewServer(createHypermediaHandler())
+	defer server.Close()
+
+	e := Default(t, server.URL).Builder(func(req *Request) {
+		req.WithHeader("Authorization", "Bearer token")
+	})
+
+	doc := e.GET("/api/articles").
+		Expect().
+		Status(http.StatusOK).
+		JSONAPI()
+
+	doc.Meta().HasValue("total", 2)
+	doc.Data().Array().Value(0).Object().HasValue("id", "1")
+
+	next := doc.Follow("next").
+		Expect().
+		Status(http.StatusOK).
+		JSONAPI()
+
+	next.Data().Array().Value(0).Object().HasValue("id", "2")
+
+	next.Follow("prev").
+		Expect().
+		Status(http.StatusOK).
+		JSONAPI().
+		Data().Array().Value(0).Object().HasValue("id", "1")
+}
+
+func TestE2EHypermedia_HAL(t *testing.T) {
+	server := httptest.NewServer(createHypermediaHandler())
+	defer server.Close()
+
+	e := Default(t, server.URL)
+
+	hal := e.GET("/api/orders").
+		Expect().
+		Status(http.StatusOK).
+		HAL()
+
+	hal.Embedded("orders").Array().Value(0).Object().HasValue("total", 30)
+
+	hal.Follow("first").
+		Expect().
+		Status(http.StatusOK).
+		HAL().
+		Links().Value("self").Object().HasValue("href", "/api/orders/1")
+}
+
+func TestE2EHypermedia_FollowQuery(t *testing.T) {
+	server := httptest.NewServer(createHypermediaHandler())
+	defer server.Close()
+
+	e := Default(t, server.URL).Builder(func(req *Request) {
+		req.WithQuery("token", "secret")
+	})
+
+	doc := e.GET("/api/items").
+		Expect().
+		Status(http.StatusOK).
+		JSONAPI()
+
+	doc.Data().Array().Value(0).Object().HasValue("id", "1")
+
+	doc.Follow("next").
+		Expect().
+		Status(http.StatusOK).
+		JSONAPI().
+		Data().Array().Value(0).Object().HasValue("id", "2")
+}
+
+func TestE2EHypermedia_FollowWithQuery(t *testing.T) {
+	server := httptest.NewServer(createHypermediaHandler())
+	defer server.Close()
+
+	e := Default(t, server.URL)
+
+	e.GET("/api/pages").
+		Expect().
+		Status(http.StatusOK).
+		HAL().
+		Follow("next").
+		WithQuery("limit", 10).
+		Expect().
+		Status(http.StatusOK).
+		JSON(ContentOpts{MediaType: "application/hal+json"}).Object().
+		HasValue("page", "2").
+		HasValue("limit", "10")
+}
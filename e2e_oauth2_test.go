@@ -0,0 +1,363 @@
+package httpexpect
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type oauth2Stub struct {
+	mu sync.Mutex
+
+	expiresIn int
+
+	tokenRequests []url.Values
+	codes         map[string]string // code => code_challenge
+	tokens        map[string]bool   // access token => valid
+	counter       int
+}
+
+func newOAuth2Stub(expiresIn int) *oauth2Stub {
+	return &oauth2Stub{
+		expiresIn: expiresIn,
+		codes:     map[string]string{},
+		tokens:    map[string]bool{},
+	}
+}
+
+func (s *oauth2Stub) revokeAll() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for k := range s.tokens {
+		s.tokens[k] = false
+	}
+}
+
+func (s *oauth2Stub) grants() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var grants []string
+	for _, params := range s.tokenRequests {
+		grants = append(grants, params.Get("grant_type"))
+	}
+	return grants
+}
+
+func (s *oauth2Stub) handler() http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/authorize", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		q := r.URL.Query()
+
+		if q.Get("code_challenge_method") != "S256" || q.Get("client_id") != "id" {
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+
+		s.counter++
+		code := fmt.Sprintf("code%d", s.counter)
+		s.codes[code] = q.Get("code_challenge")
+
+		redirect := q.Get("redirect_uri") + "?" + url.Values{
+			"code":  []string{code},
+			"state": []string{q.Get("state")},
+		}.Encode()
+
+		http.Redirect(w, r, redirect, http.StatusFound)
+	})
+
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		_ = r.ParseForm()
+		s.tokenRequests = append(s.tokenRequests, r.PostForm)
+
+		if r.PostForm.Get("client_id") != "id" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		switch r.PostForm.Get("grant_type") {
+		case "client_credentials":
+			if r.PostForm.Get("client_secret") != "secret" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+
+		case "password":
+			if r.PostForm.Get("username") != "user" ||
+				r.PostForm.Get("password") != "pass" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+
+		case "authorization_code":
+			challenge, ok := s.codes[r.PostForm.Get("code")]
+			sum := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+			if !ok || challenge != base64.RawURLEncoding.EncodeToString(sum[:]) {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			delete(s.codes, r.PostForm.Get("code"))
+
+		case "refresh_token":
+			if !strings.HasPrefix(r.PostForm.Get("refresh_token"), "refresh") {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		s.counter++
+		token := fmt.Sprintf("token%d", s.counter)
+		s.tokens[token] = true
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"access_token":  token,
+			"token_type":    "bearer",
+			"refresh_token": fmt.Sprintf("refresh%d", s.counter),
+			"expires_in":    s.expiresIn,
+		})
+	})
+
+	mux.HandleFunc("/protected", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !s.tokens[token] {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		_, _ = w.Write([]byte(token))
+	})
+
+	return mux
+}
+
+func TestE2EOAuth2_ClientCredentials(t *testing.T) {
+	stub := newOAuth2Stub(3600)
+
+	server := httptest.NewServer(stub.handler())
+	defer server.Close()
+
+	e := Default(t, server.URL)
+
+	e.GET("/protected").
+		Expect().
+		Status(http.StatusUnauthorized)
+
+	auth := e.OAuth2(OAuth2Config{
+		Grant:        OAuth2ClientCredentials,
+		TokenURL:     server.URL + "/token",
+		ClientID:     "id",
+		ClientSecret: "secret",
+		Scopes:       []string{"read", "write"},
+	})
+
+	auth.GET("/protected").
+		Expect().
+		Status(http.StatusOK).
+		Body().IsEqual("token1")
+
+	auth.GET("/protected").
+		Expect().
+		Status(http.StatusOK).
+		Body().IsEqual("token1")
+
+	assert.Equal(t, []string{"client_credentials"}, stub.grants())
+	assert.Equal(t, "read write", stub.tokenRequests[0].Get("scope"))
+
+	keys := e.Env().Glob("oauth2.token:*")
+	require.Equal(t, 1, len(keys))
+
+	token, ok := e.Env().Get(keys[0]).(*OAuth2Token)
+	require.True(t, ok)
+	assert.Equal(t, "token1", token.AccessToken)
+	assert.Equal(t, "refresh1", token.RefreshToken)
+	assert.False(t, token.Expiry.IsZero())
+}
+
+func TestE2EOAuth2_Password(t *testing.T) {
+	stub := newOAuth2Stub(3600)
+
+	e := WithConfig(Config{
+		BaseURL:  "http://example.com",
+		Reporter: NewAssertReporter(t),
+		Client: &http.Client{
+			Transport: NewBinder(stub.handler()),
+		},
+	})
+
+	auth := e.OAuth2(OAuth2Config{
+		Grant:    OAuth2Password,
+		TokenURL: "http://example.com/token",
+		ClientID: "id",
+		Username: "user",
+		Password: "pass",
+		EnvKey:   "token",
+	})
+
+	auth.GET("/protected").
+		Expect().
+		Status(http.StatusOK).
+		Body().IsEqual("token1")
+
+	assert.Equal(t, []string{"password"}, stub.grants())
+	assert.True(t, e.Env().Has("token"))
+}
+
+func TestE2EOAuth2_AuthorizationCode(t *testing.T) {
+	stub := newOAuth2Stub(3600)
+
+	server := httptest.NewServer(stub.handler())
+	defer server.Close()
+
+	e := Default(t, server.URL)
+
+	auth := e.OAuth2(OAuth2Config{
+		Grant:       OAuth2AuthorizationCode,
+		AuthURL:     server.URL + "/authorize",
+		TokenURL:    server.URL + "/token",
+		RedirectURL: "http://localhost/callback",
+		ClientID:    "id",
+	})
+
+	auth.GET("/protected").
+		Expect().
+		Status(http.StatusOK).
+		Body().IsEqual("token2")
+
+	assert.Equal(t, []string{"authorization_code"}, stub.grants())
+}
+
+func TestE2EOAuth2_Renewal(t *testing.T) {
+	t.Run("expired token", func(t *testing.T) {
+		// token is always considered expired because of ExpiryDelta
+		stub := newOAuth2Stub(1)
+
+		server := httptest.NewServer(stub.handler())
+		defer server.Close()
+
+		auth := Default(t, server.URL).OAuth2(OAuth2Config{
+			TokenURL:     server.URL + "/token",
+			ClientID:     "id",
+			ClientSecret: "secret",
+		})
+
+		auth.GET("/protected").
+			Expect().
+			Status(http.StatusOK).
+			Body().IsEqual("token1")
+
+		auth.GET("/protected").
+			Expect().
+			Status(http.StatusOK).
+			Body().IsEqual("token2")
+
+		assert.Equal(t, []string{"client_credentials", "refresh_token"}, stub.grants())
+	})
+
+	t.Run("rejected token", func(t *testing.T) {
+		stub := newOAuth2Stub(3600)
+
+		server := httptest.NewServer(stub.handler())
+		defer server.Close()
+
+		auth := Default(t, server.URL).OAuth2(OAuth2Config{
+			TokenURL:     server.URL + "/token",
+			ClientID:     "id",
+			ClientSecret: "secret",
+		})
+
+		auth.GET("/protected").
+			Expect().
+			Status(http.StatusOK).
+			Body().IsEqual("token1")
+
+		stub.revokeAll()
+
+		auth.POST("/protected").
+			WithText("body").
+			Expect().
+			Status(http.StatusOK).
+			Body().IsEqual("token2")
+
+		assert.Equal(t, []string{"client_credentials", "refresh_token"}, stub.grants())
+	})
+}
+
+func TestE2EOAuth2_Failures(t *testing.T) {
+	stub := newOAuth2Stub(3600)
+
+	server := httptest.NewServer(stub.handler())
+	defer server.Close()
+
+	t.Run("invalid config", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		e := WithConfig(Config{
+			BaseURL:  server.URL,
+			Reporter: reporter,
+		})
+
+		e.OAuth2(OAuth2Config{})
+		assert.True(t, reporter.reported)
+	})
+
+	t.Run("missing auth url", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		e := WithConfig(Config{
+			BaseURL:  server.URL,
+			Reporter: reporter,
+		})
+
+		e.OAuth2(OAuth2Config{
+			Grant:    OAuth2AuthorizationCode,
+			TokenURL: server.URL + "/token",
+		})
+		assert.True(t, reporter.reported)
+	})
+
+	t.Run("rejected credentials", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		e := WithConfig(Config{
+			BaseURL:  server.URL,
+			Reporter: reporter,
+		})
+
+		auth := e.OAuth2(OAuth2Config{
+			TokenURL:     server.URL + "/token",
+			ClientID:     "id",
+			ClientSecret: "bad",
+		})
+		assert.False(t, reporter.reported)
+
+		resp := auth.GET("/protected").Expect()
+		resp.chain.assertFailed(t)
+		assert.True(t, reporter.reported)
+	})
+}
@@ -0,0 +1,307 @@
+package httpexpect
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type paginateStub struct {
+	mu sync.Mutex
+
+	items    []int
+	pageSize int
+
+	requests []*http.Request
+}
+
+func (s *paginateStub) page(r *http.Request, param string) ([]int, int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requests = append(s.requests, r)
+
+	start := 0
+	if v := r.URL.Query().Get(param); v != "" {
+		start, _ = strconv.Atoi(v)
+	}
+
+	end := start + s.pageSize
+	if end >= len(s.items) {
+		return s.items[start:], -1
+	}
+
+	return s.items[start:end], end
+}
+
+func (s *paginateStub) handler() http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/links", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Token") != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		items, next := s.page(r, "offset")
+
+		if next >= 0 {
+			w.Header().Add("Link", `</links?offset=0>; rel="first"`)
+			w.Header().Add("Link", fmt.Sprintf(`</links?offset=%d>; rel="next"`, next))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(items)
+	})
+
+	mux.HandleFunc("/cursor", func(w http.ResponseWriter, r *http.Request) {
+		items, next := s.page(r, "after")
+
+		body := map[string]interface{}{
+			"data": items,
+		}
+		if next >= 0 {
+			body["meta"] = map[string]interface{}{
+				"next": strconv.Itoa(next),
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(body)
+	})
+
+	mux.HandleFunc("/broken", func(w http.ResponseWriter, r *http.Request) {
+		items, next := s.page(r, "offset")
+
+		if next >= 0 {
+			w.Header().Add("Link", fmt.Sprintf(`</broken?offset=%d>; rel="next"`, next))
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(items)
+		} else {
+			w.Header().Set("Content-Type", "text/plain")
+			_, _ = w.Write([]byte("oops"))
+		}
+	})
+
+	return mux
+}
+
+func TestE2EPaginate_Links(t *testing.T) {
+	stub := &paginateStub{
+		items:    []int{1, 2, 3, 4, 5, 6, 7},
+		pageSize: 3,
+	}
+
+	server := httptest.NewServer(stub.handler())
+	defer server.Close()
+
+	e := Default(t, server.URL)
+
+	e.Paginate(e.GET("/links").WithHeader("X-Token", "secret")).
+		IsEqual([]int{1, 2, 3, 4, 5, 6, 7})
+
+	assert.Equal(t, 3, len(stub.requests))
+
+	for _, r := range stub.requests {
+		assert.Equal(t, "secret", r.Header.Get("X-Token"))
+	}
+	assert.Equal(t, "offset=3", stub.requests[1].URL.RawQuery)
+	assert.Equal(t, "offset=6", stub.requests[2].URL.RawQuery)
+}
+
+func TestE2EPaginate_Cursor(t *testing.T) {
+	stub := &paginateStub{
+		items:    []int{1, 2, 3, 4, 5},
+		pageSize: 2,
+	}
+
+	server := httptest.NewServer(stub.handler())
+	defer server.Close()
+
+	e := Default(t, server.URL)
+
+	e.Paginate(e.GET("/cursor").WithQuery("filter", "all"), PaginateOpts{
+		ItemsPath:   "$.data",
+		CursorPath:  "$.meta.next",
+		CursorParam: "after",
+	}).
+		IsEqual([]int{1, 2, 3, 4, 5})
+
+	assert.Equal(t, 3, len(stub.requests))
+
+	for _, r := range stub.requests {
+		assert.Equal(t, "all", r.URL.Query().Get("filter"))
+	}
+	assert.Equal(t, "2", stub.requests[1].URL.Query().Get("after"))
+	assert.Equal(t, "4", stub.requests[2].URL.Query().Get("after"))
+}
+
+func TestE2EPaginate_NumericCursor(t *testing.T) {
+	var cursors []string
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/numeric", func(w http.ResponseWriter, r *http.Request) {
+		cursor := r.URL.Query().Get("cursor")
+		cursors = append(cursors, cursor)
+
+		w.Header().Set("Content-Type", "application/json")
+
+		switch cursor {
+		case "":
+			_, _ = w.Write([]byte(`{"data": [1], "next": 12345678901234567891}`))
+		case "12345678901234567891":
+			_, _ = w.Write([]byte(`{"data": [2], "next": 1e21}`))
+		default:
+			_, _ = w.Write([]byte(`{"data": [3]}`))
+		}
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	e := Default(t, server.URL)
+
+	e.Paginate(e.GET("/numeric"), PaginateOpts{
+		ItemsPath:  "$.data",
+		CursorPath: "$.next",
+	}).
+		IsEqual([]int{1, 2, 3})
+
+	assert.Equal(t, []string{
+		"",
+		"12345678901234567891",
+		"1000000000000000000000",
+	}, cursors)
+}
+
+func TestE2EPaginate_Failures(t *testing.T) {
+	stub := &paginateStub{
+		items:    []int{1, 2, 3, 4, 5},
+		pageSize: 2,
+	}
+
+	server := httptest.NewServer(stub.handler())
+	defer server.Close()
+
+	newExpect := func(reporter Reporter) *Expect {
+		return WithConfig(Config{
+			BaseURL:  server.URL,
+			Reporter: reporter,
+		})
+	}
+
+	t.Run("max pages", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		e := newExpect(reporter)
+
+		arr := e.Paginate(e.GET("/links").WithHeader("X-Token", "secret"),
+			PaginateOpts{
+				MaxPages: 2,
+			})
+
+		arr.chain.assertFailed(t)
+		assert.True(t, reporter.reported)
+	})
+
+	t.Run("page failure", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		e := newExpect(reporter)
+
+		arr := e.Paginate(e.GET("/broken"))
+
+		arr.chain.assertFailed(t)
+		assert.True(t, reporter.reported)
+	})
+
+	t.Run("bad items path", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		e := newExpect(reporter)
+
+		arr := e.Paginate(e.GET("/cursor"), PaginateOpts{
+			ItemsPath: "$.meta",
+		})
+
+		arr.chain.assertFailed(t)
+		assert.True(t, reporter.reported)
+	})
+
+	t.Run("invalid items path", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		e := newExpect(reporter)
+
+		numRequests := len(stub.requests)
+
+		arr := e.Paginate(e.GET("/cursor"), PaginateOpts{
+			ItemsPath: "$.data[",
+		})
+
+		arr.chain.assertFailed(t)
+		assert.True(t, reporter.reported)
+		assert.Equal(t, numRequests, len(stub.requests))
+	})
+
+	t.Run("invalid cursor path", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		e := newExpect(reporter)
+
+		arr := e.Paginate(e.GET("/cursor"), PaginateOpts{
+			ItemsPath:  "$.data",
+			CursorPath: "meta.next",
+		})
+
+		arr.chain.assertFailed(t)
+		assert.True(t, reporter.reported)
+	})
+
+	t.Run("ambiguous items path", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		e := newExpect(reporter)
+
+		arr := e.Paginate(e.GET("/cursor"), PaginateOpts{
+			ItemsPath: "$..*",
+		})
+
+		arr.chain.assertFailed(t)
+		assert.True(t, reporter.reported)
+	})
+
+	t.Run("ambiguous cursor path", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		e := newExpect(reporter)
+
+		arr := e.Paginate(e.GET("/cursor"), PaginateOpts{
+			ItemsPath:  "$.data",
+			CursorPath: "$..*",
+		})
+
+		arr.chain.assertFailed(t)
+		assert.True(t, reporter.reported)
+	})
+
+	t.Run("nil request", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		e := newExpect(reporter)
+
+		arr := e.Paginate(nil)
+
+		arr.chain.assertFailed(t)
+		assert.True(t, reporter.reported)
+	})
+
+	t.Run("multiple options", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		e := newExpect(reporter)
+
+		arr := e.Paginate(e.GET("/links"), PaginateOpts{}, PaginateOpts{})
+
+		arr.chain.assertFailed(t)
+		assert.True(t, reporter.reported)
+	})
+}
@@ -0,0 +1,83 @@
+package httpexpect
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func rangeHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		http.ServeContent(w, r, "", time.Time{},
+			bytes.NewReader([]byte("0123456789abcdef")))
+	})
+}
+
+func TestE2ERange_Single(t *testing.T) {
+	server := httptest.NewServer(rangeHandler())
+	defer server.Close()
+
+	e := Default(t, server.URL)
+
+	resp := e.GET("/").WithRange(4, 9).
+		Expect().
+		Status(http.StatusPartialContent)
+
+	resp.Body().IsEqual("456789")
+
+	cr := resp.ContentRange()
+	cr.Unit().IsEqual("bytes")
+	cr.Start().IsEqual(4)
+	cr.End().IsEqual(9)
+	cr.Total().IsEqual(16)
+
+	resp = e.GET("/").WithRange(-3).
+		Expect().
+		Status(http.StatusPartialContent)
+
+	resp.Body().IsEqual("def")
+	resp.ContentRange().Start().IsEqual(13)
+}
+
+func TestE2ERange_Multiple(t *testing.T) {
+	server := httptest.NewServer(rangeHandler())
+	defer server.Close()
+
+	e := Default(t, server.URL)
+
+	parts := e.GET("/").
+		WithRanges(
+			ByteRange{Start: 0, End: 1},
+			ByteRange{Start: 10, End: -1},
+		).
+		Expect().
+		Status(http.StatusPartialContent).
+		RangeParts()
+
+	parts.Length().IsEqual(2)
+
+	parts.Part(0).ContentType().IsEqual("text/plain")
+	parts.Part(0).ContentRange().Start().IsEqual(0)
+	parts.Part(0).ContentRange().End().IsEqual(1)
+	parts.Part(0).Body().IsEqual("01")
+
+	parts.Part(1).ContentRange().Start().IsEqual(10)
+	parts.Part(1).ContentRange().End().IsEqual(15)
+	parts.Part(1).Body().IsEqual("abcdef")
+}
+
+func TestE2ERange_NotSatisfiable(t *testing.T) {
+	server := httptest.NewServer(rangeHandler())
+	defer server.Close()
+
+	e := Default(t, server.URL)
+
+	resp := e.GET("/").WithRange(100).
+		Expect().
+		Status(http.StatusRequestedRangeNotSatisfiable)
+
+	resp.ContentRange().Total().IsEqual(16)
+}
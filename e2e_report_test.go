@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"runtime"
 	"strings"
 	"testing"
 
@@ -77,6 +78,41 @@ func TestE2EReport_Aliases(t *testing.T) {
 	assert.Contains(t, rep.reported, "foo.Object().ContainsKey()")
 }
 
+func TestE2EReport_Location(t *testing.T) {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/test", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"foo":123}`))
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	rep := &recordingReporter{}
+
+	e := WithConfig(Config{
+		TestName: "TestExample",
+		BaseURL:  server.URL,
+		Reporter: rep,
+	})
+
+	var line int
+
+	checkFoo := func(obj *Object) {
+		_, _, line, _ = runtime.Caller(0)
+		obj.Value("foo").Number().IsEqual(456) // will fail
+	}
+
+	checkFoo(e.GET("/test").
+		Expect().
+		JSON().
+		Object())
+
+	assert.Contains(t, rep.reported,
+		fmt.Sprintf("location:\n  e2e_report_test.go:%d", line+1))
+}
+
 func TestE2EReport_LineWidth(t *testing.T) {
 	mux := http.NewServeMux()
 
@@ -0,0 +1,454 @@
+package httpexpect
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// EqualOption configures comparison performed by IsEqualWith and
+// NotEqualWith methods of Value, Object, and Array.
+//
+// Options that accept paths use JSONPath syntax, as defined by RFC 9535
+// (same as in Value.PathAll), where "$" denotes compared value itself:
+//
+//	$.foo, $['foo']   - member "foo" of an object
+//	$[0], $[-1]       - first and last element of an array
+//	$.*, $[*]         - any member of an object or element of an array
+//	$..foo            - member "foo" at any depth
+//	$[?@.id > 10]     - elements matching filter expression
+//
+// Segments may be combined, e.g. "$.items[*].price" or "$..meta.createdAt".
+// Path is evaluated against both actual and expected values, and option
+// applies to every location matched in any of them.
+type EqualOption func(*equalOptions) error
+
+type equalOptions struct {
+	ignore        []*equalPath
+	unordered     []*equalPath
+	tolerance     []equalTolerance
+	nullAsMissing bool
+}
+
+type equalTolerance struct {
+	path  *equalPath
+	delta float64
+}
+
+// IgnorePaths returns an EqualOption that excludes values matching
+// given paths from comparison. Ignored members may be present on either
+// side or absent on both.
+//
+// Example:
+//
+//	object.IsEqualWith(expected, IgnorePaths("$.id", "$..createdAt"))
+func IgnorePaths(paths ...string) EqualOption {
+	return func(opts *equalOptions) error {
+		for _, p := range paths {
+			path, err := parseEqualPath(p)
+			if err != nil {
+				return err
+			}
+			opts.ignore = append(opts.ignore, path)
+		}
+		return nil
+	}
+}
+
+// Tolerance returns an EqualOption that allows numbers matching given
+// path to differ by at most delta.
+//
+// Example:
+//
+//	object.IsEqualWith(expected, Tolerance("$.items[*].price", 0.01))
+func Tolerance(path string, delta float64) EqualOption {
+	return func(opts *equalOptions) error {
+		if math.IsNaN(delta) || delta < 0 {
+			return fmt.Errorf("invalid tolerance %v, expected non-negative number",
+				delta)
+		}
+		p, err := parseEqualPath(path)
+		if err != nil {
+			return err
+		}
+		opts.tolerance = append(opts.tolerance, equalTolerance{path: p, delta: delta})
+		return nil
+	}
+}
+
+// UnorderedAt returns an EqualOption that compares arrays matching given
+// path regardless of element order. Arrays should have same elements,
+// with same number of repetitions.
+//
+// Example:
+//
+//	object.IsEqualWith(expected, UnorderedAt("$.tags"))
+func UnorderedAt(path string) EqualOption {
+	return func(opts *equalOptions) error {
+		p, err := parseEqualPath(path)
+		if err != nil {
+			return err
+		}
+		opts.unordered = append(opts.unordered, p)
+		return nil
+	}
+}
+
+// TreatNullAsMissing returns an EqualOption that treats object members
+// with null values same as absent members.
+//
+// Example:
+//
+//	object := NewObject(t, map[string]interface{}{"foo": 1, "bar": nil})
+//	object.IsEqualWith(map[string]interface{}{"foo": 1}, TreatNullAsMissing())
+func TreatNullAsMissing() EqualOption {
+	return func(opts *equalOptions) error {
+		opts.nullAsMissing = true
+		return nil
+	}
+}
+
+func buildEqualOptions(opChain *chain, options []EqualOption) (*equalOptions, bool) {
+	opts := &equalOptions{}
+
+	for _, option := range options {
+		if option == nil {
+			opChain.fail(AssertionFailure{
+				Type: AssertUsage,
+				Errors: []error{
+					errors.New("unexpected nil option"),
+				},
+			})
+			return nil, false
+		}
+
+		if err := option(opts); err != nil {
+			opChain.fail(AssertionFailure{
+				Type: AssertUsage,
+				Errors: []error{
+					errors.New("invalid equality option"),
+					err,
+				},
+			})
+			return nil, false
+		}
+	}
+
+	return opts, true
+}
+
+// Compare canonical values using given options.
+// Returns list of found differences; empty list means values are equal.
+func compareWithOptions(opts *equalOptions, actual, expected interface{}) []error {
+	for _, p := range opts.ignore {
+		p.resolve(actual, expected)
+	}
+	for _, p := range opts.unordered {
+		p.resolve(actual, expected)
+	}
+	for _, t := range opts.tolerance {
+		t.path.resolve(actual, expected)
+	}
+
+	var diffs []error
+	opts.compare(&diffs, nil, actual, expected)
+	return diffs
+}
+
+func (opts *equalOptions) compare(
+	diffs *[]error, path []interface{}, actual, expected interface{},
+) {
+	if opts.matchAny(opts.ignore, path) {
+		return
+	}
+
+	switch e := expected.(type) {
+	case *Placeholder:
+		if err := e.match(actual); err != nil {
+			*diffs = append(*diffs, fmt.Errorf("at %s: %s", formatEqualPath(path), err))
+		}
+		return
+
+	case map[string]interface{}:
+		if a, ok := actual.(map[string]interface{}); ok {
+			opts.compareObjects(diffs, path, a, e)
+			return
+		}
+
+	case []interface{}:
+		if a, ok := actual.([]interface{}); ok {
+			if opts.matchAny(opts.unordered, path) {
+				opts.compareUnordered(diffs, path, a, e)
+			} else {
+				opts.compareArrays(diffs, path, a, e)
+			}
+			return
+		}
+
+	case float64, json.Number:
+		if isNumber(actual) {
+			if !opts.numbersEqual(path, actual, e) {
+				*diffs = append(*diffs, fmt.Errorf("at %s: expected %v, got %v",
+					formatEqualPath(path), e, actual))
+			}
+			return
+		}
+	}
+
+	if !canonEqual(actual, expected) {
+		*diffs = append(*diffs, fmt.Errorf("at %s: expected %s, got %s",
+			formatEqualPath(path), formatEqualValue(expected), formatEqualValue(actual)))
+	}
+}
+
+func (opts *equalOptions) compareObjects(
+	diffs *[]error, path []interface{}, actual, expected map[string]interface{},
+) {
+	for _, k := range mergeKeys(actual, expected) {
+		aVal, aOk := actual[k]
+		eVal, eOk := expected[k]
+
+		if opts.nullAsMissing {
+			aOk = aOk && aVal != nil
+			eOk = eOk && eVal != nil
+		}
+
+		childPath := appendEqualPath(path, k)
+
+		if opts.matchAny(opts.ignore, childPath) {
+			continue
+		}
+
+		switch {
+		case aOk && eOk:
+			opts.compare(diffs, childPath, aVal, eVal)
+		case eOk:
+			*diffs = append(*diffs, fmt.Errorf("at %s: missing member",
+				formatEqualPath(childPath)))
+		case aOk:
+			*diffs = append(*diffs, fmt.Errorf("at %s: unexpected member",
+				formatEqualPath(childPath)))
+		}
+	}
+}
+
+func (opts *equalOptions) compareArrays(
+	diffs *[]error, path []interface{}, actual, expected []interface{},
+) {
+	if len(actual) != len(expected) {
+		*diffs = append(*diffs, fmt.Errorf("at %s: expected %d elements, got %d",
+			formatEqualPath(path), len(expected), len(actual)))
+		return
+	}
+
+	for n := range expected {
+		opts.compare(diffs, appendEqualPath(path, n), actual[n], expected[n])
+	}
+}
+
+func (opts *equalOptions) compareUnordered(
+	diffs *[]error, path []interface{}, actual, expected []interface{},
+) {
+	if len(actual) != len(expected) {
+		*diffs = append(*diffs, fmt.Errorf("at %s: expected %d elements, got %d",
+			formatEqualPath(path), len(expected), len(actual)))
+		return
+	}
+
+	// matches[en][an] is true if expected[en] matches actual[an]
+	matches := make([][]bool, len(expected))
+	for en, eVal := range expected {
+		matches[en] = make([]bool, len(actual))
+		for an, aVal := range actual {
+			var elemDiffs []error
+			opts.compare(&elemDiffs, appendEqualPath(path, an), aVal, eVal)
+
+			matches[en][an] = len(elemDiffs) == 0
+		}
+	}
+
+	// find maximum bipartite matching between expected and actual elements,
+	// so that e.g. a placeholder doesn't consume an element needed by an
+	// exact value; owner[an] is index of expected element matched to actual[an]
+	owner := make([]int, len(actual))
+	for an := range owner {
+		owner[an] = -1
+	}
+
+	for en, eVal := range expected {
+		visited := make([]bool, len(actual))
+
+		if !augmentMatching(matches, owner, visited, en) {
+			*diffs = append(*diffs, fmt.Errorf("at %s: no matching element for %s",
+				formatEqualPath(appendEqualPath(path, en)), formatEqualValue(eVal)))
+		}
+	}
+}
+
+// augmentMatching tries to match expected element en to some actual element,
+// re-assigning previously matched expected elements if needed
+// (Kuhn's augmenting path algorithm).
+func augmentMatching(matches [][]bool, owner []int, visited []bool, en int) bool {
+	for an := range owner {
+		if !matches[en][an] || visited[an] {
+			continue
+		}
+		visited[an] = true
+
+		if owner[an] < 0 || augmentMatching(matches, owner, visited, owner[an]) {
+			owner[an] = en
+			return true
+		}
+	}
+
+	return false
+}
+
+func (opts *equalOptions) numbersEqual(
+	path []interface{}, actual, expected interface{},
+) bool {
+	if canonEqual(actual, expected) {
+		return true
+	}
+
+	a, aOk := canonRat(actual)
+	e, eOk := canonRat(expected)
+
+	if !aOk || !eOk {
+		return false
+	}
+
+	for _, t := range opts.tolerance {
+		if t.path.match(path) {
+			af, _ := a.Float64()
+			ef, _ := e.Float64()
+			return math.Abs(af-ef) <= t.delta
+		}
+	}
+
+	return false
+}
+
+func (opts *equalOptions) matchAny(paths []*equalPath, path []interface{}) bool {
+	for _, p := range paths {
+		if p.match(path) {
+			return true
+		}
+	}
+	return false
+}
+
+func mergeKeys(a, b map[string]interface{}) []string {
+	keys := make([]string, 0, len(a)+len(b))
+	for k := range a {
+		keys = append(keys, k)
+	}
+	for k := range b {
+		if _, ok := a[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func appendEqualPath(path []interface{}, elem interface{}) []interface{} {
+	ret := make([]interface{}, len(path), len(path)+1)
+	copy(ret, path)
+	return append(ret, elem)
+}
+
+var equalPathIdent = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+func formatEqualPath(path []interface{}) string {
+	var b strings.Builder
+
+	b.WriteString("$")
+
+	for _, elem := range path {
+		switch e := elem.(type) {
+		case int:
+			b.WriteString("[" + strconv.Itoa(e) + "]")
+		case string:
+			if equalPathIdent.MatchString(e) {
+				b.WriteString("." + e)
+			} else {
+				b.WriteString("[" + strconv.Quote(e) + "]")
+			}
+		}
+	}
+
+	return b.String()
+}
+
+func formatEqualValue(value interface{}) string {
+	switch value.(type) {
+	case map[string]interface{}:
+		return "object"
+	case []interface{}:
+		return "array"
+	case nil:
+		return "null"
+	case string:
+		return strconv.Quote(value.(string))
+	default:
+		return fmt.Sprint(value)
+	}
+}
+
+// Path used in options, compiled as JSONPath query.
+type equalPath struct {
+	query *jsonPathQuery
+
+	// normalized paths of matched nodes, e.g. "$['items'][0]"
+	nodes map[string]bool
+}
+
+func parseEqualPath(s string) (*equalPath, error) {
+	query, err := parseJSONPath(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid path %q: %s", s, err)
+	}
+
+	return &equalPath{query: query}, nil
+}
+
+// Evaluate query against compared values and remember matched nodes.
+func (p *equalPath) resolve(actual, expected interface{}) {
+	p.nodes = map[string]bool{}
+
+	for _, root := range []interface{}{actual, expected} {
+		for _, node := range p.query.eval(root) {
+			p.nodes[node.path] = true
+		}
+	}
+}
+
+// Check if location in compared values is matched by path;
+// should be called after resolve.
+func (p *equalPath) match(path []interface{}) bool {
+	return p.nodes[normalizeEqualPath(path)]
+}
+
+// Format location in the same way as normalized paths of JSONPath nodes.
+func normalizeEqualPath(path []interface{}) string {
+	var b strings.Builder
+
+	b.WriteString("$")
+
+	for _, elem := range path {
+		switch e := elem.(type) {
+		case int:
+			b.WriteString("[" + strconv.Itoa(e) + "]")
+		case string:
+			b.WriteString(formatJSONPathName(e))
+		}
+	}
+
+	return b.String()
+}
@@ -0,0 +1,332 @@
+package httpexpect
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEqualOptions_Path(t *testing.T) {
+	var doc interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"a": {"b": 1, "id": 2},
+		"a.b": 3,
+		"id": 4,
+		"items": [
+			{"price": 1, "id": 5, "meta": {"createdAt": "x"}},
+			{"price": 20}
+		],
+		"meta": {"createdAt": "y"}
+	}`), &doc))
+
+	cases := []struct {
+		pattern string
+		matches [][]interface{}
+		misses  [][]interface{}
+	}{
+		{
+			pattern: "$",
+			matches: [][]interface{}{{}},
+			misses:  [][]interface{}{{"a"}},
+		},
+		{
+			pattern: "$.a",
+			matches: [][]interface{}{{"a"}},
+			misses:  [][]interface{}{{}, {"id"}, {"a", "b"}, {0}},
+		},
+		{
+			pattern: "$['a.b']",
+			matches: [][]interface{}{{"a.b"}},
+			misses:  [][]interface{}{{"a", "b"}},
+		},
+		{
+			pattern: `$["items"][1]`,
+			matches: [][]interface{}{{"items", 1}},
+			misses:  [][]interface{}{{"items", 0}, {"items", "1"}},
+		},
+		{
+			pattern: "$.items[-1]",
+			matches: [][]interface{}{{"items", 1}},
+			misses:  [][]interface{}{{"items", 0}},
+		},
+		{
+			pattern: "$.items[*].price",
+			matches: [][]interface{}{{"items", 0, "price"}, {"items", 1, "price"}},
+			misses:  [][]interface{}{{"items", "price"}, {"items", 0, "id"}},
+		},
+		{
+			pattern: "$.items[?@.price > 10].price",
+			matches: [][]interface{}{{"items", 1, "price"}},
+			misses:  [][]interface{}{{"items", 0, "price"}},
+		},
+		{
+			pattern: "$.*",
+			matches: [][]interface{}{{"a"}, {"items"}},
+			misses:  [][]interface{}{{}, {"a", "b"}},
+		},
+		{
+			pattern: "$..id",
+			matches: [][]interface{}{{"id"}, {"a", "id"}, {"items", 0, "id"}},
+			misses:  [][]interface{}{{"a"}, {"items", 1, "id"}},
+		},
+		{
+			pattern: "$..meta.createdAt",
+			matches: [][]interface{}{
+				{"meta", "createdAt"},
+				{"items", 0, "meta", "createdAt"},
+			},
+			misses: [][]interface{}{{"meta"}},
+		},
+		{
+			pattern: "$..[0]",
+			matches: [][]interface{}{{"items", 0}},
+			misses:  [][]interface{}{{"items", 1}, {"items", 0, "id"}},
+		},
+		{
+			pattern: "$..*",
+			matches: [][]interface{}{{"a"}, {"items", 0, "meta", "createdAt"}},
+			misses:  [][]interface{}{{}},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.pattern, func(t *testing.T) {
+			path, err := parseEqualPath(tc.pattern)
+			require.NoError(t, err)
+
+			path.resolve(doc, nil)
+
+			for _, m := range tc.matches {
+				assert.True(t, path.match(m), "%v", m)
+			}
+			for _, m := range tc.misses {
+				assert.False(t, path.match(m), "%v", m)
+			}
+		})
+	}
+
+	t.Run("expected value", func(t *testing.T) {
+		path, err := parseEqualPath("$.b")
+		require.NoError(t, err)
+
+		path.resolve(map[string]interface{}{"a": 1.0},
+			map[string]interface{}{"b": 2.0})
+
+		assert.True(t, path.match([]interface{}{"b"}))
+	})
+
+	for _, pattern := range []string{
+		"", "a", "$a", "$.", "$..", "$[", "$[x]", "$.a[", "$.a..",
+	} {
+		_, err := parseEqualPath(pattern)
+		assert.Error(t, err, pattern)
+	}
+}
+
+func TestEqualOptions_Format(t *testing.T) {
+	assert.Equal(t, "$", formatEqualPath(nil))
+	assert.Equal(t, `$.a[0]["b c"]._d1`,
+		formatEqualPath([]interface{}{"a", 0, "b c", "_d1"}))
+}
+
+func TestEqualOptions_Compare(t *testing.T) {
+	cases := []struct {
+		name     string
+		actual   string
+		expected string
+		options  []EqualOption
+		equal    bool
+	}{
+		{
+			name:     "no options",
+			actual:   `{"a": [1, {"b": "c"}]}`,
+			expected: `{"a": [1, {"b": "c"}]}`,
+			equal:    true,
+		},
+		{
+			name:     "no options, different",
+			actual:   `{"a": [1, {"b": "c"}]}`,
+			expected: `{"a": [1, {"b": "d"}]}`,
+			equal:    false,
+		},
+		{
+			name:     "ignore top-level",
+			actual:   `{"id": 123, "name": "foo"}`,
+			expected: `{"id": 456, "name": "foo"}`,
+			options:  []EqualOption{IgnorePaths("$.id")},
+			equal:    true,
+		},
+		{
+			name:     "ignore missing",
+			actual:   `{"id": 123, "name": "foo"}`,
+			expected: `{"name": "foo"}`,
+			options:  []EqualOption{IgnorePaths("$.id")},
+			equal:    true,
+		},
+		{
+			name:     "ignore recursive",
+			actual:   `{"createdAt": 1, "items": [{"createdAt": 2, "v": 1}]}`,
+			expected: `{"createdAt": 3, "items": [{"createdAt": 4, "v": 1}]}`,
+			options:  []EqualOption{IgnorePaths("$..createdAt")},
+			equal:    true,
+		},
+		{
+			name:     "ignore recursive, other difference",
+			actual:   `{"createdAt": 1, "items": [{"createdAt": 2, "v": 1}]}`,
+			expected: `{"createdAt": 3, "items": [{"createdAt": 4, "v": 2}]}`,
+			options:  []EqualOption{IgnorePaths("$..createdAt")},
+			equal:    false,
+		},
+		{
+			name:     "ignore root",
+			actual:   `1`,
+			expected: `2`,
+			options:  []EqualOption{IgnorePaths("$")},
+			equal:    true,
+		},
+		{
+			name:     "tolerance",
+			actual:   `{"price": 10.004, "count": 3}`,
+			expected: `{"price": 10, "count": 3}`,
+			options:  []EqualOption{Tolerance("$.price", 0.01)},
+			equal:    true,
+		},
+		{
+			name:     "tolerance exceeded",
+			actual:   `{"price": 10.02}`,
+			expected: `{"price": 10}`,
+			options:  []EqualOption{Tolerance("$.price", 0.01)},
+			equal:    false,
+		},
+		{
+			name:     "tolerance other path",
+			actual:   `{"price": 10, "count": 3.001}`,
+			expected: `{"price": 10, "count": 3}`,
+			options:  []EqualOption{Tolerance("$.price", 0.01)},
+			equal:    false,
+		},
+		{
+			name:     "unordered",
+			actual:   `{"tags": ["b", "a", "b"]}`,
+			expected: `{"tags": ["a", "b", "b"]}`,
+			options:  []EqualOption{UnorderedAt("$.tags")},
+			equal:    true,
+		},
+		{
+			name:     "unordered, different counts",
+			actual:   `{"tags": ["b", "a", "a"]}`,
+			expected: `{"tags": ["a", "b", "b"]}`,
+			options:  []EqualOption{UnorderedAt("$.tags")},
+			equal:    false,
+		},
+		{
+			name:     "unordered, different lengths",
+			actual:   `{"tags": ["a", "b"]}`,
+			expected: `{"tags": ["a", "b", "c"]}`,
+			options:  []EqualOption{UnorderedAt("$.tags")},
+			equal:    false,
+		},
+		{
+			name:     "unordered, other path",
+			actual:   `{"tags": ["a"], "ids": [2, 1]}`,
+			expected: `{"tags": ["a"], "ids": [1, 2]}`,
+			options:  []EqualOption{UnorderedAt("$.tags")},
+			equal:    false,
+		},
+		{
+			name:     "unordered nested with ignore",
+			actual:   `{"items": [{"id": 1, "v": "b"}, {"id": 2, "v": "a"}]}`,
+			expected: `{"items": [{"id": 7, "v": "a"}, {"id": 8, "v": "b"}]}`,
+			options: []EqualOption{
+				UnorderedAt("$.items"),
+				IgnorePaths("$.items[*].id"),
+			},
+			equal: true,
+		},
+		{
+			name:     "ignore by filter",
+			actual:   `{"items": [{"id": 1, "tmp": true, "v": 2}, {"id": 2, "v": 3}]}`,
+			expected: `{"items": [{"id": 1, "tmp": true, "v": 9}, {"id": 2, "v": 3}]}`,
+			options:  []EqualOption{IgnorePaths("$.items[?@.tmp == true].v")},
+			equal:    true,
+		},
+		{
+			name:     "ignore by filter, other element",
+			actual:   `{"items": [{"id": 1, "tmp": true, "v": 2}, {"id": 2, "v": 3}]}`,
+			expected: `{"items": [{"id": 1, "tmp": true, "v": 2}, {"id": 2, "v": 4}]}`,
+			options:  []EqualOption{IgnorePaths("$.items[?@.tmp == true].v")},
+			equal:    false,
+		},
+		{
+			name:     "null as missing",
+			actual:   `{"a": 1, "b": null}`,
+			expected: `{"a": 1, "c": null}`,
+			options:  []EqualOption{TreatNullAsMissing()},
+			equal:    true,
+		},
+		{
+			name:     "null without option",
+			actual:   `{"a": 1, "b": null}`,
+			expected: `{"a": 1}`,
+			equal:    false,
+		},
+		{
+			name:     "null as missing, value present",
+			actual:   `{"a": 1, "b": null}`,
+			expected: `{"a": 1, "b": 2}`,
+			options:  []EqualOption{TreatNullAsMissing()},
+			equal:    false,
+		},
+		{
+			name:     "type mismatch",
+			actual:   `{"a": [1]}`,
+			expected: `{"a": {"0": 1}}`,
+			equal:    false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var actual, expected interface{}
+			require.NoError(t, json.Unmarshal([]byte(tc.actual), &actual))
+			require.NoError(t, json.Unmarshal([]byte(tc.expected), &expected))
+
+			opChain := newMockChain(t).enter("test")
+			opts, ok := buildEqualOptions(opChain, tc.options)
+			opChain.leave()
+			require.True(t, ok)
+
+			diffs := compareWithOptions(opts, actual, expected)
+
+			if tc.equal {
+				assert.Empty(t, diffs)
+			} else {
+				assert.NotEmpty(t, diffs)
+			}
+		})
+	}
+}
+
+func TestEqualOptions_Invalid(t *testing.T) {
+	for _, option := range []EqualOption{
+		nil,
+		IgnorePaths("$.a", "foo"),
+		Tolerance("$.a", -1),
+		Tolerance("$.a", math.NaN()),
+		Tolerance("foo", 1),
+		UnorderedAt("$["),
+	} {
+		chain := newMockChain(t)
+
+		opChain := chain.enter("test")
+		opts, ok := buildEqualOptions(opChain, []EqualOption{option})
+		opChain.leave()
+
+		assert.False(t, ok)
+		assert.Nil(t, opts)
+		chain.assertFailed(t)
+	}
+}
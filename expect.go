@@ -79,8 +79,12 @@ package httpexpect
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"io"
 	"net/http"
+	"strings"
+	"sync"
 
 	"github.com/gorilla/websocket"
 )
@@ -220,6 +224,22 @@ type Config struct {
 	// If Environment is nil, a new empty environment is automatically created
 	// when Expect instance is constructed.
 	Environment *Environment
+
+	// DisableDecompression disables automatic decoding of response body.
+	// Default is false.
+	//
+	// By default, if response has "Content-Encoding" header with "gzip",
+	// "deflate", "br" or "zstd" codings, response body is decoded before
+	// it is inspected by methods like Response.Body and Response.JSON.
+	// Usually http.Client already decodes gzip itself, but it doesn't
+	// happen with Binder, FastBinder, or clients with DisableCompression.
+	//
+	// Partial responses, i.e. with "206 Partial Content" status or with
+	// "Content-Range" header, are never decoded, because range of encoded
+	// representation is a slice of encoded bytes that can't be decoded alone.
+	//
+	// If DisableDecompression is true, response body is never decoded.
+	DisableDecompression bool
 }
 
 func (config Config) withDefaults() Config {
@@ -375,6 +395,9 @@ func New(t LoggerReporter, baseURL string) *Expect {
 //   - NewAssertReporter(t) for Config.Reporter
 //   - NewCompactPrinter(t) for Config.Printers
 //
+// If t supports Cleanup (like *testing.T), Default also logs WarningSummary
+// at the end of the test.
+//
 // Example:
 //
 //	func TestSomething(t *testing.T) {
@@ -385,7 +408,7 @@ func New(t LoggerReporter, baseURL string) *Expect {
 //	        Status(http.StatusOK)
 //	}
 func Default(t TestingTB, baseURL string) *Expect {
-	return WithConfig(Config{
+	e := WithConfig(Config{
 		TestName: t.Name(),
 		BaseURL:  baseURL,
 		Reporter: NewAssertReporter(t),
@@ -393,6 +416,16 @@ func Default(t TestingTB, baseURL string) *Expect {
 			NewCompactPrinter(t),
 		},
 	})
+
+	if c, ok := t.(interface{ Cleanup(func()) }); ok {
+		c.Cleanup(func() {
+			if summary := e.WarningSummary(); summary != "" {
+				t.Logf("%s", summary)
+			}
+		})
+	}
+
+	return e
 }
 
 // WithConfig returns a new Expect instance with custom config.
@@ -445,6 +478,64 @@ func (e *Expect) Env() *Environment {
 	return e.chain.env()
 }
 
+// Chain returns public handle to assertion chain of Expect instance,
+// which may be used to create custom matchers not bound to a request.
+// See Chain for details.
+//
+// Example:
+//
+//	e := httpexpect.Default(t, "http://example.com")
+//
+//	opChain := e.Chain().Enter("Money()")
+//	defer opChain.Leave()
+func (e *Expect) Chain() *Chain {
+	return &Chain{chain: e.chain}
+}
+
+// WarningSummary returns a summary of all failed assertions of matchers
+// in warning mode, i.e. returned by AsWarning method, e.g. Value.AsWarning.
+// Returns empty string if there were no such failures.
+//
+// Summary includes all matchers created by this Expect instance and its
+// copies, e.g. ones returned by Builder.
+//
+// When Expect instance is created using Default, and t supports Cleanup
+// (like *testing.T), summary is logged automatically at the end of the test.
+//
+// Example:
+//
+//	e := httpexpect.WithConfig(httpexpect.Config{
+//	    Reporter: httpexpect.NewAssertReporter(t),
+//	})
+//
+//	t.Cleanup(func() {
+//	    t.Log(e.WarningSummary())
+//	})
+//
+//	e.GET("/path").Expect().AsWarning().
+//	    Header("Deprecation").IsEmpty()
+func (e *Expect) WarningSummary() string {
+	messages := e.chain.warningMessages()
+	if len(messages) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+
+	if len(messages) == 1 {
+		sb.WriteString("1 warning reported")
+	} else {
+		fmt.Fprintf(&sb, "%d warnings reported", len(messages))
+	}
+
+	for _, msg := range enumerateFailures("warning", messages) {
+		sb.WriteString("\n\n")
+		sb.WriteString(msg)
+	}
+
+	return sb.String()
+}
+
 func (e *Expect) clone() *Expect {
 	return &Expect{
 		config:   e.config,
@@ -480,6 +571,148 @@ func (e *Expect) Builder(builder func(*Request)) *Expect {
 	return ret
 }
 
+// OAuth2 returns a copy of Expect instance which authorizes every request
+// using OAuth2 access token.
+//
+// Token is obtained from token endpoint on first request using grant type
+// defined by config, and then cached in Environment (see Env method).
+// Token is renewed when it expires, or when server responds with 401;
+// in the latter case the request is resent once. If token endpoint issued
+// a refresh token, it is used for renewal.
+//
+// Authorization header is injected by a builder which wraps the client
+// of every new request, so it should not be overridden by WithClient or
+// WithHandler. Token endpoint is accessed using Config.Client.
+//
+// Example:
+//
+//	e := httpexpect.Default(t, "http://example.com")
+//
+//	auth := e.OAuth2(httpexpect.OAuth2Config{
+//	    Grant:        httpexpect.OAuth2ClientCredentials,
+//	    TokenURL:     "http://example.com/oauth/token",
+//	    ClientID:     "client",
+//	    ClientSecret: "secret",
+//	    Scopes:       []string{"all"},
+//	})
+//
+//	auth.GET("/restricted").
+//	   Expect().
+//	   Status(http.StatusOK)
+func (e *Expect) OAuth2(config OAuth2Config) *Expect {
+	opChain := e.chain.enter("OAuth2()")
+	defer opChain.leave()
+
+	ret := e.clone()
+
+	source := newOAuth2Source(config, e.config.Client, e.chain.env())
+
+	if err := source.validate(); err != nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("invalid OAuth2 config"),
+				err,
+			},
+		})
+		return ret
+	}
+
+	ret.builders = append(ret.builders, func(req *Request) {
+		req.WithClient(source.wrapClient(req.config.Client))
+	})
+	return ret
+}
+
+// Paginate sends given request, follows all subsequent pages, and returns
+// a new Array instance with items from all pages concatenated.
+//
+// Every page should have JSON body. By default, the page body itself should
+// be an array of items, and the next page is found using "Link" header with
+// rel="next". Alternatively, items and cursor of the next page may be taken
+// from page body using JSON paths; see PaginateOpts for details.
+//
+// Next page requests use same method, headers and config (including client)
+// as the given request. If any page request fails, or there are more pages
+// than PaginateOpts.MaxPages, failure is reported.
+//
+// Example:
+//
+//	e := httpexpect.Default(t, "http://example.com")
+//
+//	e.Paginate(e.GET("/users").WithQuery("limit", 10)).
+//	    Length().IsEqual(42)
+//
+//	e.Paginate(e.GET("/events"), httpexpect.PaginateOpts{
+//	    ItemsPath:  "$.items",
+//	    CursorPath: "$.next_cursor",
+//	}).NotEmpty()
+func (e *Expect) Paginate(req *Request, options ...PaginateOpts) *Array {
+	opChain := e.chain.enter("Paginate()")
+	defer opChain.leave()
+
+	if len(options) > 1 {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected multiple options arguments"),
+			},
+		})
+		return newArray(opChain, nil)
+	}
+
+	var opts PaginateOpts
+	if len(options) != 0 {
+		opts = options[0]
+	}
+
+	items := newPaginator(req, opts).run(opChain)
+
+	return newArray(opChain, items)
+}
+
+// Preflight sends CORS preflight request and returns a new CORS instance
+// to inspect response.
+//
+// Preflight request is an OPTIONS request with "Origin" header set to given
+// origin, "Access-Control-Request-Method" header set to given method, and,
+// if request headers are given, "Access-Control-Request-Headers" header set
+// to their lower-cased sorted list, same as browsers do.
+//
+// Example:
+//
+//	e := httpexpect.Default(t, "http://example.com")
+//
+//	e.Preflight("/users", "https://app.example.com", "PUT", "X-Token").
+//	    AllowsOrigin("https://app.example.com").
+//	    AllowsMethod("PUT").
+//	    AllowsHeader("X-Token").
+//	    AllowsCredentials()
+func (e *Expect) Preflight(path, origin, method string, headers ...string) *CORS {
+	opChain := e.chain.enter("Preflight(%q)", path)
+	defer opChain.leave()
+
+	if origin == "" || method == "" {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected empty origin or method"),
+			},
+		})
+		return newCORS(opChain, nil)
+	}
+
+	req := e.newRequest(opChain, http.MethodOptions, path).
+		WithHeader("Origin", origin).
+		WithHeader("Access-Control-Request-Method", method)
+
+	if len(headers) != 0 {
+		req.WithHeader("Access-Control-Request-Headers", corsRequestHeaders(headers))
+	}
+
+	return req.Expect().CORS()
+}
+
 // Matcher returns a copy of Expect instance with given matcher attached to it.
 // Returned copy contains all previously attached matchers plus a new one.
 // Matchers are invoked from Request.Expect method, after retrieving a new response.
@@ -506,6 +739,144 @@ func (e *Expect) Matcher(matcher func(*Response)) *Expect {
 	return ret
 }
 
+// Group runs given function with a copy of Expect instance and reports all
+// failures that occurred inside it as a single failure.
+//
+// Failures with SeverityError that happen in function are not reported
+// immediately. Instead, they are collected, and when function returns,
+// Group reports one consolidated failure that includes every collected
+// failure, formatted as it would be reported without the group, with its
+// own assertion path and location. Successful assertions and failures
+// with other severities are passed to AssertionHandler as usual.
+//
+// This allows to see all failures of a scenario at once, even with
+// reporters that stop the test on first failure, like RequireReporter,
+// and to avoid many disconnected messages with AssertReporter.
+//
+// Group name is added to assertion path of all assertions inside group.
+// Failure of group does not mark Expect instance as failed, so further
+// requests and groups are executed as usual.
+//
+// Example:
+//
+//	e := httpexpect.Default(t, "http://example.com")
+//
+//	e.Group("users", func(e *httpexpect.Expect) {
+//	    e.GET("/users/1").Expect().Status(http.StatusOK)
+//	    e.GET("/users/2").Expect().Status(http.StatusOK)
+//	})
+func (e *Expect) Group(name string, fn func(e *Expect)) {
+	opChain := e.chain.enter("Group(%q)", name)
+	defer opChain.leave()
+
+	opChain.setRoot()
+
+	if fn == nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected nil function argument"),
+			},
+		})
+		return
+	}
+
+	handler := &groupHandler{
+		parent: opChain.handler,
+	}
+
+	groupExpect := e.clone()
+	groupExpect.chain = opChain.clone()
+	groupExpect.chain.setHandler(handler)
+
+	fn(groupExpect)
+
+	if errs := handler.errors(); len(errs) != 0 {
+		opChain.fail(AssertionFailure{
+			Type: AssertOperation,
+			Errors: append([]error{
+				fmt.Errorf("expected: all assertions in group %q succeed,"+
+					" but %d failed", name, len(errs)),
+			}, errs...),
+		})
+	}
+}
+
+// AssertionHandler that collects failures inside Expect.Group.
+type groupHandler struct {
+	mu       sync.Mutex
+	parent   AssertionHandler
+	failures []string
+}
+
+func (h *groupHandler) Success(ctx *AssertionContext) {
+	h.parent.Success(ctx)
+}
+
+func (h *groupHandler) Failure(ctx *AssertionContext, failure *AssertionFailure) {
+	if failure.Severity != SeverityError {
+		h.parent.Failure(ctx, failure)
+		return
+	}
+
+	// context path already includes group name
+	msg := formatFailure(h.parent, ctx, failure)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.failures = append(h.failures, msg)
+}
+
+// Get collected failures, one error per failure.
+func (h *groupHandler) errors() []error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	errs := make([]error, 0, len(h.failures))
+
+	for _, msg := range enumerateFailures("failure", h.failures) {
+		errs = append(errs, errors.New(msg))
+	}
+
+	return errs
+}
+
+// Format failure using formatter of handler, or default formatter
+// if handler is not DefaultAssertionHandler.
+func formatFailure(
+	handler AssertionHandler, ctx *AssertionContext, failure *AssertionFailure,
+) string {
+	var formatter Formatter
+	if defaultHandler, ok := handler.(*DefaultAssertionHandler); ok &&
+		defaultHandler.Formatter != nil {
+		formatter = defaultHandler.Formatter
+	} else {
+		formatter = &DefaultFormatter{}
+	}
+
+	return formatter.FormatFailure(ctx, failure)
+}
+
+// Prepend "<kind> N of M:" header to every message and indent message body.
+func enumerateFailures(kind string, messages []string) []string {
+	result := make([]string, 0, len(messages))
+
+	for n, msg := range messages {
+		lines := strings.Split(strings.TrimSpace(msg), "\n")
+		for i := range lines {
+			if lines[i] != "" {
+				lines[i] = defaultIndent + lines[i]
+			}
+		}
+
+		result = append(result, fmt.Sprintf("%s %d of %d:\n%s",
+			kind, n+1, len(messages), strings.Join(lines, "\n")))
+	}
+
+	return result
+}
+
 // Request returns a new Request instance.
 // Arguments are similar to NewRequest.
 // After creating request, all builders attached to Expect instance are invoked.
@@ -514,7 +885,15 @@ func (e *Expect) Request(method, path string, pathargs ...interface{}) *Request
 	opChain := e.chain.enter("Request(%q)", method)
 	defer opChain.leave()
 
-	req := newRequest(opChain, e.config, method, path, pathargs...)
+	return e.newRequest(opChain, method, path, pathargs...)
+}
+
+func (e *Expect) newRequest(
+	parent *chain, method, path string, pathargs ...interface{},
+) *Request {
+	req := newRequest(parent, e.config, method, path, pathargs...)
+
+	req.owner = e
 
 	for _, builder := range e.builders {
 		builder(req)
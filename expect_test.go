@@ -1,9 +1,11 @@
 package httpexpect
 
 import (
+	"net/http"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestExpect_Constructors(t *testing.T) {
@@ -250,6 +252,300 @@ func TestExpect_Matchers(t *testing.T) {
 	})
 }
 
+func TestExpect_Group(t *testing.T) {
+	t.Run("failures", func(t *testing.T) {
+		var reports []string
+
+		reporter := newMockReporter(t)
+		reporter.reportCb = func() {
+			reports = append(reports, reporter.lastMessage)
+		}
+
+		e := WithConfig(Config{
+			Client: &mockClient{
+				resp: http.Response{StatusCode: http.StatusOK},
+			},
+			AssertionHandler: &DefaultAssertionHandler{
+				Formatter: &DefaultFormatter{},
+				Reporter:  reporter,
+			},
+		})
+
+		e.Group("users", func(e *Expect) {
+			e.GET("/users/1").Expect().Status(http.StatusNotFound)
+			assert.Equal(t, 0, len(reports))
+
+			e.GET("/users/2").Expect().Status(http.StatusOK)
+			assert.Equal(t, 0, len(reports))
+
+			e.GET("/users/3").Expect().Status(http.StatusTeapot)
+			assert.Equal(t, 0, len(reports))
+		})
+
+		require.Equal(t, 1, len(reports))
+		assert.Contains(t, reports[0], `all assertions in group "users" succeed`)
+		assert.Contains(t, reports[0], "failure 1 of 2:")
+		assert.Contains(t, reports[0], "failure 2 of 2:")
+		assert.Contains(t, reports[0], `Group("users").Request("GET")`)
+		assert.Contains(t, reports[0], "404 Not Found")
+		assert.Contains(t, reports[0], "418 I'm a teapot")
+		assert.Contains(t, reports[0], "expect_test.go:275")
+		assert.Contains(t, reports[0], "expect_test.go:281")
+	})
+
+	t.Run("report", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+
+		e := WithConfig(Config{
+			Client: &mockClient{
+				resp: http.Response{StatusCode: http.StatusOK},
+			},
+			AssertionHandler: handler,
+		})
+
+		e.Group("users", func(e *Expect) {
+			e.GET("/users/1").Expect().Status(http.StatusNotFound)
+			assert.Nil(t, handler.failure)
+		})
+
+		require.NotNil(t, handler.failure)
+		assert.Equal(t, AssertOperation, handler.failure.Type)
+		assert.Equal(t, SeverityError, handler.failure.Severity)
+		assert.Equal(t, []string{`Group("users")`}, handler.ctx.Path)
+
+		require.Equal(t, 2, len(handler.failure.Errors))
+		assert.Contains(t, handler.failure.Errors[1].Error(),
+			`Group("users").Request("GET").Expect().Status()`)
+		assert.Contains(t, handler.failure.Errors[1].Error(), "200 OK")
+	})
+
+	t.Run("success", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		e := WithConfig(Config{
+			Client: &mockClient{
+				resp: http.Response{StatusCode: http.StatusOK},
+			},
+			Reporter: reporter,
+		})
+
+		called := false
+
+		e.Group("users", func(e *Expect) {
+			called = true
+			e.GET("/users/1").Expect().Status(http.StatusOK)
+		})
+
+		assert.True(t, called)
+		assert.False(t, reporter.reported)
+		e.chain.assertNotFailed(t)
+	})
+
+	t.Run("expect not failed", func(t *testing.T) {
+		reportCount := 0
+
+		reporter := newMockReporter(t)
+		reporter.reportCb = func() {
+			reportCount++
+		}
+
+		e := WithConfig(Config{
+			Client: &mockClient{
+				resp: http.Response{StatusCode: http.StatusOK},
+			},
+			Reporter: reporter,
+		})
+
+		e.Group("first", func(e *Expect) {
+			e.GET("/users/1").Expect().Status(http.StatusNotFound)
+		})
+
+		e.Group("second", func(e *Expect) {
+			e.GET("/users/1").Expect().Status(http.StatusNotFound)
+		})
+
+		assert.Equal(t, 2, reportCount)
+		assert.False(t, e.chain.failed())
+
+		e.GET("/users/1").Expect().Status(http.StatusNotFound)
+
+		assert.Equal(t, 3, reportCount)
+	})
+
+	t.Run("log severity", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		logger := newMockLogger(t)
+
+		e := WithConfig(Config{
+			AssertionHandler: &DefaultAssertionHandler{
+				Formatter: &DefaultFormatter{},
+				Reporter:  reporter,
+				Logger:    logger,
+			},
+		})
+
+		e.Group("filter", func(e *Expect) {
+			e.Array([]interface{}{1, "a"}).Filter(func(_ int, v *Value) bool {
+				v.Number()
+				return true
+			})
+		})
+
+		assert.True(t, logger.logged)
+		assert.False(t, reporter.reported)
+	})
+
+	t.Run("nested", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+
+		e := WithConfig(Config{
+			AssertionHandler: handler,
+		})
+
+		e.Group("outer", func(e *Expect) {
+			e.Group("inner", func(e *Expect) {
+				e.Number(1).IsEqual(2)
+			})
+		})
+
+		require.NotNil(t, handler.failure)
+		assert.Equal(t, AssertOperation, handler.failure.Type)
+		assert.Equal(t, []string{`Group("outer")`}, handler.ctx.Path)
+
+		require.Equal(t, 2, len(handler.failure.Errors))
+		assert.Contains(t, handler.failure.Errors[1].Error(),
+			`Group("outer").Group("inner")`)
+		assert.Contains(t, handler.failure.Errors[1].Error(),
+			`Group("outer").Group("inner").Number().IsEqual()`)
+	})
+
+	t.Run("builders and matchers", func(t *testing.T) {
+		e := WithConfig(Config{
+			Client:   &mockClient{},
+			Reporter: newMockReporter(t),
+		})
+
+		built := 0
+		matched := 0
+
+		e = e.Builder(func(r *Request) {
+			built++
+		}).Matcher(func(r *Response) {
+			matched++
+		})
+
+		e.Group("users", func(e *Expect) {
+			e.GET("/users").Expect()
+		})
+
+		assert.Equal(t, 1, built)
+		assert.Equal(t, 1, matched)
+	})
+
+	t.Run("nil function", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+
+		e := WithConfig(Config{
+			AssertionHandler: handler,
+		})
+
+		e.Group("users", nil)
+
+		require.NotNil(t, handler.failure)
+		assert.Equal(t, AssertUsage, handler.failure.Type)
+	})
+}
+
+func TestExpect_Warnings(t *testing.T) {
+	t.Run("summary", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		e := WithConfig(Config{
+			Client: &mockClient{
+				resp: http.Response{StatusCode: http.StatusOK},
+			},
+			Reporter: reporter,
+		})
+
+		assert.Equal(t, "", e.WarningSummary())
+
+		resp := e.GET("/users").Expect()
+
+		resp.AsWarning().Header("Deprecation").IsEqual("true")
+		resp.Status(http.StatusOK)
+
+		assert.False(t, reporter.reported)
+		resp.chain.assertNotFailed(t)
+
+		summary := e.WarningSummary()
+		assert.Contains(t, summary, "1 warning reported")
+		assert.Contains(t, summary, "warning 1 of 1:")
+		assert.Contains(t, summary, `Header("Deprecation").IsEqual()`)
+
+		e.Value(1).AsWarning().IsEqual(2)
+
+		summary = e.WarningSummary()
+		assert.Contains(t, summary, "2 warnings reported")
+		assert.Contains(t, summary, "warning 2 of 2:")
+		assert.False(t, reporter.reported)
+
+		resp.Status(http.StatusNotFound)
+
+		assert.True(t, reporter.reported)
+		resp.chain.assertFailed(t)
+	})
+
+	t.Run("parent not failed", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		e := WithConfig(Config{
+			Reporter: reporter,
+		})
+
+		obj := e.Object(map[string]interface{}{"foo": 1, "bar": 2})
+
+		obj.Value("foo").AsWarning().Number().IsEqual(3)
+		obj.Value("bar").Number().IsEqual(2)
+
+		obj.chain.assertNotFailed(t)
+		assert.False(t, reporter.reported)
+
+		obj.Value("bar").Number().IsEqual(3)
+
+		assert.True(t, reporter.reported)
+	})
+
+	t.Run("logger", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		logger := newMockLogger(t)
+
+		e := WithConfig(Config{
+			AssertionHandler: &DefaultAssertionHandler{
+				Formatter: &DefaultFormatter{},
+				Reporter:  reporter,
+				Logger:    logger,
+			},
+		})
+
+		e.String("foo").AsWarning().IsEqual("bar")
+
+		assert.True(t, logger.logged)
+		assert.Contains(t, logger.lastMessage, "String().IsEqual()")
+		assert.False(t, reporter.reported)
+	})
+
+	t.Run("default cleanup", func(t *testing.T) {
+		var e *Expect
+
+		t.Run("test", func(t *testing.T) {
+			e = Default(t, "")
+			e.Number(1).AsWarning().IsEqual(2)
+		})
+
+		assert.Contains(t, e.WarningSummary(), "1 warning reported")
+	})
+}
+
 func TestExpect_Traverse(t *testing.T) {
 	client := &mockClient{}
 
@@ -0,0 +1,212 @@
+package httpexpect
+
+import (
+	"time"
+)
+
+// Chain is a public handle to assertion chain, which allows to implement
+// custom matchers that behave exactly like built-in ones.
+//
+// Every matcher (Value, Object, Cookie, etc.) holds a chain, which can be
+// obtained using its Chain method. Chains are linked into a tree: each
+// assertion creates a child chain using Enter, reports failures using Fail,
+// and finalizes it using Leave. On Leave, failure is propagated to parent
+// chains, and successful or failed assertion is reported to AssertionHandler
+// with AssertionContext that includes path from the root of the chain.
+//
+// Custom matcher should store its own Chain, created using Clone from the
+// chain of the assertion that constructs it. Each method of custom matcher
+// should call Enter on that Chain and defer Leave on returned Chain.
+// Child matchers should be created from the entered chain, using
+// NewValue, NewObject, and other similar methods.
+//
+// Example:
+//
+//	type Money struct {
+//	    chain    *httpexpect.Chain
+//	    amount   float64
+//	    currency string
+//	}
+//
+//	func NewMoney(value *httpexpect.Value) *Money {
+//	    opChain := value.Chain().Enter("Money()")
+//	    defer opChain.Leave()
+//
+//	    m := &Money{chain: opChain.Clone()}
+//
+//	    obj := opChain.NewValue(value.Raw()).Object()
+//	    m.amount = obj.Value("amount").Number().Raw()
+//	    m.currency = obj.Value("currency").String().Raw()
+//
+//	    return m
+//	}
+//
+//	func (m *Money) IsPositive() *Money {
+//	    opChain := m.chain.Enter("IsPositive()")
+//	    defer opChain.Leave()
+//
+//	    if opChain.Failed() {
+//	        return m
+//	    }
+//
+//	    if m.amount <= 0 {
+//	        opChain.Fail(httpexpect.AssertionFailure{
+//	            Type:     httpexpect.AssertGt,
+//	            Actual:   &httpexpect.AssertionValue{Value: m.amount},
+//	            Expected: &httpexpect.AssertionValue{Value: 0},
+//	            Errors: []error{
+//	                errors.New("expected: positive amount"),
+//	            },
+//	        })
+//	    }
+//
+//	    return m
+//	}
+type Chain struct {
+	chain *chain
+}
+
+// NewChain returns a new root Chain with given name, which reports failures
+// to given reporter using DefaultAssertionHandler.
+//
+// Root chain may be used to construct custom matchers from raw values,
+// in the same way as NewValue and similar functions do.
+//
+// If reporter is nil, the function panics.
+//
+// Example:
+//
+//	chain := NewChain("Money()", t)
+func NewChain(name string, reporter Reporter) *Chain {
+	return &Chain{chain: newChainWithDefaults(name, reporter)}
+}
+
+// NewChainC returns a new root Chain with given name and config.
+//
+// Requirements for config are same as for WithConfig function.
+//
+// Example:
+//
+//	chain := NewChainC("Money()", config)
+func NewChainC(name string, config Config) *Chain {
+	return &Chain{chain: newChainWithConfig(name, config.withDefaults())}
+}
+
+// Enter creates a temporary child chain to be used during assertion.
+//
+// name is formatted using fmt.Sprintf with given args and appended to
+// the path reported in AssertionContext. You must call Leave on returned
+// chain at the end of assertion.
+func (c *Chain) Enter(name string, args ...interface{}) *Chain {
+	return &Chain{chain: c.chain.enter(name, args...)}
+}
+
+// Replace is like Enter, but it replaces last element of the path instead
+// of appending to it. It is useful for assertions that iterate over
+// elements, e.g. to report "Every[1]" instead of "Every()".
+//
+// Must be called on a chain returned by Enter, before calling Leave.
+// You must call Leave on returned chain at the end of assertion.
+func (c *Chain) Replace(name string, args ...interface{}) *Chain {
+	return &Chain{chain: c.chain.replace(name, args...)}
+}
+
+// Leave finalizes assertion started by Enter.
+//
+// If Fail was called, Leave marks parent chain as failed and notifies
+// its parents that they have failed children. Otherwise, Leave reports
+// successful assertion to AssertionHandler.
+//
+// Chain can't be used after this call.
+func (c *Chain) Leave() {
+	c.chain.leave()
+}
+
+// Fail reports assertion failure to AssertionHandler and marks chain
+// as failed. If chain is already failed, Fail does nothing.
+//
+// Must be called on a chain returned by Enter, before calling Leave.
+func (c *Chain) Fail(failure AssertionFailure) {
+	c.chain.fail(failure)
+}
+
+// Failed returns true if chain is failed, either because Fail was called,
+// or because chain was created from a failed chain.
+//
+// Assertions usually check it right after Enter and skip the check if
+// the matcher is already failed.
+func (c *Chain) Failed() bool {
+	return c.chain.failed()
+}
+
+// Clone returns a copy of chain, which may be stored in a new matcher.
+//
+// Usually called on a chain returned by Enter, before calling Leave,
+// so that new matcher inherits assertion path.
+func (c *Chain) Clone() *Chain {
+	return &Chain{chain: c.chain.clone()}
+}
+
+// SetAlias replaces assertion path reported for chain and its children
+// with given alias, like Alias method of matchers does.
+func (c *Chain) SetAlias(name string) {
+	c.chain.setAlias(name)
+}
+
+// Env returns Environment associated with chain.
+func (c *Chain) Env() *Environment {
+	return c.chain.env()
+}
+
+// Context returns a copy of AssertionContext of chain.
+func (c *Chain) Context() AssertionContext {
+	c.chain.mu.Lock()
+	defer c.chain.mu.Unlock()
+
+	ctx := c.chain.context
+
+	ctx.Path = append([]string(nil), ctx.Path...)
+	ctx.AliasedPath = append([]string(nil), ctx.AliasedPath...)
+
+	return ctx
+}
+
+// NewValue returns a new Value attached to chain.
+func (c *Chain) NewValue(value interface{}) *Value {
+	return newValue(c.chain, value)
+}
+
+// NewObject returns a new Object attached to chain.
+func (c *Chain) NewObject(value map[string]interface{}) *Object {
+	return newObject(c.chain, value)
+}
+
+// NewArray returns a new Array attached to chain.
+func (c *Chain) NewArray(value []interface{}) *Array {
+	return newArray(c.chain, value)
+}
+
+// NewString returns a new String attached to chain.
+func (c *Chain) NewString(value string) *String {
+	return newString(c.chain, value)
+}
+
+// NewNumber returns a new Number attached to chain.
+func (c *Chain) NewNumber(value float64) *Number {
+	return newNumber(c.chain, value)
+}
+
+// NewBoolean returns a new Boolean attached to chain.
+func (c *Chain) NewBoolean(value bool) *Boolean {
+	return newBoolean(c.chain, value)
+}
+
+// NewDateTime returns a new DateTime attached to chain.
+func (c *Chain) NewDateTime(value time.Time) *DateTime {
+	return newDateTime(c.chain, value)
+}
+
+// NewDuration returns a new Duration attached to chain.
+func (c *Chain) NewDuration(value time.Duration) *Duration {
+	return newDuration(c.chain, &value)
+}
@@ -0,0 +1,293 @@
+package httpexpect
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testMoney struct {
+	chain    *Chain
+	amount   float64
+	currency string
+}
+
+func newTestMoney(value *Value) *testMoney {
+	opChain := value.Chain().Enter("Money()")
+	defer opChain.Leave()
+
+	m := &testMoney{chain: opChain.Clone()}
+
+	if opChain.Failed() {
+		return m
+	}
+
+	obj, ok := value.Raw().(map[string]interface{})
+	if !ok {
+		opChain.Fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{value.Raw()},
+			Errors: []error{
+				errors.New("expected: money object"),
+			},
+		})
+		return m
+	}
+
+	m.amount, _ = obj["amount"].(float64)
+	m.currency, _ = obj["currency"].(string)
+
+	return m
+}
+
+func (m *testMoney) IsPositive() *testMoney {
+	opChain := m.chain.Enter("IsPositive()")
+	defer opChain.Leave()
+
+	if opChain.Failed() {
+		return m
+	}
+
+	if m.amount <= 0 {
+		opChain.Fail(AssertionFailure{
+			Type:     AssertGt,
+			Actual:   &AssertionValue{m.amount},
+			Expected: &AssertionValue{0},
+			Errors: []error{
+				errors.New("expected: positive amount"),
+			},
+		})
+	}
+
+	return m
+}
+
+func (m *testMoney) Currency() *String {
+	opChain := m.chain.Enter("Currency()")
+	defer opChain.Leave()
+
+	return opChain.NewString(m.currency)
+}
+
+func TestExtension_CustomMatcher(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		value := NewValue(reporter, map[string]interface{}{
+			"amount":   10,
+			"currency": "EUR",
+		})
+
+		money := newTestMoney(value)
+		money.IsPositive()
+		money.Currency().IsEqual("EUR")
+
+		money.chain.chain.assertNotFailed(t)
+		value.chain.assertNotFailed(t)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+		chain := newChainWithConfig("test", Config{
+			AssertionHandler: handler,
+		}.withDefaults())
+
+		value := newValue(chain, map[string]interface{}{
+			"amount":   -1,
+			"currency": "EUR",
+		})
+
+		money := newTestMoney(value)
+		money.IsPositive()
+
+		require.NotNil(t, handler.failure)
+		assert.Equal(t, AssertGt, handler.failure.Type)
+		assert.True(t, handler.failure.IsFatal)
+		assert.Equal(t, []string{"test", "Money()", "IsPositive()"}, handler.ctx.Path)
+
+		money.chain.chain.assertFailed(t)
+		assert.True(t, value.chain.treeFailed())
+	})
+
+	t.Run("child failure", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+		chain := newChainWithConfig("test", Config{
+			AssertionHandler: handler,
+		}.withDefaults())
+
+		value := newValue(chain, map[string]interface{}{
+			"amount":   1,
+			"currency": "EUR",
+		})
+
+		money := newTestMoney(value)
+		money.Currency().IsEqual("USD")
+
+		require.NotNil(t, handler.failure)
+		assert.Equal(t,
+			[]string{"test", "Money()", "Currency()", "IsEqual()"}, handler.ctx.Path)
+
+		assert.True(t, money.chain.chain.treeFailed())
+		assert.True(t, value.chain.treeFailed())
+	})
+
+	t.Run("failed parent", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		value := NewValue(reporter, "not an object")
+		value.chain.setFailed()
+
+		money := newTestMoney(value)
+		money.IsPositive()
+		money.Currency().chain.assertFailed(t)
+
+		assert.False(t, reporter.reported)
+	})
+
+	t.Run("alias", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+		chain := newChainWithConfig("test", Config{
+			AssertionHandler: handler,
+		}.withDefaults())
+
+		value := newValue(chain, map[string]interface{}{"amount": 0})
+		value.Alias("price")
+
+		newTestMoney(value).IsPositive()
+
+		require.NotNil(t, handler.failure)
+		assert.Equal(t, []string{"price", "Money()", "IsPositive()"},
+			handler.ctx.AliasedPath)
+	})
+}
+
+func TestExtension_Chain(t *testing.T) {
+	t.Run("root", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		root := NewChain("Money()", reporter)
+
+		opChain := root.Enter("IsPositive(%d)", 1)
+		assert.False(t, opChain.Failed())
+		assert.Equal(t, []string{"Money()", "IsPositive(1)"}, opChain.Context().Path)
+
+		opChain.Fail(AssertionFailure{
+			Type: AssertOperation,
+			Errors: []error{
+				errors.New("test"),
+			},
+		})
+		assert.True(t, opChain.Failed())
+
+		opChain.Leave()
+
+		assert.True(t, reporter.reported)
+		root.chain.assertFailed(t)
+	})
+
+	t.Run("config", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+		env := NewEnvironment(newMockReporter(t))
+
+		root := NewChainC("Money()", Config{
+			TestName:         "TestMoney",
+			AssertionHandler: handler,
+			Environment:      env,
+		})
+
+		assert.Same(t, env, root.Env())
+		assert.Equal(t, "TestMoney", root.Context().TestName)
+
+		opChain := root.Enter("IsPositive()")
+		opChain.Leave()
+
+		require.NotNil(t, handler.ctx)
+		assert.Nil(t, handler.failure)
+		assert.Equal(t, []string{"Money()", "IsPositive()"}, handler.ctx.Path)
+	})
+
+	t.Run("replace", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+		root := NewChainC("Money()", Config{
+			AssertionHandler: handler,
+		})
+
+		opChain := root.Enter("Every()")
+
+		for i := 0; i < 2; i++ {
+			elemChain := opChain.Replace("Every[%d]", i)
+			elemChain.NewNumber(float64(i)).IsEqual(0)
+			elemChain.Leave()
+		}
+
+		opChain.Leave()
+
+		require.NotNil(t, handler.failure)
+		assert.Equal(t, []string{"Money()", "Every[1]", "IsEqual()"}, handler.ctx.Path)
+		assert.True(t, root.chain.treeFailed())
+	})
+
+	t.Run("context copy", func(t *testing.T) {
+		root := NewChain("Money()", newMockReporter(t))
+
+		ctx := root.Context()
+		ctx.Path[0] = "changed"
+
+		assert.Equal(t, []string{"Money()"}, root.Context().Path)
+
+		root.SetAlias("price")
+		assert.Equal(t, []string{"price"}, root.Context().AliasedPath)
+		assert.Equal(t, []string{"Money()"}, root.Context().Path)
+	})
+
+	t.Run("child matchers", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		root := NewChain("test", reporter)
+
+		opChain := root.Enter("Check()")
+
+		opChain.NewValue(1).Number().IsEqual(1)
+		opChain.NewObject(map[string]interface{}{"a": 1}).ContainsKey("a")
+		opChain.NewArray([]interface{}{1}).ConsistsOf(1)
+		opChain.NewString("a").IsEqual("a")
+		opChain.NewNumber(1).IsEqual(1)
+		opChain.NewBoolean(true).IsTrue()
+		opChain.NewDateTime(time.Unix(0, 0)).IsEqual(time.Unix(0, 0))
+		opChain.NewDuration(time.Second).IsEqual(time.Second)
+
+		opChain.Leave()
+
+		root.chain.assertNotFailed(t)
+		assert.False(t, reporter.reported)
+	})
+}
+
+func TestExtension_MatcherChain(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	value := NewValue(reporter, map[string]interface{}{"a": []interface{}{"b"}})
+
+	assert.Same(t, value.chain, value.Chain().chain)
+
+	object := value.Object()
+	assert.Same(t, object.chain, object.Chain().chain)
+
+	array := object.Value("a").Array()
+	assert.Same(t, array.chain, array.Chain().chain)
+
+	str := array.Value(0).String()
+	assert.Same(t, str.chain, str.Chain().chain)
+
+	cookie := NewCookie(reporter, &http.Cookie{Name: "a"})
+	assert.Same(t, cookie.chain, cookie.Chain().chain)
+
+	e := WithConfig(Config{
+		Reporter: reporter,
+	})
+	assert.Same(t, e.chain, e.Chain().chain)
+}
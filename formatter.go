@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"math"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"text/template"
@@ -42,6 +43,9 @@ type DefaultFormatter struct {
 	// Exclude aliased assertion path from failure report.
 	DisableAliases bool
 
+	// Exclude source location of assertion from failure report.
+	DisableLocations bool
+
 	// Exclude diff from failure report.
 	DisableDiffs bool
 
@@ -119,6 +123,7 @@ type FormatData struct {
 	RequestName string
 
 	AssertPath     []string
+	AssertLocation string
 	AssertType     string
 	AssertSeverity string
 
@@ -191,7 +196,7 @@ func (f *DefaultFormatter) buildFormatData(
 	f.fillDescription(&data, ctx)
 
 	if failure != nil {
-		data.AssertType = failure.Type.String()
+		data.AssertType = assertionTypeName(failure.Type)
 		data.AssertSeverity = failure.Severity.String()
 
 		f.fillErrors(&data, ctx, failure)
@@ -234,6 +239,11 @@ func (f *DefaultFormatter) fillDescription(
 		}
 	}
 
+	if !f.DisableLocations && ctx.Location.File != "" {
+		data.AssertLocation = fmt.Sprintf("%s:%d",
+			filepath.Base(ctx.Location.File), ctx.Location.Line)
+	}
+
 	if f.LineWidth != 0 {
 		data.LineWidth = f.LineWidth
 	} else {
@@ -255,6 +265,16 @@ func (f *DefaultFormatter) fillErrors(
 func (f *DefaultFormatter) fillActual(
 	data *FormatData, ctx *AssertionContext, failure *AssertionFailure,
 ) {
+	if spec, ok := lookupAssertionType(failure.Type); ok {
+		data.HaveActual = true
+		if spec.FormatActual != nil {
+			data.Actual = spec.FormatActual(failure.Actual.Value)
+		} else {
+			data.Actual = f.formatValue(failure.Actual.Value)
+		}
+		return
+	}
+
 	switch failure.Type { //nolint
 	case AssertUsage, AssertOperation:
 		data.HaveActual = false
@@ -360,6 +380,34 @@ func (f *DefaultFormatter) fillExpected(
 		data.HaveExpected = true
 		data.ExpectedKind = kindValueList
 		data.Expected = f.formatListValue(failure.Expected.Value)
+
+	default:
+		if spec, ok := lookupAssertionType(failure.Type); ok {
+			f.fillCustomExpected(data, failure, spec)
+		}
+	}
+}
+
+func (f *DefaultFormatter) fillCustomExpected(
+	data *FormatData, failure *AssertionFailure, spec AssertionTypeSpec,
+) {
+	data.HaveExpected = true
+
+	if spec.ExpectedKind != "" {
+		data.ExpectedKind = spec.ExpectedKind
+	} else {
+		data.ExpectedKind = kindValue
+	}
+
+	if spec.FormatExpected != nil {
+		data.Expected = spec.FormatExpected(failure.Expected.Value)
+	} else {
+		data.Expected = f.formatListValue(failure.Expected.Value)
+	}
+
+	if spec.IsDiffable && !f.DisableDiffs && failure.Actual != nil {
+		data.Diff, data.HaveDiff = f.formatDiff(
+			failure.Expected.Value, failure.Actual.Value)
 	}
 }
 
@@ -400,15 +448,25 @@ func (f *DefaultFormatter) fillIsNegation(
 		AssertNotContainsSubset,
 		AssertNotBelongs:
 		data.IsNegation = true
+
+	default:
+		if spec, ok := lookupAssertionType(failure.Type); ok {
+			data.IsNegation = spec.IsNegation
+		}
 	}
 }
 
 func (f *DefaultFormatter) fillIsComparison(
 	data *FormatData, ctx *AssertionContext, failure *AssertionFailure,
 ) {
-	switch failure.Type { //nolint
+	switch failure.Type {
 	case AssertLt, AssertLe, AssertGt, AssertGe:
 		data.IsComparison = true
+
+	default:
+		if spec, ok := lookupAssertionType(failure.Type); ok {
+			data.IsComparison = spec.IsComparison
+		}
 	}
 }
 
@@ -441,8 +499,12 @@ func (f *DefaultFormatter) formatValue(value interface{}) string {
 				return ss
 			}
 		}
-		if b, err := json.MarshalIndent(value, "", defaultIndent); err == nil {
-			return string(b)
+		var buf bytes.Buffer
+		enc := json.NewEncoder(&buf)
+		enc.SetEscapeHTML(false)
+		enc.SetIndent("", defaultIndent)
+		if err := enc.Encode(value); err == nil {
+			return strings.TrimSuffix(buf.String(), "\n")
 		}
 	}
 
@@ -522,6 +584,9 @@ func (f *DefaultFormatter) formatListValue(value interface{}) []string {
 }
 
 func (f *DefaultFormatter) formatDiff(expected, actual interface{}) (string, bool) {
+	expected = f.formatDiffValue(diffExpected(expected, actual))
+	actual = f.formatDiffValue(actual)
+
 	differ := gojsondiff.New()
 
 	var diff gojsondiff.Diff
@@ -561,6 +626,100 @@ func (f *DefaultFormatter) formatDiff(expected, actual interface{}) (string, boo
 	return diffText, true
 }
 
+// Prepare expected value for diff.
+//
+// Placeholders that match actual value, and numbers that are equal to
+// actual numbers (see canonEqual), are replaced with actual value, so that
+// diff shows only real mismatches.
+func diffExpected(expected, actual interface{}) interface{} {
+	switch ev := expected.(type) {
+	case *Placeholder:
+		if ev != nil && ev.match(actual) == nil {
+			return actual
+		}
+		return expected
+
+	case map[string]interface{}:
+		av, ok := actual.(map[string]interface{})
+		if !ok {
+			return expected
+		}
+		out := make(map[string]interface{}, len(ev))
+		for k, e := range ev {
+			if a, ok := av[k]; ok {
+				out[k] = diffExpected(e, a)
+			} else {
+				out[k] = e
+			}
+		}
+		return out
+
+	case []interface{}:
+		av, ok := actual.([]interface{})
+		if !ok {
+			return expected
+		}
+		out := make([]interface{}, len(ev))
+		for n := range ev {
+			if n < len(av) {
+				out[n] = diffExpected(ev[n], av[n])
+			} else {
+				out[n] = ev[n]
+			}
+		}
+		return out
+	}
+
+	if canonEqual(actual, expected) {
+		return actual
+	}
+	return expected
+}
+
+// Convert numbers and placeholders to literals printed as is in diff.
+// Numbers are formatted the same way as other values, so that float64
+// and json.Number look alike.
+func (f *DefaultFormatter) formatDiffValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(v))
+		for k, e := range v {
+			out[k] = f.formatDiffValue(e)
+		}
+		return out
+
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for n, e := range v {
+			out[n] = f.formatDiffValue(e)
+		}
+		return out
+
+	case float64:
+		return diffLiteral{f.formatFloatValue(v, 64)}
+
+	case json.Number:
+		return diffLiteral{string(v)}
+
+	case *Placeholder:
+		if v != nil {
+			return diffLiteral{v.String()}
+		}
+	}
+
+	return value
+}
+
+// Value printed without quotes by diff formatter, which uses %#v.
+// Not a string kind, otherwise differ would try to compare it as text.
+type diffLiteral struct {
+	text string
+}
+
+func (l diffLiteral) GoString() string {
+	return l.text
+}
+
 func extractString(value interface{}) *string {
 	switch s := value.(type) {
 	case string:
@@ -697,6 +856,11 @@ test name: {{ .TestName }}
 
 request name: {{ .RequestName }}
 {{- end -}}
+{{- if .AssertLocation }}
+
+location:
+{{ .AssertLocation | indent }}
+{{- end -}}
 {{- if .AssertPath }}
 
 assertion:
@@ -1,6 +1,8 @@
 package httpexpect
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 	"testing"
@@ -569,6 +571,25 @@ func TestFormatter_FloatFormat(t *testing.T) {
 			value:    int(12345678),
 			wantText: "12345678",
 		},
+		// arbitrary precision
+		{
+			name:     "json.Number integer",
+			format:   FloatFormatScientific,
+			value:    json.Number("12345678901234567890"),
+			wantText: "12345678901234567890",
+		},
+		{
+			name:     "json.Number decimal",
+			format:   FloatFormatAuto,
+			value:    json.Number("0.10000000000000000001"),
+			wantText: "0.10000000000000000001",
+		},
+		{
+			name:     "json.Number nested",
+			format:   FloatFormatAuto,
+			value:    []interface{}{json.Number("12345678901234567890")},
+			wantText: "[\n  12345678901234567890\n]",
+		},
 	}
 
 	for _, tc := range testCases {
@@ -787,4 +808,135 @@ func TestFormatter_FormatDiff(t *testing.T) {
 
 	checkOK(map[string]interface{}{"a": 1}, map[string]interface{}{})
 	checkOK([]interface{}{"a"}, []interface{}{})
+
+	t.Run("placeholders", func(t *testing.T) {
+		s, ok := formatter.formatDiff(
+			map[string]interface{}{
+				"id":   AnyString(),
+				"name": "b",
+				"tags": []interface{}{Any(), AnyString()},
+			},
+			map[string]interface{}{
+				"id":   "x",
+				"name": "a",
+				"tags": []interface{}{"foo", 1.0},
+			})
+
+		require.True(t, ok)
+		t.Logf("\n%s", s)
+
+		assert.Contains(t, s, `   "id": "x",`)
+		assert.Contains(t, s, `-  "name": "b"`)
+		assert.Contains(t, s, `-    1: <AnyString()>`)
+		assert.NotContains(t, s, `-  "id"`)
+		assert.NotContains(t, s, `-    0:`)
+		assert.NotContains(t, s, "Placeholder")
+	})
+
+	t.Run("long placeholder", func(t *testing.T) {
+		s, ok := formatter.formatDiff(
+			map[string]interface{}{"id": Regexp(`^[a-z]+-[0-9]+-[a-z]+-[0-9]+$`)},
+			map[string]interface{}{"id": "this string does not match pattern"})
+
+		require.True(t, ok)
+		assert.Contains(t, s, `-  "id": <Regexp("^[a-z]+-[0-9]+-[a-z]+-[0-9]+$")>`)
+	})
+
+	t.Run("matched placeholders", func(t *testing.T) {
+		s, ok := formatter.formatDiff(
+			map[string]interface{}{"id": AnyString()},
+			map[string]interface{}{"id": "x"})
+
+		assert.False(t, ok)
+		assert.Equal(t, "", s)
+	})
+}
+
+func TestFormatter_FormatDiffNumbers(t *testing.T) {
+	formatter := &DefaultFormatter{}
+
+	t.Run("equal", func(t *testing.T) {
+		s, ok := formatter.formatDiff(
+			map[string]interface{}{
+				"n": json.Number("12345678901234567890"),
+				"s": "b",
+			},
+			map[string]interface{}{
+				"n": float64(12345678901234567890),
+				"s": "a",
+			})
+
+		require.True(t, ok)
+		t.Logf("\n%s", s)
+
+		assert.Contains(t, s, `   "n": 12345678901234567000,`)
+		assert.NotContains(t, s, `-  "n"`)
+		assert.NotContains(t, s, `+  "n"`)
+	})
+
+	t.Run("different", func(t *testing.T) {
+		s, ok := formatter.formatDiff(
+			map[string]interface{}{"n": json.Number("12345678901234567890")},
+			map[string]interface{}{"n": json.Number("12345678901234567891")})
+
+		require.True(t, ok)
+		t.Logf("\n%s", s)
+
+		assert.Contains(t, s, `-  "n": 12345678901234567890`)
+		assert.Contains(t, s, `+  "n": 12345678901234567891`)
+	})
+}
+
+func TestFormatter_FormatPlaceholder(t *testing.T) {
+	formatter := &DefaultFormatter{}
+
+	assert.Equal(t, "<AnyString()>", formatter.formatValue(AnyString()))
+
+	s := formatter.formatValue(map[string]interface{}{"id": AnyString()})
+	assert.Contains(t, s, `"id": "<AnyString()>"`)
+}
+
+func TestFormatter_Location(t *testing.T) {
+	ctx := &AssertionContext{
+		Location: AssertionLocation{
+			File: "/path/to/example_test.go",
+			Line: 42,
+		},
+	}
+
+	failure := &AssertionFailure{
+		Type: AssertOperation,
+		Errors: []error{
+			errors.New("test"),
+		},
+	}
+
+	t.Run("enabled", func(t *testing.T) {
+		formatter := DefaultFormatter{}
+
+		data := formatter.buildFormatData(ctx, failure)
+		assert.Equal(t, "example_test.go:42", data.AssertLocation)
+
+		msg := formatter.FormatFailure(ctx, failure)
+		assert.Contains(t, msg, "location:\n  example_test.go:42")
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		formatter := DefaultFormatter{
+			DisableLocations: true,
+		}
+
+		data := formatter.buildFormatData(ctx, failure)
+		assert.Equal(t, "", data.AssertLocation)
+
+		msg := formatter.FormatFailure(ctx, failure)
+		assert.NotContains(t, msg, "location:")
+	})
+
+	t.Run("unknown", func(t *testing.T) {
+		formatter := DefaultFormatter{}
+
+		data := formatter.buildFormatData(&AssertionContext{}, failure)
+		assert.Equal(t, "", data.AssertLocation)
+	})
 }
@@ -4,11 +4,14 @@ go 1.14
 
 require (
 	github.com/ajg/form v1.5.1
+	github.com/andybalholm/brotli v1.0.4
 	github.com/fasthttp/websocket v1.4.3-rc.6
 	github.com/fatih/structs v1.1.0
 	github.com/google/go-querystring v1.1.0
 	github.com/gorilla/websocket v1.4.2
 	github.com/imkira/go-interpol v1.1.0
+	github.com/jmespath/go-jmespath v0.4.0
+	github.com/klauspost/compress v1.15.0
 	github.com/mitchellh/go-wordwrap v1.0.1
 	github.com/sanity-io/litter v1.5.5
 	github.com/stretchr/testify v1.5.0
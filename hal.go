@@ -0,0 +1,286 @@
+package httpexpect
+
+import (
+	"errors"
+	"fmt"
+)
+
+// HAL provides methods to inspect resource in HAL format
+// (JSON Hypertext Application Language).
+type HAL struct {
+	noCopy   noCopy
+	chain    *chain
+	follower *linkFollower
+	value    map[string]interface{}
+}
+
+func newHAL(
+	parent *chain, follower *linkFollower, val map[string]interface{},
+) *HAL {
+	h := &HAL{chain: parent.clone(), follower: follower, value: nil}
+
+	opChain := h.chain.enter("")
+	defer opChain.leave()
+
+	if val == nil {
+		opChain.fail(AssertionFailure{
+			Type:   AssertNotNil,
+			Actual: &AssertionValue{val},
+			Errors: []error{
+				errors.New("expected: non-nil HAL resource"),
+			},
+		})
+	} else {
+		h.value = val
+	}
+
+	return h
+}
+
+// Raw returns underlying resource object, including "_links" and
+// "_embedded" members.
+//
+// Example:
+//
+//	hal := resp.HAL()
+//	assert.Equal(t, 30.0, hal.Raw()["total"])
+func (h *HAL) Raw() map[string]interface{} {
+	return h.value
+}
+
+// Alias is similar to Value.Alias.
+func (h *HAL) Alias(name string) *HAL {
+	opChain := h.chain.enter("Alias(%q)", name)
+	defer opChain.leave()
+
+	h.chain.setAlias(name)
+	return h
+}
+
+// Chain returns public handle to assertion chain of HAL, which may be
+// used to implement custom matchers. See Chain for details.
+func (h *HAL) Chain() *Chain {
+	return &Chain{chain: h.chain}
+}
+
+// AsWarning is similar to Value.AsWarning.
+func (h *HAL) AsWarning() *HAL {
+	opChain := h.chain.enter("AsWarning()")
+	defer opChain.leave()
+
+	warnChain := h.chain.clone()
+	warnChain.setWarning()
+
+	return &HAL{
+		chain:    warnChain,
+		follower: h.follower,
+		value:    h.value,
+	}
+}
+
+// Links returns a new Object instance with "_links" member of the resource.
+// Keys are link relations, values are link objects or arrays of link objects.
+//
+// If resource has no links, returned object is empty.
+//
+// Example:
+//
+//	hal := resp.HAL()
+//	hal.Links().Value("self").Object().HasValue("href", "/orders")
+func (h *HAL) Links() *Object {
+	opChain := h.chain.enter("Links()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newObject(opChain, nil)
+	}
+
+	links, _ := h.value["_links"].(map[string]interface{})
+	if links == nil {
+		links = map[string]interface{}{}
+	}
+
+	return newObject(opChain, links)
+}
+
+// Embedded returns a new Value instance with embedded resource for given
+// link relation. The value is either a resource object or an array of
+// resource objects.
+//
+// If there is no such embedded resource, failure is reported.
+//
+// Example:
+//
+//	hal := resp.HAL()
+//	hal.Embedded("orders").Array().Length().IsEqual(2)
+func (h *HAL) Embedded(rel string) *Value {
+	opChain := h.chain.enter("Embedded(%q)", rel)
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newValue(opChain, nil)
+	}
+
+	embedded, _ := h.value["_embedded"].(map[string]interface{})
+
+	resource, ok := embedded[rel]
+	if !ok {
+		opChain.fail(AssertionFailure{
+			Type:     AssertContainsKey,
+			Actual:   &AssertionValue{embedded},
+			Expected: &AssertionValue{rel},
+			Errors: []error{
+				fmt.Errorf("expected: resource contains %q embedded resource", rel),
+			},
+		})
+		return newValue(opChain, nil)
+	}
+
+	return newValue(opChain, resource)
+}
+
+// Follow returns a new Request instance for GET request to the link with
+// given relation.
+//
+// Link URL is resolved relative to the URL of the request that received
+// this response. If the response was received using Expect, new request
+// is created by the same Expect and has same builders and matchers.
+//
+// If there is no such link, if there are multiple links with this
+// relation, or if link is templated, failure is reported.
+//
+// Example:
+//
+//	hal := resp.HAL()
+//	hal.Follow("next").Expect().Status(http.StatusOK).HAL()
+func (h *HAL) Follow(rel string) *Request {
+	opChain := h.chain.enter("Follow(%q)", rel)
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return h.follower.follow(opChain, "")
+	}
+
+	links, _ := h.value["_links"].(map[string]interface{})
+
+	link, ok := links[rel]
+	if !ok {
+		opChain.fail(AssertionFailure{
+			Type:     AssertContainsKey,
+			Actual:   &AssertionValue{links},
+			Expected: &AssertionValue{rel},
+			Errors: []error{
+				fmt.Errorf("expected: resource contains %q link", rel),
+			},
+		})
+		return h.follower.follow(opChain, "")
+	}
+
+	if arr, ok := link.([]interface{}); ok {
+		if len(arr) != 1 {
+			opChain.fail(AssertionFailure{
+				Type:   AssertValid,
+				Actual: &AssertionValue{link},
+				Errors: []error{
+					fmt.Errorf("expected: single link with relation %q", rel),
+				},
+			})
+			return h.follower.follow(opChain, "")
+		}
+		link = arr[0]
+	}
+
+	linkObj, _ := link.(map[string]interface{})
+
+	if templated, _ := linkObj["templated"].(bool); templated {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{link},
+			Errors: []error{
+				fmt.Errorf("expected: link with relation %q is not templated", rel),
+			},
+		})
+		return h.follower.follow(opChain, "")
+	}
+
+	href, _ := linkObj["href"].(string)
+
+	return h.follower.follow(opChain, href)
+}
+
+// Check structure of "_links" and "_embedded" members of HAL resource,
+// recursively.
+func validateHAL(resource map[string]interface{}) error {
+	if links, ok := resource["_links"]; ok {
+		linksObj, ok := links.(map[string]interface{})
+		if !ok {
+			return errors.New(`"_links" should be an object`)
+		}
+
+		for rel, link := range linksObj {
+			if arr, ok := link.([]interface{}); ok {
+				for n, elem := range arr {
+					if err := validateHALLink(elem); err != nil {
+						return fmt.Errorf("invalid link %q at index %d: %s", rel, n, err)
+					}
+				}
+			} else if err := validateHALLink(link); err != nil {
+				return fmt.Errorf("invalid link %q: %s", rel, err)
+			}
+		}
+	}
+
+	if embedded, ok := resource["_embedded"]; ok {
+		embeddedObj, ok := embedded.(map[string]interface{})
+		if !ok {
+			return errors.New(`"_embedded" should be an object`)
+		}
+
+		for rel, res := range embeddedObj {
+			switch r := res.(type) {
+			case map[string]interface{}:
+				if err := validateHAL(r); err != nil {
+					return fmt.Errorf("invalid embedded resource %q: %s", rel, err)
+				}
+
+			case []interface{}:
+				for n, elem := range r {
+					elemObj, ok := elem.(map[string]interface{})
+					if !ok {
+						return fmt.Errorf(
+							"embedded resource %q at index %d should be an object", rel, n)
+					}
+					if err := validateHAL(elemObj); err != nil {
+						return fmt.Errorf(
+							"invalid embedded resource %q at index %d: %s", rel, n, err)
+					}
+				}
+
+			default:
+				return fmt.Errorf(
+					"embedded resource %q should be an object or array of objects", rel)
+			}
+		}
+	}
+
+	return nil
+}
+
+func validateHALLink(link interface{}) error {
+	linkObj, ok := link.(map[string]interface{})
+	if !ok {
+		return errors.New("link should be an object")
+	}
+
+	if _, ok := linkObj["href"].(string); !ok {
+		return errors.New(`link should have string "href"`)
+	}
+
+	if templated, ok := linkObj["templated"]; ok {
+		if _, ok := templated.(bool); !ok {
+			return errors.New(`link "templated" should be a boolean`)
+		}
+	}
+
+	return nil
+}
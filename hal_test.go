@@ -0,0 +1,199 @@
+package httpexpect
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHAL_FailedChain(t *testing.T) {
+	check := func(value *HAL) {
+		value.chain.assertFailed(t)
+
+		value.Alias("foo")
+
+		value.Links().chain.assertFailed(t)
+		value.Embedded("foo").chain.assertFailed(t)
+		value.Follow("next").chain.assertFailed(t)
+	}
+
+	t.Run("failed chain", func(t *testing.T) {
+		chain := newMockChain(t)
+		chain.setFailed()
+
+		value := newHAL(chain, newMockLinkFollower(t), map[string]interface{}{})
+
+		check(value)
+	})
+
+	t.Run("nil value", func(t *testing.T) {
+		chain := newMockChain(t)
+
+		value := newHAL(chain, newMockLinkFollower(t), nil)
+
+		check(value)
+	})
+}
+
+func TestHAL_Alias(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	parent := newChainWithDefaults("HAL()", reporter)
+	value := newHAL(parent, newMockLinkFollower(t), map[string]interface{}{})
+	assert.Equal(t, []string{"HAL()"}, value.chain.context.Path)
+	assert.Equal(t, []string{"HAL()"}, value.chain.context.AliasedPath)
+
+	value.Alias("foo")
+	assert.Equal(t, []string{"HAL()"}, value.chain.context.Path)
+	assert.Equal(t, []string{"foo"}, value.chain.context.AliasedPath)
+
+	childValue := value.Links()
+	assert.Equal(t, []string{"HAL()", "Links()"},
+		childValue.chain.context.Path)
+	assert.Equal(t, []string{"foo", "Links()"},
+		childValue.chain.context.AliasedPath)
+}
+
+func TestHAL_Members(t *testing.T) {
+	t.Run("links and embedded", func(t *testing.T) {
+		resource := map[string]interface{}{
+			"_links": map[string]interface{}{
+				"self": map[string]interface{}{"href": "/orders"},
+			},
+			"_embedded": map[string]interface{}{
+				"orders": []interface{}{
+					map[string]interface{}{"total": 30.0},
+					map[string]interface{}{"total": 20.0},
+				},
+				"customer": map[string]interface{}{"name": "John"},
+			},
+			"count": 2.0,
+		}
+
+		value := newHAL(newMockChain(t), newMockLinkFollower(t), resource)
+
+		assert.Equal(t, resource, value.Raw())
+
+		value.Links().Value("self").Object().HasValue("href", "/orders")
+		value.Embedded("orders").Array().Length().IsEqual(2)
+		value.Embedded("customer").Object().HasValue("name", "John")
+
+		value.chain.assertNotFailed(t)
+
+		value.Embedded("invoices").chain.assertFailed(t)
+		value.chain.assertFailed(t)
+	})
+
+	t.Run("empty resource", func(t *testing.T) {
+		value := newHAL(newMockChain(t), newMockLinkFollower(t),
+			map[string]interface{}{})
+
+		value.Links().IsEmpty()
+		value.chain.assertNotFailed(t)
+
+		value.Embedded("orders").chain.assertFailed(t)
+	})
+}
+
+func TestHAL_Follow(t *testing.T) {
+	resource := map[string]interface{}{
+		"_links": map[string]interface{}{
+			"self": map[string]interface{}{"href": "/api/orders"},
+			"next": map[string]interface{}{"href": "orders?page=2"},
+			"item": []interface{}{
+				map[string]interface{}{"href": "/api/orders/1"},
+			},
+			"ea:admin": []interface{}{
+				map[string]interface{}{"href": "/admins/2"},
+				map[string]interface{}{"href": "/admins/5"},
+			},
+			"find": map[string]interface{}{
+				"href":      "/api/orders{?id}",
+				"templated": true,
+			},
+		},
+	}
+
+	cases := []struct {
+		rel  string
+		url  string
+		fail bool
+	}{
+		{rel: "self", url: "http://example.com/api/orders"},
+		{rel: "next", url: "http://example.com/api/orders?page=2"},
+		{rel: "item", url: "http://example.com/api/orders/1"},
+		{rel: "ea:admin", fail: true},
+		{rel: "find", fail: true},
+		{rel: "prev", fail: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.rel, func(t *testing.T) {
+			value := newHAL(newMockChain(t), newMockLinkFollower(t), resource)
+
+			req := value.Follow(tc.rel)
+
+			if tc.fail {
+				req.chain.assertFailed(t)
+				value.chain.assertFailed(t)
+				return
+			}
+
+			req.chain.assertNotFailed(t)
+			value.chain.assertNotFailed(t)
+
+			assert.True(t, req.encodeRequest(newMockChain(t)))
+
+			assert.Equal(t, "GET", req.httpReq.Method)
+			assert.Equal(t, tc.url, req.httpReq.URL.String())
+		})
+	}
+}
+
+func TestHAL_Validate(t *testing.T) {
+	link := map[string]interface{}{"href": "/"}
+
+	valid := []map[string]interface{}{
+		{},
+		{"foo": "bar"},
+		{"_links": map[string]interface{}{}},
+		{"_links": map[string]interface{}{"self": link}},
+		{"_links": map[string]interface{}{"item": []interface{}{link, link}}},
+		{"_links": map[string]interface{}{
+			"find": map[string]interface{}{"href": "/{id}", "templated": true},
+		}},
+		{"_embedded": map[string]interface{}{
+			"item": map[string]interface{}{
+				"_links": map[string]interface{}{"self": link},
+			},
+			"items": []interface{}{map[string]interface{}{}},
+		}},
+	}
+
+	invalid := []map[string]interface{}{
+		{"_links": "foo"},
+		{"_links": map[string]interface{}{"self": "/"}},
+		{"_links": map[string]interface{}{"self": map[string]interface{}{}}},
+		{"_links": map[string]interface{}{"item": []interface{}{link, "/"}}},
+		{"_links": map[string]interface{}{
+			"find": map[string]interface{}{"href": "/{id}", "templated": "yes"},
+		}},
+		{"_embedded": "foo"},
+		{"_embedded": map[string]interface{}{"item": "foo"}},
+		{"_embedded": map[string]interface{}{"items": []interface{}{"foo"}}},
+		{"_embedded": map[string]interface{}{
+			"item": map[string]interface{}{"_links": "foo"},
+		}},
+		{"_embedded": map[string]interface{}{
+			"items": []interface{}{map[string]interface{}{"_embedded": "foo"}},
+		}},
+	}
+
+	for n, resource := range valid {
+		assert.NoError(t, validateHAL(resource), "valid[%d]", n)
+	}
+
+	for n, resource := range invalid {
+		assert.Error(t, validateHAL(resource), "invalid[%d]", n)
+	}
+}
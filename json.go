@@ -1,11 +1,13 @@
 package httpexpect
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"reflect"
 	"regexp"
 
+	"github.com/jmespath/go-jmespath"
 	"github.com/xeipuuv/gojsonschema"
 	"github.com/yalp/jsonpath"
 )
@@ -45,6 +47,101 @@ func jsonPath(opChain *chain, value interface{}, path string) *Value {
 	return newValue(opChain, result)
 }
 
+func jsonPathAll(opChain *chain, value interface{}, path string) *Array {
+	if opChain.failed() {
+		return newArray(opChain, nil)
+	}
+
+	query, err := parseJSONPath(path)
+	if err != nil {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{path},
+			Errors: []error{
+				errors.New("expected: valid json path"),
+				err,
+			},
+		})
+		return newArray(opChain, nil)
+	}
+
+	nodes := query.eval(value)
+
+	values := make([]interface{}, 0, len(nodes))
+	paths := make([]string, 0, len(nodes))
+
+	for _, node := range nodes {
+		values = append(values, node.value)
+		paths = append(paths, node.path)
+	}
+
+	arr := newArray(opChain, values)
+	arr.paths = paths
+
+	return arr
+}
+
+func jsonQuery(opChain *chain, value interface{}, expr string) *Value {
+	if opChain.failed() {
+		return newValue(opChain, nil)
+	}
+
+	query, err := jmespath.Compile(expr)
+	if err != nil {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{expr},
+			Errors: []error{
+				errors.New("expected: valid jmespath expression"),
+				err,
+			},
+		})
+		return newValue(opChain, nil)
+	}
+
+	result, err := query.Search(jmespathValue(value))
+	if err != nil {
+		opChain.fail(AssertionFailure{
+			Type:     AssertMatchPath,
+			Actual:   &AssertionValue{value},
+			Expected: &AssertionValue{expr},
+			Errors: []error{
+				errors.New("expected: value matches given jmespath expression"),
+				err,
+			},
+		})
+		return newValue(opChain, nil)
+	}
+
+	return newValue(opChain, result)
+}
+
+// JMESPath implementation recognizes only float64 numbers, so exact
+// numbers are converted to float64 before evaluation.
+func jmespathValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case json.Number:
+		f, _ := v.Float64()
+		return f
+
+	case []interface{}:
+		ret := make([]interface{}, len(v))
+		for n := range v {
+			ret[n] = jmespathValue(v[n])
+		}
+		return ret
+
+	case map[string]interface{}:
+		ret := make(map[string]interface{}, len(v))
+		for k := range v {
+			ret[k] = jmespathValue(v[k])
+		}
+		return ret
+	}
+
+	return value
+}
+
 func jsonSchema(opChain *chain, value, schema interface{}) {
 	if opChain.failed() {
 		return
@@ -61,6 +158,41 @@ func jsonSchema(opChain *chain, value, schema interface{}) {
 		return
 	}
 
+	if ref, ok := schema.(*SchemaRef); ok {
+		index, loc, err := ref.locate()
+		if err != nil {
+			opChain.fail(AssertionFailure{
+				Type:   AssertValid,
+				Actual: &AssertionValue{schema},
+				Errors: []error{
+					errors.New("expected: valid json schema reference"),
+					err,
+				},
+			})
+			return
+		}
+		jsonSchemaValidate(opChain, value, schema, index, loc)
+		return
+	}
+
+	if data, ok := getModernSchema(schema, getString); ok {
+		index := newJSONSchemaIndex()
+		if err := index.add(jsonSchemaDefaultURI, data); err != nil {
+			opChain.fail(AssertionFailure{
+				Type:   AssertValid,
+				Actual: &AssertionValue{schema},
+				Errors: []error{
+					errors.New("expected: valid json schema"),
+					err,
+				},
+			})
+			return
+		}
+		loc := index.resources[jsonSchemaDefaultURI]
+		jsonSchemaValidate(opChain, value, schema, index, loc)
+		return
+	}
+
 	var schemaLoader gojsonschema.JSONLoader
 	var schemaData interface{}
 
@@ -107,3 +239,108 @@ func jsonSchema(opChain *chain, value, schema interface{}) {
 		})
 	}
 }
+
+func jsonMatchesType(opChain *chain, value, example interface{}) {
+	if opChain.failed() {
+		return
+	}
+
+	if example == nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected nil example argument"),
+			},
+		})
+		return
+	}
+
+	schema, err := jsonSchemaFromType(reflect.TypeOf(example))
+	if err != nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				fmt.Errorf("can't generate json schema from %T", example),
+				err,
+			},
+		})
+		return
+	}
+
+	index := newJSONSchemaIndex()
+	if err := index.add(jsonSchemaDefaultURI, schema); err != nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				fmt.Errorf("can't generate json schema from %T", example),
+				err,
+			},
+		})
+		return
+	}
+
+	loc := index.resources[jsonSchemaDefaultURI]
+	jsonSchemaValidate(opChain, value, schema, index, loc)
+}
+
+// Load schema if it declares draft 2019-09 or 2020-12, which are
+// handled by built-in validator.
+func getModernSchema(
+	schema interface{}, getString func(interface{}) (string, bool),
+) (interface{}, bool) {
+	var (
+		data interface{}
+		err  error
+	)
+
+	if str, ok := getString(schema); ok {
+		if ok, _ := regexp.MatchString(`^\w+://`, str); ok {
+			return nil, false
+		}
+		data, err = loadSchema([]byte(str))
+	} else {
+		var b []byte
+		if b, err = json.Marshal(schema); err == nil {
+			data, err = loadSchema(b)
+		}
+	}
+
+	if err != nil || !isModernJSONSchema(data) {
+		return nil, false
+	}
+
+	return data, true
+}
+
+func jsonSchemaValidate(
+	opChain *chain, value, schema interface{},
+	index *jsonSchemaIndex, loc jsonSchemaLoc,
+) {
+	schemaErrors, err := newJSONSchemaValidator(index).run(loc, value)
+	if err != nil {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{schema},
+			Errors: []error{
+				errors.New("expected: valid json schema"),
+				err,
+			},
+		})
+		return
+	}
+
+	if len(schemaErrors) != 0 {
+		errs := []error{
+			errors.New("expected: value matches given json schema"),
+		}
+		for _, e := range schemaErrors {
+			errs = append(errs, e)
+		}
+		opChain.fail(AssertionFailure{
+			Type:     AssertMatchSchema,
+			Actual:   &AssertionValue{value},
+			Expected: &AssertionValue{loc.node},
+			Errors:   errs,
+		})
+	}
+}
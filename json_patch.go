@@ -0,0 +1,187 @@
+package httpexpect
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Build JSON Patch (RFC 6902) that transforms "from" into "to".
+// Both values should be in canonical form.
+//
+// Objects are compared key by key. Arrays are compared element by element,
+// extra elements are removed from the end or added to the end.
+// Values of different types are replaced.
+func diffJSONPatch(from, to interface{}) []interface{} {
+	ops := []interface{}{}
+	diffJSONPatchValue(&ops, "", from, to)
+	return ops
+}
+
+func diffJSONPatchValue(ops *[]interface{}, path string, from, to interface{}) {
+	if canonEqual(from, to) {
+		return
+	}
+
+	switch f := from.(type) {
+	case map[string]interface{}:
+		if t, ok := to.(map[string]interface{}); ok {
+			diffJSONPatchObject(ops, path, f, t)
+			return
+		}
+
+	case []interface{}:
+		if t, ok := to.([]interface{}); ok {
+			diffJSONPatchArray(ops, path, f, t)
+			return
+		}
+	}
+
+	*ops = append(*ops, jsonPatchOp("replace", path, to))
+}
+
+func diffJSONPatchObject(
+	ops *[]interface{}, path string, from, to map[string]interface{},
+) {
+	keys := make([]string, 0, len(from)+len(to))
+	for k := range from {
+		keys = append(keys, k)
+	}
+	for k := range to {
+		if _, ok := from[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		fromVal, inFrom := from[k]
+		toVal, inTo := to[k]
+
+		keyPath := path + "/" + escapeJSONPointer(k)
+
+		switch {
+		case !inTo:
+			*ops = append(*ops, map[string]interface{}{
+				"op":   "remove",
+				"path": keyPath,
+			})
+		case !inFrom:
+			*ops = append(*ops, jsonPatchOp("add", keyPath, toVal))
+		default:
+			diffJSONPatchValue(ops, keyPath, fromVal, toVal)
+		}
+	}
+}
+
+func diffJSONPatchArray(ops *[]interface{}, path string, from, to []interface{}) {
+	common := len(from)
+	if len(to) < common {
+		common = len(to)
+	}
+
+	for n := 0; n < common; n++ {
+		diffJSONPatchValue(ops, path+"/"+strconv.Itoa(n), from[n], to[n])
+	}
+
+	// remove in reverse order, so that indices of remaining elements
+	// are not shifted
+	for n := len(from) - 1; n >= common; n-- {
+		*ops = append(*ops, map[string]interface{}{
+			"op":   "remove",
+			"path": path + "/" + strconv.Itoa(n),
+		})
+	}
+
+	for n := common; n < len(to); n++ {
+		*ops = append(*ops, jsonPatchOp("add", path+"/"+strconv.Itoa(n), to[n]))
+	}
+}
+
+func jsonPatchOp(op, path string, value interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"op":    op,
+		"path":  path,
+		"value": value,
+	}
+}
+
+// Escape JSON Pointer (RFC 6901) reference token.
+func escapeJSONPointer(token string) string {
+	token = strings.Replace(token, "~", "~0", -1)
+	token = strings.Replace(token, "/", "~1", -1)
+	return token
+}
+
+// Check that value is a valid JSON Patch (RFC 6902) document.
+// Value should be in canonical form.
+func validateJSONPatch(patch interface{}) error {
+	ops, ok := patch.([]interface{})
+	if !ok {
+		return errors.New("patch should be an array of operations")
+	}
+
+	for n, elem := range ops {
+		if err := validateJSONPatchOp(elem); err != nil {
+			return fmt.Errorf("invalid operation at index %d: %s", n, err)
+		}
+	}
+
+	return nil
+}
+
+func validateJSONPatchOp(elem interface{}) error {
+	op, ok := elem.(map[string]interface{})
+	if !ok {
+		return errors.New("operation should be an object")
+	}
+
+	name, ok := op["op"].(string)
+	if !ok {
+		return errors.New(`operation should have string "op" member`)
+	}
+
+	if err := validateJSONPointer(op["path"], "path"); err != nil {
+		return err
+	}
+
+	switch name {
+	case "add", "replace", "test":
+		if _, ok := op["value"]; !ok {
+			return fmt.Errorf(`%q operation should have "value" member`, name)
+		}
+
+	case "move", "copy":
+		if err := validateJSONPointer(op["from"], "from"); err != nil {
+			return err
+		}
+
+	case "remove":
+
+	default:
+		return fmt.Errorf("unknown operation %q", name)
+	}
+
+	return nil
+}
+
+func validateJSONPointer(value interface{}, member string) error {
+	ptr, ok := value.(string)
+	if !ok {
+		return fmt.Errorf("operation should have string %q member", member)
+	}
+
+	if ptr != "" && !strings.HasPrefix(ptr, "/") {
+		return fmt.Errorf("%q member should be empty or start with \"/\"", member)
+	}
+
+	for i := 0; i < len(ptr); i++ {
+		if ptr[i] == '~' && (i+1 == len(ptr) || (ptr[i+1] != '0' && ptr[i+1] != '1')) {
+			return fmt.Errorf("%q member has invalid escape sequence", member)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,214 @@
+package httpexpect
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Minimal JSON Patch implementation, supporting operations produced
+// by diffJSONPatch, used to check that diff is correct.
+func applyJSONPatch(doc interface{}, ops []interface{}) (interface{}, error) {
+	for _, elem := range ops {
+		op := elem.(map[string]interface{})
+		path := op["path"].(string)
+
+		if path == "" {
+			if op["op"] != "replace" {
+				return nil, errors.New("unsupported root operation")
+			}
+			doc = op["value"]
+			continue
+		}
+
+		tokens := strings.Split(path[1:], "/")
+		for n := range tokens {
+			tokens[n] = strings.Replace(tokens[n], "~1", "/", -1)
+			tokens[n] = strings.Replace(tokens[n], "~0", "~", -1)
+		}
+
+		var err error
+		doc, err = applyJSONPatchOp(doc, tokens, op)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return doc, nil
+}
+
+func applyJSONPatchOp(
+	doc interface{}, tokens []string, op map[string]interface{},
+) (interface{}, error) {
+	token := tokens[0]
+
+	switch d := doc.(type) {
+	case map[string]interface{}:
+		if len(tokens) > 1 {
+			child, err := applyJSONPatchOp(d[token], tokens[1:], op)
+			d[token] = child
+			return d, err
+		}
+		switch op["op"] {
+		case "add", "replace":
+			d[token] = op["value"]
+		case "remove":
+			delete(d, token)
+		}
+		return d, nil
+
+	case []interface{}:
+		idx, err := strconv.Atoi(token)
+		if err != nil || idx < 0 || idx > len(d) {
+			return nil, errors.New("invalid index")
+		}
+		if len(tokens) > 1 {
+			child, err := applyJSONPatchOp(d[idx], tokens[1:], op)
+			d[idx] = child
+			return d, err
+		}
+		switch op["op"] {
+		case "add":
+			d = append(d[:idx], append([]interface{}{op["value"]}, d[idx:]...)...)
+		case "replace":
+			d[idx] = op["value"]
+		case "remove":
+			d = append(d[:idx], d[idx+1:]...)
+		}
+		return d, nil
+	}
+
+	return nil, errors.New("invalid path")
+}
+
+func TestJSONPatch_Diff(t *testing.T) {
+	cases := []struct {
+		name string
+		from string
+		to   string
+		ops  string
+	}{
+		{
+			name: "equal",
+			from: `{"a": 1, "b": [1, 2]}`,
+			to:   `{"b": [1, 2], "a": 1}`,
+			ops:  `[]`,
+		},
+		{
+			name: "replace root",
+			from: `1`,
+			to:   `"foo"`,
+			ops:  `[{"op": "replace", "path": "", "value": "foo"}]`,
+		},
+		{
+			name: "object members",
+			from: `{"a": 1, "b": 2, "c": {"d": 3}}`,
+			to:   `{"a": 1, "b": 5, "c": {"d": 3, "e": null}, "f": true}`,
+			ops: `[
+				{"op": "replace", "path": "/b", "value": 5},
+				{"op": "add", "path": "/c/e", "value": null},
+				{"op": "add", "path": "/f", "value": true}
+			]`,
+		},
+		{
+			name: "remove member",
+			from: `{"a": 1, "b": 2}`,
+			to:   `{"a": 1}`,
+			ops:  `[{"op": "remove", "path": "/b"}]`,
+		},
+		{
+			name: "type change",
+			from: `{"a": [1]}`,
+			to:   `{"a": {"0": 1}}`,
+			ops:  `[{"op": "replace", "path": "/a", "value": {"0": 1}}]`,
+		},
+		{
+			name: "array grow",
+			from: `[1, 2]`,
+			to:   `[1, 3, 4, 5]`,
+			ops: `[
+				{"op": "replace", "path": "/1", "value": 3},
+				{"op": "add", "path": "/2", "value": 4},
+				{"op": "add", "path": "/3", "value": 5}
+			]`,
+		},
+		{
+			name: "array shrink",
+			from: `[1, 2, 3, 4]`,
+			to:   `[0, 2]`,
+			ops: `[
+				{"op": "replace", "path": "/0", "value": 0},
+				{"op": "remove", "path": "/3"},
+				{"op": "remove", "path": "/2"}
+			]`,
+		},
+		{
+			name: "escaping",
+			from: `{"a/b": {"c~d": 1}}`,
+			to:   `{"a/b": {"c~d": 2}}`,
+			ops:  `[{"op": "replace", "path": "/a~1b/c~0d", "value": 2}]`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var from, to, ops interface{}
+			require.NoError(t, json.Unmarshal([]byte(tc.from), &from))
+			require.NoError(t, json.Unmarshal([]byte(tc.to), &to))
+			require.NoError(t, json.Unmarshal([]byte(tc.ops), &ops))
+
+			diff := diffJSONPatch(from, to)
+			assert.Equal(t, ops, diff)
+			assert.NoError(t, validateJSONPatch(diff))
+
+			result, err := applyJSONPatch(from, diff)
+			require.NoError(t, err)
+			assert.Equal(t, to, result)
+		})
+	}
+}
+
+func TestJSONPatch_Validate(t *testing.T) {
+	valid := []string{
+		`[]`,
+		`[{"op": "add", "path": "/a", "value": null}]`,
+		`[{"op": "remove", "path": "/a/0"}]`,
+		`[{"op": "replace", "path": "", "value": 1}]`,
+		`[{"op": "move", "from": "/a", "path": "/b"}]`,
+		`[{"op": "copy", "from": "/a", "path": "/b"}]`,
+		`[{"op": "test", "path": "/a~0b~1c", "value": "foo"}]`,
+	}
+
+	invalid := []string{
+		`{}`,
+		`["foo"]`,
+		`[{"path": "/a"}]`,
+		`[{"op": "add", "value": 1}]`,
+		`[{"op": "add", "path": "/a"}]`,
+		`[{"op": "replace", "path": "/a"}]`,
+		`[{"op": "test", "path": "/a"}]`,
+		`[{"op": "move", "path": "/a"}]`,
+		`[{"op": "copy", "path": "/a", "from": 1}]`,
+		`[{"op": "merge", "path": "/a"}]`,
+		`[{"op": "remove", "path": "a"}]`,
+		`[{"op": "remove", "path": "/a~2"}]`,
+		`[{"op": "remove", "path": "/a~"}]`,
+	}
+
+	for _, s := range valid {
+		var patch interface{}
+		require.NoError(t, json.Unmarshal([]byte(s), &patch))
+		assert.NoError(t, validateJSONPatch(patch), s)
+	}
+
+	for _, s := range invalid {
+		var patch interface{}
+		require.NoError(t, json.Unmarshal([]byte(s), &patch))
+		assert.Error(t, validateJSONPatch(patch), s)
+	}
+}
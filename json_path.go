@@ -0,0 +1,1348 @@
+package httpexpect
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Node of JSONPath query result: value and its normalized path,
+// e.g. "$['users'][0]['name']".
+type jsonPathNode struct {
+	value interface{}
+	path  string
+}
+
+// Compiled JSONPath query (RFC 9535).
+//
+// Absolute query starts with "$" and is applied to root value; relative
+// query starts with "@" and is used inside filter expressions to refer
+// to current node.
+type jsonPathQuery struct {
+	relative bool
+	segments []jsonPathSegment
+}
+
+type jsonPathSegment struct {
+	descendant bool
+	selectors  []jsonPathSelector
+}
+
+// Selector applied to a single node.
+type jsonPathSelector interface {
+	apply(ctx *jsonPathContext, node jsonPathNode, out []jsonPathNode) []jsonPathNode
+}
+
+type (
+	jsonPathNameSelector     struct{ name string }
+	jsonPathWildcardSelector struct{}
+	jsonPathIndexSelector    struct{ index int64 }
+	jsonPathSliceSelector    struct{ start, end, step *int64 }
+	jsonPathFilterSelector   struct{ expr jsonPathLogical }
+)
+
+// Evaluation context.
+type jsonPathContext struct {
+	root    interface{}
+	regexps map[string]*regexp.Regexp
+}
+
+// Logical expression of filter selector.
+type jsonPathLogical interface {
+	test(ctx *jsonPathContext, current interface{}) bool
+}
+
+type (
+	jsonPathOr  []jsonPathLogical
+	jsonPathAnd []jsonPathLogical
+	jsonPathNot struct{ expr jsonPathLogical }
+
+	// existence test of query or result of function
+	jsonPathTest struct{ operand interface{} }
+
+	jsonPathComparison struct {
+		op          string
+		left, right interface{}
+	}
+)
+
+// Literal value in filter expression.
+type jsonPathLiteral struct{ value interface{} }
+
+// Function extension call.
+type jsonPathCall struct {
+	fn   *jsonPathFunction
+	args []interface{}
+}
+
+// Types of function parameters and results.
+type jsonPathType int
+
+const (
+	jsonPathValueType jsonPathType = iota
+	jsonPathLogicalType
+	jsonPathNodesType
+)
+
+// Function extension, with "nothing" represented by ok=false in value
+// results.
+type jsonPathFunction struct {
+	params []jsonPathType
+	result jsonPathType
+	call   func(ctx *jsonPathContext, args []interface{}) interface{}
+}
+
+// Result of function of ValueType.
+type jsonPathValue struct {
+	value interface{}
+	ok    bool
+}
+
+var jsonPathFunctions = map[string]*jsonPathFunction{
+	"length": {
+		params: []jsonPathType{jsonPathValueType},
+		result: jsonPathValueType,
+		call: func(_ *jsonPathContext, args []interface{}) interface{} {
+			arg := args[0].(jsonPathValue)
+			if !arg.ok {
+				return jsonPathValue{}
+			}
+			switch v := arg.value.(type) {
+			case string:
+				return jsonPathValue{float64(utf8.RuneCountInString(v)), true}
+			case []interface{}:
+				return jsonPathValue{float64(len(v)), true}
+			case map[string]interface{}:
+				return jsonPathValue{float64(len(v)), true}
+			}
+			return jsonPathValue{}
+		},
+	},
+	"count": {
+		params: []jsonPathType{jsonPathNodesType},
+		result: jsonPathValueType,
+		call: func(_ *jsonPathContext, args []interface{}) interface{} {
+			return jsonPathValue{float64(len(args[0].([]jsonPathNode))), true}
+		},
+	},
+	"match": {
+		params: []jsonPathType{jsonPathValueType, jsonPathValueType},
+		result: jsonPathLogicalType,
+		call: func(ctx *jsonPathContext, args []interface{}) interface{} {
+			return ctx.matchRegexp(args[0].(jsonPathValue), args[1].(jsonPathValue), true)
+		},
+	},
+	"search": {
+		params: []jsonPathType{jsonPathValueType, jsonPathValueType},
+		result: jsonPathLogicalType,
+		call: func(ctx *jsonPathContext, args []interface{}) interface{} {
+			return ctx.matchRegexp(args[0].(jsonPathValue), args[1].(jsonPathValue), false)
+		},
+	},
+	"value": {
+		params: []jsonPathType{jsonPathNodesType},
+		result: jsonPathValueType,
+		call: func(_ *jsonPathContext, args []interface{}) interface{} {
+			nodes := args[0].([]jsonPathNode)
+			if len(nodes) != 1 {
+				return jsonPathValue{}
+			}
+			return jsonPathValue{nodes[0].value, true}
+		},
+	},
+}
+
+// Maximum absolute value of index and slice bounds.
+const jsonPathMaxInt = 1<<53 - 1
+
+// Evaluate query against given canonical value and return resulting
+// node list.
+func (q *jsonPathQuery) eval(root interface{}) []jsonPathNode {
+	ctx := &jsonPathContext{
+		root:    root,
+		regexps: map[string]*regexp.Regexp{},
+	}
+
+	return ctx.query(q, root)
+}
+
+func (ctx *jsonPathContext) query(q *jsonPathQuery, current interface{}) []jsonPathNode {
+	nodes := []jsonPathNode{{value: ctx.root, path: "$"}}
+	if q.relative {
+		nodes = []jsonPathNode{{value: current, path: "@"}}
+	}
+
+	for _, seg := range q.segments {
+		var out []jsonPathNode
+
+		for _, node := range nodes {
+			if seg.descendant {
+				out = ctx.descend(seg.selectors, node, out)
+			} else {
+				for _, sel := range seg.selectors {
+					out = sel.apply(ctx, node, out)
+				}
+			}
+		}
+
+		nodes = out
+	}
+
+	return nodes
+}
+
+// Apply selectors to node and its descendants, visiting nodes before
+// their children.
+func (ctx *jsonPathContext) descend(
+	selectors []jsonPathSelector, node jsonPathNode, out []jsonPathNode,
+) []jsonPathNode {
+	for _, sel := range selectors {
+		out = sel.apply(ctx, node, out)
+	}
+
+	for _, child := range jsonPathChildren(node) {
+		out = ctx.descend(selectors, child, out)
+	}
+
+	return out
+}
+
+func jsonPathChildren(node jsonPathNode) []jsonPathNode {
+	var children []jsonPathNode
+
+	switch v := node.value.(type) {
+	case []interface{}:
+		for n, elem := range v {
+			children = append(children, jsonPathNode{
+				value: elem,
+				path:  node.path + "[" + strconv.Itoa(n) + "]",
+			})
+		}
+
+	case map[string]interface{}:
+		for _, k := range sortedKeys(v) {
+			children = append(children, jsonPathNode{
+				value: v[k],
+				path:  node.path + formatJSONPathName(k),
+			})
+		}
+	}
+
+	return children
+}
+
+func (s jsonPathNameSelector) apply(
+	_ *jsonPathContext, node jsonPathNode, out []jsonPathNode,
+) []jsonPathNode {
+	if obj, ok := node.value.(map[string]interface{}); ok {
+		if val, ok := obj[s.name]; ok {
+			out = append(out, jsonPathNode{
+				value: val,
+				path:  node.path + formatJSONPathName(s.name),
+			})
+		}
+	}
+	return out
+}
+
+func (jsonPathWildcardSelector) apply(
+	_ *jsonPathContext, node jsonPathNode, out []jsonPathNode,
+) []jsonPathNode {
+	return append(out, jsonPathChildren(node)...)
+}
+
+func (s jsonPathIndexSelector) apply(
+	_ *jsonPathContext, node jsonPathNode, out []jsonPathNode,
+) []jsonPathNode {
+	if arr, ok := node.value.([]interface{}); ok {
+		idx := s.index
+		if idx < 0 {
+			idx += int64(len(arr))
+		}
+		if idx >= 0 && idx < int64(len(arr)) {
+			out = append(out, jsonPathNode{
+				value: arr[idx],
+				path:  node.path + "[" + strconv.FormatInt(idx, 10) + "]",
+			})
+		}
+	}
+	return out
+}
+
+func (s jsonPathSliceSelector) apply(
+	_ *jsonPathContext, node jsonPathNode, out []jsonPathNode,
+) []jsonPathNode {
+	arr, ok := node.value.([]interface{})
+	if !ok {
+		return out
+	}
+
+	length := int64(len(arr))
+
+	step := int64(1)
+	if s.step != nil {
+		step = *s.step
+	}
+	if step == 0 {
+		return out
+	}
+
+	normalize := func(i int64) int64 {
+		if i < 0 {
+			return length + i
+		}
+		return i
+	}
+
+	clamp := func(i, lo, hi int64) int64 {
+		if i < lo {
+			return lo
+		}
+		if i > hi {
+			return hi
+		}
+		return i
+	}
+
+	elem := func(i int64) jsonPathNode {
+		return jsonPathNode{
+			value: arr[i],
+			path:  node.path + "[" + strconv.FormatInt(i, 10) + "]",
+		}
+	}
+
+	if step > 0 {
+		start, end := int64(0), length
+		if s.start != nil {
+			start = normalize(*s.start)
+		}
+		if s.end != nil {
+			end = normalize(*s.end)
+		}
+		lower, upper := clamp(start, 0, length), clamp(end, 0, length)
+		for i := lower; i < upper; i += step {
+			out = append(out, elem(i))
+		}
+	} else {
+		start, end := length-1, -length-1
+		if s.start != nil {
+			start = normalize(*s.start)
+		}
+		if s.end != nil {
+			end = normalize(*s.end)
+		}
+		upper, lower := clamp(start, -1, length-1), clamp(end, -1, length-1)
+		for i := upper; lower < i; i += step {
+			out = append(out, elem(i))
+		}
+	}
+
+	return out
+}
+
+func (s jsonPathFilterSelector) apply(
+	ctx *jsonPathContext, node jsonPathNode, out []jsonPathNode,
+) []jsonPathNode {
+	for _, child := range jsonPathChildren(node) {
+		if s.expr.test(ctx, child.value) {
+			out = append(out, child)
+		}
+	}
+	return out
+}
+
+func (e jsonPathOr) test(ctx *jsonPathContext, current interface{}) bool {
+	for _, sub := range e {
+		if sub.test(ctx, current) {
+			return true
+		}
+	}
+	return false
+}
+
+func (e jsonPathAnd) test(ctx *jsonPathContext, current interface{}) bool {
+	for _, sub := range e {
+		if !sub.test(ctx, current) {
+			return false
+		}
+	}
+	return true
+}
+
+func (e jsonPathNot) test(ctx *jsonPathContext, current interface{}) bool {
+	return !e.expr.test(ctx, current)
+}
+
+func (e jsonPathTest) test(ctx *jsonPathContext, current interface{}) bool {
+	return ctx.logical(e.operand, current)
+}
+
+func (e jsonPathComparison) test(ctx *jsonPathContext, current interface{}) bool {
+	left := ctx.value(e.left, current)
+	right := ctx.value(e.right, current)
+
+	switch e.op {
+	case "==":
+		return jsonPathEqual(left, right)
+	case "!=":
+		return !jsonPathEqual(left, right)
+	case "<":
+		return jsonPathLess(left, right)
+	case "<=":
+		return jsonPathLess(left, right) || jsonPathEqual(left, right)
+	case ">":
+		return jsonPathLess(right, left)
+	case ">=":
+		return jsonPathLess(right, left) || jsonPathEqual(left, right)
+	}
+
+	return false
+}
+
+// Evaluate operand as ValueType.
+func (ctx *jsonPathContext) value(operand, current interface{}) jsonPathValue {
+	switch op := operand.(type) {
+	case *jsonPathLiteral:
+		return jsonPathValue{op.value, true}
+
+	case *jsonPathQuery:
+		nodes := ctx.query(op, current)
+		if len(nodes) == 0 {
+			return jsonPathValue{}
+		}
+		return jsonPathValue{nodes[0].value, true}
+
+	case *jsonPathCall:
+		return ctx.call(op, current).(jsonPathValue)
+	}
+
+	return jsonPathValue{}
+}
+
+// Evaluate operand as LogicalType.
+func (ctx *jsonPathContext) logical(operand, current interface{}) bool {
+	switch op := operand.(type) {
+	case jsonPathLogical:
+		return op.test(ctx, current)
+
+	case *jsonPathQuery:
+		return len(ctx.query(op, current)) != 0
+
+	case *jsonPathCall:
+		switch res := ctx.call(op, current).(type) {
+		case bool:
+			return res
+		case []jsonPathNode:
+			return len(res) != 0
+		}
+	}
+
+	return false
+}
+
+func (ctx *jsonPathContext) call(c *jsonPathCall, current interface{}) interface{} {
+	args := make([]interface{}, len(c.args))
+
+	for n, arg := range c.args {
+		switch c.fn.params[n] {
+		case jsonPathValueType:
+			args[n] = ctx.value(arg, current)
+
+		case jsonPathLogicalType:
+			args[n] = ctx.logical(arg, current)
+
+		case jsonPathNodesType:
+			args[n] = ctx.query(arg.(*jsonPathQuery), current)
+		}
+	}
+
+	return c.fn.call(ctx, args)
+}
+
+func (ctx *jsonPathContext) matchRegexp(value, pattern jsonPathValue, full bool) bool {
+	str, ok := value.value.(string)
+	if !value.ok || !ok {
+		return false
+	}
+
+	re, ok := pattern.value.(string)
+	if !pattern.ok || !ok {
+		return false
+	}
+
+	re = translateIRegexp(re)
+	if full {
+		re = `^(?:` + re + `)$`
+	}
+
+	compiled, ok := ctx.regexps[re]
+	if !ok {
+		compiled, _ = regexp.Compile(re)
+		ctx.regexps[re] = compiled
+	}
+
+	if compiled == nil {
+		return false
+	}
+
+	return compiled.MatchString(str)
+}
+
+// Convert I-Regexp (RFC 9485) to RE2 syntax. The only difference
+// that matters is that "." doesn't match "\r" in I-Regexp.
+func translateIRegexp(re string) string {
+	var b strings.Builder
+
+	inClass := false
+
+	for i := 0; i < len(re); i++ {
+		c := re[i]
+
+		switch {
+		case c == '\\' && i+1 < len(re):
+			b.WriteByte(c)
+			b.WriteByte(re[i+1])
+			i++
+			continue
+
+		case c == '[':
+			inClass = true
+
+		case c == ']':
+			inClass = false
+
+		case c == '.' && !inClass:
+			b.WriteString(`[^\n\r]`)
+			continue
+		}
+
+		b.WriteByte(c)
+	}
+
+	return b.String()
+}
+
+func jsonPathEqual(a, b jsonPathValue) bool {
+	if !a.ok || !b.ok {
+		return !a.ok && !b.ok
+	}
+
+	return jsonPathDeepEqual(a.value, b.value)
+}
+
+func jsonPathDeepEqual(a, b interface{}) bool {
+	if isNumber(a) && isNumber(b) {
+		ra, _ := canonRat(a)
+		rb, _ := canonRat(b)
+		return ra != nil && rb != nil && ra.Cmp(rb) == 0
+	}
+
+	switch av := a.(type) {
+	case nil:
+		return b == nil
+
+	case bool:
+		bv, ok := b.(bool)
+		return ok && av == bv
+
+	case string:
+		bv, ok := b.(string)
+		return ok && av == bv
+
+	case []interface{}:
+		bv, ok := b.([]interface{})
+		if !ok || len(av) != len(bv) {
+			return false
+		}
+		for n := range av {
+			if !jsonPathDeepEqual(av[n], bv[n]) {
+				return false
+			}
+		}
+		return true
+
+	case map[string]interface{}:
+		bv, ok := b.(map[string]interface{})
+		if !ok || len(av) != len(bv) {
+			return false
+		}
+		for k := range av {
+			if _, ok := bv[k]; !ok || !jsonPathDeepEqual(av[k], bv[k]) {
+				return false
+			}
+		}
+		return true
+	}
+
+	return false
+}
+
+func jsonPathLess(a, b jsonPathValue) bool {
+	if !a.ok || !b.ok {
+		return false
+	}
+
+	if isNumber(a.value) && isNumber(b.value) {
+		ra, _ := canonRat(a.value)
+		rb, _ := canonRat(b.value)
+		return ra != nil && rb != nil && ra.Cmp(rb) < 0
+	}
+
+	as, ok1 := a.value.(string)
+	bs, ok2 := b.value.(string)
+
+	// byte-wise comparison of UTF-8 is the same as comparison of code points
+	return ok1 && ok2 && as < bs
+}
+
+// Format member name as normalized path element, e.g. "['name']".
+func formatJSONPathName(name string) string {
+	var b strings.Builder
+
+	b.WriteString("['")
+
+	for _, r := range name {
+		switch r {
+		case '\b':
+			b.WriteString(`\b`)
+		case '\f':
+			b.WriteString(`\f`)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\r':
+			b.WriteString(`\r`)
+		case '\t':
+			b.WriteString(`\t`)
+		case '\'':
+			b.WriteString(`\'`)
+		case '\\':
+			b.WriteString(`\\`)
+		default:
+			if r < 0x20 {
+				fmt.Fprintf(&b, `\u%04x`, r)
+			} else {
+				b.WriteRune(r)
+			}
+		}
+	}
+
+	b.WriteString("']")
+
+	return b.String()
+}
+
+// Parser of JSONPath queries (RFC 9535).
+type jsonPathParser struct {
+	input string
+	pos   int
+}
+
+func parseJSONPath(path string) (*jsonPathQuery, error) {
+	p := &jsonPathParser{input: path}
+
+	if !p.consume("$") {
+		return nil, p.errorf("query should start with '$'")
+	}
+
+	q, err := p.parseSegments(false)
+	if err != nil {
+		return nil, err
+	}
+
+	if p.pos != len(p.input) {
+		return nil, p.errorf("unexpected %q", p.input[p.pos:])
+	}
+
+	return q, nil
+}
+
+func (p *jsonPathParser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("invalid json path at position %d: %s",
+		p.pos, fmt.Sprintf(format, args...))
+}
+
+func (p *jsonPathParser) peek() byte {
+	if p.pos < len(p.input) {
+		return p.input[p.pos]
+	}
+	return 0
+}
+
+func (p *jsonPathParser) consume(s string) bool {
+	if strings.HasPrefix(p.input[p.pos:], s) {
+		p.pos += len(s)
+		return true
+	}
+	return false
+}
+
+func (p *jsonPathParser) skipSpace() {
+	for p.pos < len(p.input) {
+		switch p.input[p.pos] {
+		case ' ', '\t', '\n', '\r':
+			p.pos++
+		default:
+			return
+		}
+	}
+}
+
+func (p *jsonPathParser) parseSegments(relative bool) (*jsonPathQuery, error) {
+	q := &jsonPathQuery{relative: relative}
+
+	for {
+		start := p.pos
+		p.skipSpace()
+
+		var (
+			seg jsonPathSegment
+			err error
+		)
+
+		switch {
+		case p.consume(".."):
+			seg.descendant = true
+			if p.peek() == '[' {
+				seg.selectors, err = p.parseBracketed()
+			} else {
+				seg.selectors, err = p.parseShorthand()
+			}
+
+		case p.consume("."):
+			seg.selectors, err = p.parseShorthand()
+
+		case p.peek() == '[':
+			seg.selectors, err = p.parseBracketed()
+
+		default:
+			// whitespace is allowed only between segments
+			p.pos = start
+			return q, nil
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		q.segments = append(q.segments, seg)
+	}
+}
+
+func (p *jsonPathParser) parseShorthand() ([]jsonPathSelector, error) {
+	if p.consume("*") {
+		return []jsonPathSelector{jsonPathWildcardSelector{}}, nil
+	}
+
+	start := p.pos
+
+	for p.pos < len(p.input) {
+		r, size := utf8.DecodeRuneInString(p.input[p.pos:])
+		if !isJSONPathNameChar(r, p.pos == start) {
+			break
+		}
+		p.pos += size
+	}
+
+	if p.pos == start {
+		return nil, p.errorf("expected member name or '*'")
+	}
+
+	return []jsonPathSelector{jsonPathNameSelector{name: p.input[start:p.pos]}}, nil
+}
+
+func isJSONPathNameChar(r rune, first bool) bool {
+	switch {
+	case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r == '_':
+		return true
+	case r >= '0' && r <= '9':
+		return !first
+	default:
+		return r >= 0x80 && r != utf8.RuneError
+	}
+}
+
+func (p *jsonPathParser) parseBracketed() ([]jsonPathSelector, error) {
+	p.consume("[")
+
+	var selectors []jsonPathSelector
+
+	for {
+		p.skipSpace()
+
+		sel, err := p.parseSelector()
+		if err != nil {
+			return nil, err
+		}
+		selectors = append(selectors, sel)
+
+		p.skipSpace()
+
+		if p.consume("]") {
+			return selectors, nil
+		}
+		if !p.consume(",") {
+			return nil, p.errorf("expected ',' or ']'")
+		}
+	}
+}
+
+func (p *jsonPathParser) parseSelector() (jsonPathSelector, error) {
+	switch c := p.peek(); {
+	case c == '\'' || c == '"':
+		name, err := p.parseString()
+		if err != nil {
+			return nil, err
+		}
+		return jsonPathNameSelector{name: name}, nil
+
+	case c == '*':
+		p.pos++
+		return jsonPathWildcardSelector{}, nil
+
+	case c == '?':
+		p.pos++
+		p.skipSpace()
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		return jsonPathFilterSelector{expr: expr}, nil
+
+	case c == ':' || c == '-' || (c >= '0' && c <= '9'):
+		return p.parseIndexOrSlice()
+
+	default:
+		return nil, p.errorf("expected selector")
+	}
+}
+
+func (p *jsonPathParser) parseIndexOrSlice() (jsonPathSelector, error) {
+	var bounds [3]*int64
+
+	for n := 0; n < 3; n++ {
+		if n != 0 {
+			p.skipSpace()
+			if !p.consume(":") {
+				break
+			}
+			p.skipSpace()
+		}
+
+		if c := p.peek(); c == '-' || (c >= '0' && c <= '9') {
+			i, err := p.parseInt()
+			if err != nil {
+				return nil, err
+			}
+			bounds[n] = &i
+		}
+
+		if n == 0 {
+			start := p.pos
+			p.skipSpace()
+			isSlice := p.peek() == ':'
+			p.pos = start
+
+			if !isSlice {
+				if bounds[0] == nil {
+					return nil, p.errorf("expected index")
+				}
+				return jsonPathIndexSelector{index: *bounds[0]}, nil
+			}
+		}
+	}
+
+	return jsonPathSliceSelector{start: bounds[0], end: bounds[1], step: bounds[2]}, nil
+}
+
+func (p *jsonPathParser) parseInt() (int64, error) {
+	start := p.pos
+
+	p.consume("-")
+
+	digits := p.pos
+	for p.pos < len(p.input) && p.input[p.pos] >= '0' && p.input[p.pos] <= '9' {
+		p.pos++
+	}
+
+	s := p.input[start:p.pos]
+
+	switch {
+	case p.pos == digits:
+		return 0, p.errorf("expected integer")
+
+	case p.input[digits] == '0' && (p.pos-digits > 1 || digits != start):
+		return 0, p.errorf("invalid integer %q", s)
+	}
+
+	i, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || i > jsonPathMaxInt || i < -jsonPathMaxInt {
+		return 0, p.errorf("integer %s out of range", s)
+	}
+
+	return i, nil
+}
+
+func (p *jsonPathParser) parseString() (string, error) {
+	quote := p.input[p.pos]
+	p.pos++
+
+	var b strings.Builder
+
+	for {
+		if p.pos >= len(p.input) {
+			return "", p.errorf("unterminated string")
+		}
+
+		r, size := utf8.DecodeRuneInString(p.input[p.pos:])
+
+		switch {
+		case r == rune(quote):
+			p.pos++
+			return b.String(), nil
+
+		case r == '\\':
+			p.pos++
+			r, err := p.parseEscape(quote)
+			if err != nil {
+				return "", err
+			}
+			b.WriteRune(r)
+
+		case r < 0x20:
+			return "", p.errorf("unescaped control character in string")
+
+		default:
+			b.WriteRune(r)
+			p.pos += size
+		}
+	}
+}
+
+func (p *jsonPathParser) parseEscape(quote byte) (rune, error) {
+	c := p.peek()
+	p.pos++
+
+	switch c {
+	case 'b':
+		return '\b', nil
+	case 'f':
+		return '\f', nil
+	case 'n':
+		return '\n', nil
+	case 'r':
+		return '\r', nil
+	case 't':
+		return '\t', nil
+	case '/', '\\':
+		return rune(c), nil
+	case '\'', '"':
+		if c != quote {
+			break
+		}
+		return rune(c), nil
+	case 'u':
+		r, err := p.parseHex()
+		if err != nil {
+			return 0, err
+		}
+		if r >= 0xD800 && r <= 0xDBFF {
+			if !p.consume(`\u`) {
+				return 0, p.errorf("invalid surrogate pair")
+			}
+			low, err := p.parseHex()
+			if err != nil {
+				return 0, err
+			}
+			if low < 0xDC00 || low > 0xDFFF {
+				return 0, p.errorf("invalid surrogate pair")
+			}
+			return (r-0xD800)<<10 + (low - 0xDC00) + 0x10000, nil
+		}
+		if r >= 0xDC00 && r <= 0xDFFF {
+			return 0, p.errorf("invalid surrogate pair")
+		}
+		return r, nil
+	}
+
+	return 0, p.errorf("invalid escape sequence")
+}
+
+func (p *jsonPathParser) parseHex() (rune, error) {
+	if p.pos+4 > len(p.input) {
+		return 0, p.errorf("invalid unicode escape")
+	}
+
+	v, err := strconv.ParseUint(p.input[p.pos:p.pos+4], 16, 32)
+	if err != nil {
+		return 0, p.errorf("invalid unicode escape")
+	}
+
+	p.pos += 4
+	return rune(v), nil
+}
+
+func (p *jsonPathParser) parseOr() (jsonPathLogical, error) {
+	var or jsonPathOr
+
+	for {
+		expr, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		or = append(or, expr)
+
+		start := p.pos
+		p.skipSpace()
+		if !p.consume("||") {
+			p.pos = start
+			break
+		}
+		p.skipSpace()
+	}
+
+	if len(or) == 1 {
+		return or[0], nil
+	}
+	return or, nil
+}
+
+func (p *jsonPathParser) parseAnd() (jsonPathLogical, error) {
+	var and jsonPathAnd
+
+	for {
+		expr, err := p.parseBasic()
+		if err != nil {
+			return nil, err
+		}
+		and = append(and, expr)
+
+		start := p.pos
+		p.skipSpace()
+		if !p.consume("&&") {
+			p.pos = start
+			break
+		}
+		p.skipSpace()
+	}
+
+	if len(and) == 1 {
+		return and[0], nil
+	}
+	return and, nil
+}
+
+func (p *jsonPathParser) parseBasic() (jsonPathLogical, error) {
+	if p.consume("!") {
+		p.skipSpace()
+
+		if p.peek() == '(' {
+			expr, err := p.parseParen()
+			if err != nil {
+				return nil, err
+			}
+			return jsonPathNot{expr: expr}, nil
+		}
+
+		operand, err := p.parseOperand()
+		if err != nil {
+			return nil, err
+		}
+		test, err := p.checkTest(operand)
+		if err != nil {
+			return nil, err
+		}
+		return jsonPathNot{expr: test}, nil
+	}
+
+	if p.peek() == '(' {
+		return p.parseParen()
+	}
+
+	left, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+
+	start := p.pos
+	p.skipSpace()
+
+	op := ""
+	for _, candidate := range []string{"==", "!=", "<=", ">=", "<", ">"} {
+		if p.consume(candidate) {
+			op = candidate
+			break
+		}
+	}
+
+	if op == "" {
+		p.pos = start
+		return p.checkTest(left)
+	}
+
+	p.skipSpace()
+
+	right, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, operand := range []interface{}{left, right} {
+		if err := p.checkComparable(operand); err != nil {
+			return nil, err
+		}
+	}
+
+	return jsonPathComparison{op: op, left: left, right: right}, nil
+}
+
+func (p *jsonPathParser) parseParen() (jsonPathLogical, error) {
+	p.consume("(")
+	p.skipSpace()
+
+	expr, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	p.skipSpace()
+	if !p.consume(")") {
+		return nil, p.errorf("expected ')'")
+	}
+
+	return expr, nil
+}
+
+// Parse literal, query, or function call.
+func (p *jsonPathParser) parseOperand() (interface{}, error) {
+	switch c := p.peek(); {
+	case c == '@' || c == '$':
+		p.pos++
+		return p.parseSegments(c == '@')
+
+	case c == '\'' || c == '"':
+		s, err := p.parseString()
+		if err != nil {
+			return nil, err
+		}
+		return &jsonPathLiteral{value: s}, nil
+
+	case c == '-' || (c >= '0' && c <= '9'):
+		return p.parseNumber()
+
+	case c >= 'a' && c <= 'z':
+		start := p.pos
+		for p.pos < len(p.input) {
+			c := p.input[p.pos]
+			if (c < 'a' || c > 'z') && (c < '0' || c > '9') && c != '_' {
+				break
+			}
+			p.pos++
+		}
+		name := p.input[start:p.pos]
+
+		if p.peek() == '(' {
+			return p.parseCall(name)
+		}
+
+		switch name {
+		case "true":
+			return &jsonPathLiteral{value: true}, nil
+		case "false":
+			return &jsonPathLiteral{value: false}, nil
+		case "null":
+			return &jsonPathLiteral{value: nil}, nil
+		}
+
+		p.pos = start
+		return nil, p.errorf("unexpected %q", name)
+
+	default:
+		return nil, p.errorf("expected filter expression")
+	}
+}
+
+func (p *jsonPathParser) parseNumber() (interface{}, error) {
+	start := p.pos
+
+	p.consume("-")
+
+	digits := p.pos
+	for p.pos < len(p.input) && p.input[p.pos] >= '0' && p.input[p.pos] <= '9' {
+		p.pos++
+	}
+	if p.pos == digits || (p.input[digits] == '0' && p.pos-digits > 1) {
+		return nil, p.errorf("invalid number")
+	}
+
+	if p.consume(".") {
+		frac := p.pos
+		for p.pos < len(p.input) && p.input[p.pos] >= '0' && p.input[p.pos] <= '9' {
+			p.pos++
+		}
+		if p.pos == frac {
+			return nil, p.errorf("invalid number")
+		}
+	}
+
+	if c := p.peek(); c == 'e' || c == 'E' {
+		p.pos++
+		if c := p.peek(); c == '+' || c == '-' {
+			p.pos++
+		}
+		exp := p.pos
+		for p.pos < len(p.input) && p.input[p.pos] >= '0' && p.input[p.pos] <= '9' {
+			p.pos++
+		}
+		if p.pos == exp {
+			return nil, p.errorf("invalid number")
+		}
+	}
+
+	return &jsonPathLiteral{
+		value: canonJSONNumber(json.Number(p.input[start:p.pos])),
+	}, nil
+}
+
+func (p *jsonPathParser) parseCall(name string) (interface{}, error) {
+	fn, ok := jsonPathFunctions[name]
+	if !ok {
+		return nil, p.errorf("unknown function %q", name)
+	}
+
+	p.consume("(")
+	p.skipSpace()
+
+	call := &jsonPathCall{fn: fn}
+
+	for !p.consume(")") {
+		if len(call.args) != 0 {
+			if !p.consume(",") {
+				return nil, p.errorf("expected ',' or ')'")
+			}
+			p.skipSpace()
+		}
+
+		arg, err := p.parseArg()
+		if err != nil {
+			return nil, err
+		}
+
+		if len(call.args) >= len(fn.params) {
+			return nil, p.errorf("too many arguments for %s()", name)
+		}
+		if err := p.checkArg(fn.params[len(call.args)], arg); err != nil {
+			return nil, fmt.Errorf("%s: argument of %s()", err, name)
+		}
+
+		call.args = append(call.args, arg)
+		p.skipSpace()
+	}
+
+	if len(call.args) != len(fn.params) {
+		return nil, p.errorf("%s() expects %d argument(s)", name, len(fn.params))
+	}
+
+	return call, nil
+}
+
+// Parse function argument: literal, query, function call, or logical
+// expression.
+func (p *jsonPathParser) parseArg() (interface{}, error) {
+	start := p.pos
+
+	if c := p.peek(); c != '!' && c != '(' {
+		operand, err := p.parseOperand()
+		if err == nil {
+			p.skipSpace()
+			if c := p.peek(); c == ',' || c == ')' {
+				return operand, nil
+			}
+		}
+		p.pos = start
+	}
+
+	return p.parseOr()
+}
+
+// Check well-typedness of function argument.
+func (p *jsonPathParser) checkArg(param jsonPathType, arg interface{}) error {
+	switch param {
+	case jsonPathValueType:
+		return p.checkComparable(arg)
+
+	case jsonPathLogicalType:
+		switch a := arg.(type) {
+		case jsonPathLogical, *jsonPathQuery:
+			return nil
+		case *jsonPathCall:
+			if a.fn.result != jsonPathValueType {
+				return nil
+			}
+		}
+		return p.errorf("expected logical expression")
+
+	case jsonPathNodesType:
+		if _, ok := arg.(*jsonPathQuery); ok {
+			return nil
+		}
+		return p.errorf("expected query")
+	}
+
+	return nil
+}
+
+// Check that operand may be used in comparison.
+func (p *jsonPathParser) checkComparable(operand interface{}) error {
+	switch op := operand.(type) {
+	case *jsonPathLiteral:
+		return nil
+
+	case *jsonPathQuery:
+		if op.singular() {
+			return nil
+		}
+		return p.errorf("expected singular query")
+
+	case *jsonPathCall:
+		if op.fn.result == jsonPathValueType {
+			return nil
+		}
+		return p.errorf("function result is not comparable")
+	}
+
+	return p.errorf("expected comparable value")
+}
+
+// Check that operand may be used as test expression.
+func (p *jsonPathParser) checkTest(operand interface{}) (jsonPathLogical, error) {
+	switch op := operand.(type) {
+	case *jsonPathQuery:
+		return jsonPathTest{operand: op}, nil
+
+	case *jsonPathCall:
+		if op.fn.result != jsonPathValueType {
+			return jsonPathTest{operand: op}, nil
+		}
+		return nil, p.errorf("function result should be compared")
+	}
+
+	return nil, p.errorf("literal should be compared")
+}
+
+// Singular query selects at most one node.
+func (q *jsonPathQuery) singular() bool {
+	for _, seg := range q.segments {
+		if seg.descendant || len(seg.selectors) != 1 {
+			return false
+		}
+		switch seg.selectors[0].(type) {
+		case jsonPathNameSelector, jsonPathIndexSelector:
+		default:
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,446 @@
+package httpexpect
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// example from RFC 9535, section 1.5
+const jsonPathStore = `{
+	"store": {
+		"book": [
+			{
+				"category": "reference",
+				"author": "Nigel Rees",
+				"title": "Sayings of the Century",
+				"price": 8.95
+			},
+			{
+				"category": "fiction",
+				"author": "Evelyn Waugh",
+				"title": "Sword of Honour",
+				"price": 12.99
+			},
+			{
+				"category": "fiction",
+				"author": "Herman Melville",
+				"title": "Moby Dick",
+				"isbn": "0-553-21311-3",
+				"price": 8.99
+			},
+			{
+				"category": "fiction",
+				"author": "J. R. R. Tolkien",
+				"title": "The Lord of the Rings",
+				"isbn": "0-395-19395-8",
+				"price": 22.99
+			}
+		],
+		"bicycle": {
+			"color": "red",
+			"price": 399
+		}
+	}
+}`
+
+func evalJSONPath(t *testing.T, data interface{}, path string) []jsonPathNode {
+	query, err := parseJSONPath(path)
+	require.NoError(t, err, "path: %s", path)
+
+	return query.eval(data)
+}
+
+func loadJSONPathData(t *testing.T, data string) interface{} {
+	var value interface{}
+	require.NoError(t, json.Unmarshal([]byte(data), &value))
+	return value
+}
+
+func TestJSONPath_Store(t *testing.T) {
+	data := loadJSONPathData(t, jsonPathStore)
+
+	cases := []struct {
+		path  string
+		paths []string
+	}{
+		{
+			path: "$.store.book[*].author",
+			paths: []string{
+				"$['store']['book'][0]['author']",
+				"$['store']['book'][1]['author']",
+				"$['store']['book'][2]['author']",
+				"$['store']['book'][3]['author']",
+			},
+		},
+		{
+			path: "$..author",
+			paths: []string{
+				"$['store']['book'][0]['author']",
+				"$['store']['book'][1]['author']",
+				"$['store']['book'][2]['author']",
+				"$['store']['book'][3]['author']",
+			},
+		},
+		{
+			path: "$.store.*",
+			paths: []string{
+				"$['store']['bicycle']",
+				"$['store']['book']",
+			},
+		},
+		{
+			path: "$.store..price",
+			paths: []string{
+				"$['store']['bicycle']['price']",
+				"$['store']['book'][0]['price']",
+				"$['store']['book'][1]['price']",
+				"$['store']['book'][2]['price']",
+				"$['store']['book'][3]['price']",
+			},
+		},
+		{
+			path:  "$..book[2]",
+			paths: []string{"$['store']['book'][2]"},
+		},
+		{
+			path:  "$..book[-1]",
+			paths: []string{"$['store']['book'][3]"},
+		},
+		{
+			path: "$..book[0,1]",
+			paths: []string{
+				"$['store']['book'][0]",
+				"$['store']['book'][1]",
+			},
+		},
+		{
+			path: "$..book[:2]",
+			paths: []string{
+				"$['store']['book'][0]",
+				"$['store']['book'][1]",
+			},
+		},
+		{
+			path: "$..book[?@.isbn]",
+			paths: []string{
+				"$['store']['book'][2]",
+				"$['store']['book'][3]",
+			},
+		},
+		{
+			path: "$..book[?@.price<10]",
+			paths: []string{
+				"$['store']['book'][0]",
+				"$['store']['book'][2]",
+			},
+		},
+		{
+			path: `$.store.book[?@.category == 'fiction' && !@.isbn].title`,
+			paths: []string{
+				"$['store']['book'][1]['title']",
+			},
+		},
+		{
+			path: `$.store.book[?match(@.author, "[A-Z]\\. .*") || @.price > 20]`,
+			paths: []string{
+				"$['store']['book'][3]",
+			},
+		},
+		{
+			path: "$.store.book[?length(@.title) > 16].price",
+			paths: []string{
+				"$['store']['book'][0]['price']",
+				"$['store']['book'][3]['price']",
+			},
+		},
+		{
+			path: "$.store[?count(@.*) == 2]",
+			paths: []string{
+				"$['store']['bicycle']",
+			},
+		},
+		{
+			path: `$..*[?search(@.title, "(?i)moby")].isbn`,
+			paths: []string{
+				"$['store']['book'][2]['isbn']",
+			},
+		},
+		{
+			path: "$.store.book[?value(@..price) == $.store.book[2].price].title",
+			paths: []string{
+				"$['store']['book'][2]['title']",
+			},
+		},
+		{
+			path:  "$.store.missing",
+			paths: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.path, func(t *testing.T) {
+			var paths []string
+			for _, node := range evalJSONPath(t, data, tc.path) {
+				paths = append(paths, node.path)
+			}
+			assert.Equal(t, tc.paths, paths)
+		})
+	}
+
+	nodes := evalJSONPath(t, data, "$..book[?@.price > 20].title")
+	require.Equal(t, 1, len(nodes))
+	assert.Equal(t, "The Lord of the Rings", nodes[0].value)
+}
+
+func TestJSONPath_Selectors(t *testing.T) {
+	arr := loadJSONPathData(t, `["a", "b", "c", "d", "e", "f", "g"]`)
+
+	cases := []struct {
+		path   string
+		values []interface{}
+	}{
+		{"$", []interface{}{arr}},
+		{"$[1]", []interface{}{"b"}},
+		{"$[-2]", []interface{}{"f"}},
+		{"$[7]", nil},
+		{"$[-8]", nil},
+		{"$[0, 0]", []interface{}{"a", "a"}},
+		{"$[1:3]", []interface{}{"b", "c"}},
+		{"$[5:]", []interface{}{"f", "g"}},
+		{"$[1:5:2]", []interface{}{"b", "d"}},
+		{"$[5:1:-2]", []interface{}{"f", "d"}},
+		{"$[::-1]", []interface{}{"g", "f", "e", "d", "c", "b", "a"}},
+		{"$[:]", []interface{}{"a", "b", "c", "d", "e", "f", "g"}},
+		{"$[ 1 : 2 ]", []interface{}{"b"}},
+		{"$[::0]", nil},
+		{"$[-100:2]", []interface{}{"a", "b"}},
+		{"$[1:100]", []interface{}{"b", "c", "d", "e", "f", "g"}},
+		{"$[?@ == 'c' || @ == 'e']", []interface{}{"c", "e"}},
+		{"$[?@ > 'e']", []interface{}{"f", "g"}},
+		{"$.a", nil},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.path, func(t *testing.T) {
+			var values []interface{}
+			for _, node := range evalJSONPath(t, arr, tc.path) {
+				values = append(values, node.value)
+			}
+			assert.Equal(t, tc.values, values)
+		})
+	}
+}
+
+func TestJSONPath_Names(t *testing.T) {
+	data := loadJSONPathData(t, `{
+		"o": {"j j": {"k.k": 3}},
+		"'": {"@": 2},
+		"é": 1,
+		"\n": 4,
+		"\u0001": 5
+	}`)
+
+	cases := []struct {
+		path  string
+		paths []string
+	}{
+		{`$.o['j j']['k.k']`, []string{`$['o']['j j']['k.k']`}},
+		{`$.o["j j"]["k.k"]`, []string{`$['o']['j j']['k.k']`}},
+		{`$["'"]["@"]`, []string{`$['\'']['@']`}},
+		{`$['\'']`, []string{`$['\'']`}},
+		{`$.é`, []string{`$['é']`}},
+		{`$["é"]`, []string{`$['é']`}},
+		{`$["\n"]`, []string{`$['\n']`}},
+		{`$["\u0001"]`, []string{`$['\u0001']`}},
+		{`$['😀']`, nil},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.path, func(t *testing.T) {
+			var paths []string
+			for _, node := range evalJSONPath(t, data, tc.path) {
+				paths = append(paths, node.path)
+			}
+			assert.Equal(t, tc.paths, paths)
+		})
+	}
+}
+
+func TestJSONPath_Comparisons(t *testing.T) {
+	// example from RFC 9535, section 2.3.5.3
+	data := loadJSONPathData(t, `{
+		"obj": {"x": "y"},
+		"arr": [2, 3]
+	}`)
+
+	cases := []struct {
+		expr   string
+		result bool
+	}{
+		{"$.absent1 == $.absent2", true},
+		{"$.absent1 <= $.absent2", true},
+		{"$.absent == 'g'", false},
+		{"$.absent1 != $.absent2", false},
+		{"$.absent != 'g'", true},
+		{"1 <= 2", true},
+		{"1 > 2", false},
+		{"13 == '13'", false},
+		{"'a' <= 'b'", true},
+		{"'a' > 'b'", false},
+		{"$.obj == $.arr", false},
+		{"$.obj != $.arr", true},
+		{"$.obj == $.obj", true},
+		{"$.obj != $.obj", false},
+		{"$.arr == $.arr", true},
+		{"$.arr != $.arr", false},
+		{"$.obj == 17", false},
+		{"$.obj != 17", true},
+		{"$.obj <= $.arr", false},
+		{"$.obj < $.arr", false},
+		{"$.obj <= $.obj", true},
+		{"$.arr <= $.arr", true},
+		{"1 <= $.arr", false},
+		{"1 >= $.arr", false},
+		{"1 > $.arr", false},
+		{"1 < $.arr", false},
+		{"true <= true", true},
+		{"true > true", false},
+		{"1 == 1.0", true},
+		{"1 == 1e0", true},
+		{"-0 == 0", true},
+		{"0.1 == 1e-1", true},
+		{"$.arr[0] == 2", true},
+		{"length($.arr) == 2", true},
+		{"null == null", true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.expr, func(t *testing.T) {
+			nodes := evalJSONPath(t, data, "$[?"+tc.expr+"]")
+			assert.Equal(t, tc.result, len(nodes) == 2)
+			if !tc.result {
+				assert.Equal(t, 0, len(nodes))
+			}
+		})
+	}
+}
+
+func TestJSONPath_Exact(t *testing.T) {
+	data := map[string]interface{}{
+		"items": []interface{}{
+			map[string]interface{}{"id": json.Number("12345678901234567890")},
+			map[string]interface{}{"id": json.Number("12345678901234567891")},
+		},
+	}
+
+	nodes := evalJSONPath(t, data, "$.items[?@.id == 12345678901234567891]")
+	require.Equal(t, 1, len(nodes))
+	assert.Equal(t, "$['items'][1]", nodes[0].path)
+
+	nodes = evalJSONPath(t, data, "$.items[?@.id < 12345678901234567891]")
+	require.Equal(t, 1, len(nodes))
+	assert.Equal(t, "$['items'][0]", nodes[0].path)
+}
+
+func TestJSONPath_Regexp(t *testing.T) {
+	data := loadJSONPathData(t, `["ab", "a\rb", "a\nb", "xaby"]`)
+
+	cases := []struct {
+		expr   string
+		values []interface{}
+	}{
+		{`match(@, "a.b")`, nil},
+		{`match(@, "a.")`, []interface{}{"ab"}},
+		{`search(@, "a.")`, []interface{}{"ab", "xaby"}},
+		{`search(@, "a[^x]b")`, []interface{}{"a\rb", "a\nb"}},
+		{`search(@, "a[.]")`, nil},
+		{`match(@, "[")`, nil},
+		{`match(@, 1)`, nil},
+		{`match(1, "1")`, nil},
+		{`!search(@, "y$")`, []interface{}{"ab", "a\rb", "a\nb"}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.expr, func(t *testing.T) {
+			var values []interface{}
+			for _, node := range evalJSONPath(t, data, "$[?"+tc.expr+"]") {
+				values = append(values, node.value)
+			}
+			assert.Equal(t, tc.values, values)
+		})
+	}
+}
+
+func TestJSONPath_Invalid(t *testing.T) {
+	for _, path := range []string{
+		"",
+		"@",
+		" $",
+		"$ ",
+		"$.",
+		"$..",
+		"$.1a",
+		"$[",
+		"$[]",
+		"$[1",
+		"$[01]",
+		"$[-0]",
+		"$[9007199254740992]",
+		"$[1.0]",
+		"$['a'",
+		`$["\x"]`,
+		`$['\"']`,
+		`$["\ud800"]`,
+		"$[\"\x01\"]",
+		"$[a]",
+		"$[?]",
+		"$[?@.a ==]",
+		"$[?1]",
+		"$[?1 == 1 ==]",
+		"$[?@.* == 1]",
+		"$[?@..a == 1]",
+		"$[?@[0,1] == 1]",
+		"$[?(@.a]",
+		"$[?length(@.a)]",
+		"$[?count(@.a) ]",
+		"$[?count(1) == 1]",
+		"$[?match(@.a) ]",
+		"$[?match(@.a, 'a', 'b')]",
+		"$[?length(@.*) == 1]",
+		"$[?unknown(@)]",
+		"$[?match(@.a, 'a') == true]",
+		"$[?@.a == tru]",
+		"$[?@.a == 01]",
+		"$[?@.a == 1.]",
+		"$[?@.a == 1e]",
+	} {
+		t.Run(path, func(t *testing.T) {
+			_, err := parseJSONPath(path)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestJSONPath_Whitespace(t *testing.T) {
+	data := loadJSONPathData(t, `{"a": [{"b": 1}, {"b": 2}]}`)
+
+	for _, path := range []string{
+		"$.a[?@.b==2]",
+		"$ .a [ ?@.b == 2 ]",
+		"$.a[?\n@.b\t==\r2\n]",
+		"$.a[?(@.b == 2)]",
+		"$.a[? ( @.b == 2 ) ]",
+		"$.a[?!(@.b == 1)]",
+		"$.a[?@.b == 2 && true == true]",
+		"$.a[?count( @.b ) == 1 && @.b > 1]",
+	} {
+		t.Run(path, func(t *testing.T) {
+			nodes := evalJSONPath(t, data, path)
+			require.Equal(t, 1, len(nodes))
+			assert.Equal(t, "$['a'][1]", nodes[0].path)
+		})
+	}
+}
@@ -0,0 +1,1216 @@
+package httpexpect
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"net/url"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Meta-schema URIs of JSON Schema drafts supported by built-in validator.
+// Older drafts are handled by gojsonschema.
+const (
+	jsonSchemaDraft201909 = "https://json-schema.org/draft/2019-09/schema"
+	jsonSchemaDraft202012 = "https://json-schema.org/draft/2020-12/schema"
+)
+
+// Base URI of schemas without "$id" passed directly to Schema methods.
+const jsonSchemaDefaultURI = "httpexpect:///schema.json"
+
+// Check if schema declares draft 2019-09 or 2020-12.
+func isModernJSONSchema(schema interface{}) bool {
+	obj, ok := schema.(map[string]interface{})
+	if !ok {
+		return false
+	}
+
+	draft, _ := obj["$schema"].(string)
+	draft = strings.TrimSuffix(draft, "#")
+
+	return draft == jsonSchemaDraft201909 || draft == jsonSchemaDraft202012
+}
+
+// Location of schema node, with base URI used to resolve references
+// inside of it.
+type jsonSchemaLoc struct {
+	node interface{}
+	base string
+}
+
+// Index of schema resources, built from one or more schema documents.
+type jsonSchemaIndex struct {
+	resources map[string]jsonSchemaLoc // URI => resource
+	anchors   map[string]jsonSchemaLoc // URI#name => $anchor or $dynamicAnchor
+	dynamic   map[string]jsonSchemaLoc // URI#name => $dynamicAnchor
+	recursive map[string]bool          // URI => resource has $recursiveAnchor
+}
+
+func newJSONSchemaIndex() *jsonSchemaIndex {
+	return &jsonSchemaIndex{
+		resources: map[string]jsonSchemaLoc{},
+		anchors:   map[string]jsonSchemaLoc{},
+		dynamic:   map[string]jsonSchemaLoc{},
+		recursive: map[string]bool{},
+	}
+}
+
+func (ix *jsonSchemaIndex) clone() *jsonSchemaIndex {
+	ret := newJSONSchemaIndex()
+	for k, v := range ix.resources {
+		ret.resources[k] = v
+	}
+	for k, v := range ix.anchors {
+		ret.anchors[k] = v
+	}
+	for k, v := range ix.dynamic {
+		ret.dynamic[k] = v
+	}
+	for k, v := range ix.recursive {
+		ret.recursive[k] = v
+	}
+	return ret
+}
+
+// Register schema document with given URI, and all resources and anchors
+// defined inside of it.
+func (ix *jsonSchemaIndex) add(uri string, doc interface{}) error {
+	if _, ok := doc.(bool); !ok {
+		if _, ok := doc.(map[string]interface{}); !ok {
+			return errors.New("schema should be an object or boolean")
+		}
+	}
+
+	if err := ix.walk(doc, uri); err != nil {
+		return err
+	}
+
+	// root "$id", if present, overrides retrieval URI as base URI
+	base := uri
+	if obj, ok := doc.(map[string]interface{}); ok {
+		if id, ok := obj["$id"].(string); ok {
+			idURI, _ := resolveJSONSchemaURI(uri, id)
+			base = stripJSONSchemaFragment(idURI)
+		}
+	}
+
+	ix.resources[uri] = jsonSchemaLoc{node: doc, base: base}
+
+	return nil
+}
+
+func (ix *jsonSchemaIndex) walk(node interface{}, base string) error {
+	obj, ok := node.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	if id, ok := obj["$id"].(string); ok {
+		uri, err := resolveJSONSchemaURI(base, id)
+		if err != nil {
+			return err
+		}
+		base = stripJSONSchemaFragment(uri)
+		ix.resources[base] = jsonSchemaLoc{node: node, base: base}
+	}
+
+	if anchor, ok := obj["$anchor"].(string); ok {
+		ix.anchors[base+"#"+anchor] = jsonSchemaLoc{node: node, base: base}
+	}
+
+	if anchor, ok := obj["$dynamicAnchor"].(string); ok {
+		ix.anchors[base+"#"+anchor] = jsonSchemaLoc{node: node, base: base}
+		ix.dynamic[base+"#"+anchor] = jsonSchemaLoc{node: node, base: base}
+	}
+
+	if recursive, _ := obj["$recursiveAnchor"].(bool); recursive {
+		ix.recursive[base] = true
+	}
+
+	for _, child := range jsonSchemaChildren(obj) {
+		if err := ix.walk(child, base); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Find schema node referenced by URI, relative to given base URI.
+func (ix *jsonSchemaIndex) resolve(base, ref string) (jsonSchemaLoc, error) {
+	uri, err := resolveJSONSchemaURI(base, ref)
+	if err != nil {
+		return jsonSchemaLoc{}, err
+	}
+
+	docURI := stripJSONSchemaFragment(uri)
+
+	fragment := ""
+	if idx := strings.IndexByte(uri, '#'); idx >= 0 {
+		fragment, err = url.PathUnescape(uri[idx+1:])
+		if err != nil {
+			return jsonSchemaLoc{}, fmt.Errorf("invalid reference %q: %s", ref, err)
+		}
+	}
+
+	loc, ok := ix.resources[docURI]
+	if !ok {
+		return jsonSchemaLoc{}, fmt.Errorf("can't resolve reference %q", ref)
+	}
+
+	switch {
+	case fragment == "":
+		return loc, nil
+
+	case strings.HasPrefix(fragment, "/"):
+		return ix.resolvePointer(loc, fragment, ref)
+
+	default:
+		loc, ok := ix.anchors[docURI+"#"+fragment]
+		if !ok {
+			return jsonSchemaLoc{}, fmt.Errorf("can't resolve reference %q", ref)
+		}
+		return loc, nil
+	}
+}
+
+func (ix *jsonSchemaIndex) resolvePointer(
+	loc jsonSchemaLoc, pointer, ref string,
+) (jsonSchemaLoc, error) {
+	node, base := loc.node, loc.base
+
+	for _, token := range strings.Split(pointer[1:], "/") {
+		token = strings.Replace(token, "~1", "/", -1)
+		token = strings.Replace(token, "~0", "~", -1)
+
+		switch n := node.(type) {
+		case map[string]interface{}:
+			child, ok := n[token]
+			if !ok {
+				return jsonSchemaLoc{}, fmt.Errorf("can't resolve reference %q", ref)
+			}
+			node = child
+
+		case []interface{}:
+			idx, err := strconv.Atoi(token)
+			if err != nil || idx < 0 || idx >= len(n) {
+				return jsonSchemaLoc{}, fmt.Errorf("can't resolve reference %q", ref)
+			}
+			node = n[idx]
+
+		default:
+			return jsonSchemaLoc{}, fmt.Errorf("can't resolve reference %q", ref)
+		}
+
+		if obj, ok := node.(map[string]interface{}); ok {
+			if id, ok := obj["$id"].(string); ok {
+				if uri, err := resolveJSONSchemaURI(base, id); err == nil {
+					base = stripJSONSchemaFragment(uri)
+				}
+			}
+		}
+	}
+
+	return jsonSchemaLoc{node: node, base: base}, nil
+}
+
+// Subschemas of schema object, in keywords that may contain schemas.
+func jsonSchemaChildren(obj map[string]interface{}) []interface{} {
+	var children []interface{}
+
+	for _, kw := range []string{
+		"additionalItems", "additionalProperties", "unevaluatedItems",
+		"unevaluatedProperties", "items", "contains", "propertyNames",
+		"not", "if", "then", "else",
+	} {
+		if child, ok := obj[kw]; ok {
+			children = append(children, child)
+		}
+	}
+
+	for _, kw := range []string{"allOf", "anyOf", "oneOf", "prefixItems", "items"} {
+		if arr, ok := obj[kw].([]interface{}); ok {
+			children = append(children, arr...)
+		}
+	}
+
+	for _, kw := range []string{
+		"properties", "patternProperties", "dependentSchemas",
+		"$defs", "definitions", "dependencies",
+	} {
+		if m, ok := obj[kw].(map[string]interface{}); ok {
+			keys := make([]string, 0, len(m))
+			for k := range m {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			for _, k := range keys {
+				children = append(children, m[k])
+			}
+		}
+	}
+
+	return children
+}
+
+func resolveJSONSchemaURI(base, ref string) (string, error) {
+	baseURL, err := url.Parse(base)
+	if err != nil {
+		return "", fmt.Errorf("invalid base URI %q: %s", base, err)
+	}
+
+	refURL, err := url.Parse(ref)
+	if err != nil {
+		return "", fmt.Errorf("invalid reference %q: %s", ref, err)
+	}
+
+	return baseURL.ResolveReference(refURL).String(), nil
+}
+
+func stripJSONSchemaFragment(uri string) string {
+	if idx := strings.IndexByte(uri, '#'); idx >= 0 {
+		return uri[:idx]
+	}
+	return uri
+}
+
+// Single validation error, with location in instance and in schema.
+type jsonSchemaError struct {
+	path     []interface{}
+	keyword  string
+	location string
+	message  string
+}
+
+func (e jsonSchemaError) Error() string {
+	return fmt.Sprintf("at %s: %s: %s (%s)",
+		formatEqualPath(e.path), e.keyword, e.message, e.location)
+}
+
+// Result of validation of a value against a schema, with annotations
+// used by "unevaluatedItems" and "unevaluatedProperties".
+type jsonSchemaResult struct {
+	errors []jsonSchemaError
+	items  map[int]bool
+	props  map[string]bool
+}
+
+func (r *jsonSchemaResult) valid() bool {
+	return len(r.errors) == 0
+}
+
+func (r *jsonSchemaResult) evalItem(n int) {
+	if r.items == nil {
+		r.items = map[int]bool{}
+	}
+	r.items[n] = true
+}
+
+func (r *jsonSchemaResult) evalProp(k string) {
+	if r.props == nil {
+		r.props = map[string]bool{}
+	}
+	r.props[k] = true
+}
+
+// Add errors of child result; add annotations only if child is valid.
+func (r *jsonSchemaResult) merge(child *jsonSchemaResult) {
+	r.errors = append(r.errors, child.errors...)
+	if child.valid() {
+		r.mergeAnnotations(child)
+	}
+}
+
+func (r *jsonSchemaResult) mergeAnnotations(child *jsonSchemaResult) {
+	for n := range child.items {
+		r.evalItem(n)
+	}
+	for k := range child.props {
+		r.evalProp(k)
+	}
+}
+
+// Evaluation scope: base URI, dynamic scope, and current locations.
+type jsonSchemaScope struct {
+	base     string
+	dynamic  []string
+	location string
+	path     []interface{}
+	depth    int
+	// base already includes "$id" of the schema being entered,
+	// because it was located by reference
+	located bool
+}
+
+func (s jsonSchemaScope) keyword(tokens ...string) jsonSchemaScope {
+	for _, t := range tokens {
+		s.location += "/" + escapeJSONPointer(t)
+	}
+	return s
+}
+
+func (s jsonSchemaScope) elem(elem interface{}) jsonSchemaScope {
+	s.path = appendEqualPath(s.path, elem)
+	return s
+}
+
+func (s jsonSchemaScope) locate(base string) jsonSchemaScope {
+	s = s.enter(base)
+	s.located = true
+	return s
+}
+
+func (s jsonSchemaScope) enter(base string) jsonSchemaScope {
+	if base != s.base {
+		s.base = base
+		dynamic := make([]string, len(s.dynamic), len(s.dynamic)+1)
+		copy(dynamic, s.dynamic)
+		s.dynamic = append(dynamic, base)
+	}
+	return s
+}
+
+const jsonSchemaMaxDepth = 500
+
+// Validator of JSON Schema drafts 2019-09 and 2020-12.
+//
+// Both value and schema should be in canonical form. All applicator,
+// validation, and unevaluated* keywords are supported, as well as
+// references to anchors, dynamic anchors, and other registered schemas.
+// "format" is treated as annotation, as required by default by the spec.
+type jsonSchemaValidator struct {
+	index   *jsonSchemaIndex
+	regexps map[string]*regexp.Regexp
+	invalid error
+}
+
+func newJSONSchemaValidator(index *jsonSchemaIndex) *jsonSchemaValidator {
+	return &jsonSchemaValidator{
+		index:   index,
+		regexps: map[string]*regexp.Regexp{},
+	}
+}
+
+// Validate value against schema located by loc. Returns validation
+// errors, or error if schema is invalid.
+func (v *jsonSchemaValidator) run(
+	loc jsonSchemaLoc, value interface{},
+) ([]jsonSchemaError, error) {
+	scope := jsonSchemaScope{
+		base:     loc.base,
+		dynamic:  []string{loc.base},
+		location: "#",
+		located:  true,
+	}
+
+	res := v.validate(loc.node, value, scope)
+
+	if v.invalid != nil {
+		return nil, v.invalid
+	}
+
+	return res.errors, nil
+}
+
+func (v *jsonSchemaValidator) invalidf(
+	scope jsonSchemaScope, format string, args ...interface{},
+) {
+	if v.invalid == nil {
+		v.invalid = fmt.Errorf("%s: %s", scope.location, fmt.Sprintf(format, args...))
+	}
+}
+
+func (v *jsonSchemaValidator) fail(
+	res *jsonSchemaResult, scope jsonSchemaScope, keyword string,
+	format string, args ...interface{},
+) {
+	res.errors = append(res.errors, jsonSchemaError{
+		path:     scope.path,
+		keyword:  keyword,
+		location: scope.keyword(keyword).location,
+		message:  fmt.Sprintf(format, args...),
+	})
+}
+
+func (v *jsonSchemaValidator) validate(
+	node, value interface{}, scope jsonSchemaScope,
+) *jsonSchemaResult {
+	res := &jsonSchemaResult{}
+
+	if v.invalid != nil {
+		return res
+	}
+
+	scope.depth++
+	if scope.depth > jsonSchemaMaxDepth {
+		v.invalidf(scope, "maximum reference depth exceeded")
+		return res
+	}
+
+	switch s := node.(type) {
+	case bool:
+		if !s {
+			res.errors = append(res.errors, jsonSchemaError{
+				path:     scope.path,
+				keyword:  "false",
+				location: scope.location,
+				message:  "no value is allowed",
+			})
+		}
+		return res
+
+	case map[string]interface{}:
+		located := scope.located
+		scope.located = false
+
+		if id, ok := s["$id"].(string); ok && !located {
+			uri, err := resolveJSONSchemaURI(scope.base, id)
+			if err != nil {
+				v.invalidf(scope, "%s", err)
+				return res
+			}
+			scope = scope.enter(stripJSONSchemaFragment(uri))
+		}
+
+		v.validateRefs(res, s, value, scope)
+		v.validateGeneric(res, s, value, scope)
+
+		switch val := value.(type) {
+		case string:
+			v.validateString(res, s, val, scope)
+
+		case []interface{}:
+			v.validateArray(res, s, val, scope)
+
+		case map[string]interface{}:
+			v.validateObject(res, s, val, scope)
+
+		default:
+			if isNumber(value) {
+				v.validateNumber(res, s, value, scope)
+			}
+		}
+
+		v.validateApplicators(res, s, value, scope)
+
+		switch val := value.(type) {
+		case []interface{}:
+			v.validateUnevaluatedItems(res, s, val, scope)
+
+		case map[string]interface{}:
+			v.validateUnevaluatedProps(res, s, val, scope)
+		}
+
+		return res
+
+	default:
+		v.invalidf(scope, "schema should be an object or boolean")
+		return res
+	}
+}
+
+func (v *jsonSchemaValidator) validateRefs(
+	res *jsonSchemaResult, s map[string]interface{}, value interface{},
+	scope jsonSchemaScope,
+) {
+	if ref, ok := s["$ref"]; ok {
+		refStr, ok := ref.(string)
+		if !ok {
+			v.invalidf(scope, `"$ref" should be a string`)
+			return
+		}
+
+		loc, err := v.index.resolve(scope.base, refStr)
+		if err != nil {
+			v.invalidf(scope, "%s", err)
+			return
+		}
+
+		res.merge(v.validate(loc.node, value, scope.keyword("$ref").locate(loc.base)))
+	}
+
+	if ref, ok := s["$dynamicRef"]; ok {
+		refStr, ok := ref.(string)
+		if !ok {
+			v.invalidf(scope, `"$dynamicRef" should be a string`)
+			return
+		}
+
+		loc, err := v.index.resolve(scope.base, refStr)
+		if err != nil {
+			v.invalidf(scope, "%s", err)
+			return
+		}
+
+		if idx := strings.IndexByte(refStr, '#'); idx >= 0 {
+			name := refStr[idx+1:]
+			if obj, ok := loc.node.(map[string]interface{}); ok &&
+				obj["$dynamicAnchor"] == name {
+				// use outermost resource in dynamic scope with such anchor
+				for _, uri := range scope.dynamic {
+					if dloc, ok := v.index.dynamic[uri+"#"+name]; ok {
+						loc = dloc
+						break
+					}
+				}
+			}
+		}
+
+		res.merge(v.validate(loc.node, value,
+			scope.keyword("$dynamicRef").locate(loc.base)))
+	}
+
+	if ref, ok := s["$recursiveRef"]; ok {
+		refStr, ok := ref.(string)
+		if !ok {
+			v.invalidf(scope, `"$recursiveRef" should be a string`)
+			return
+		}
+
+		loc, err := v.index.resolve(scope.base, refStr)
+		if err != nil {
+			v.invalidf(scope, "%s", err)
+			return
+		}
+
+		if obj, ok := loc.node.(map[string]interface{}); ok {
+			if recursive, _ := obj["$recursiveAnchor"].(bool); recursive {
+				// use outermost resource in dynamic scope with recursive anchor
+				for _, uri := range scope.dynamic {
+					if v.index.recursive[uri] {
+						loc = v.index.resources[uri]
+						break
+					}
+				}
+			}
+		}
+
+		res.merge(v.validate(loc.node, value,
+			scope.keyword("$recursiveRef").locate(loc.base)))
+	}
+}
+
+func (v *jsonSchemaValidator) validateGeneric(
+	res *jsonSchemaResult, s map[string]interface{}, value interface{},
+	scope jsonSchemaScope,
+) {
+	if typ, ok := s["type"]; ok {
+		var types []string
+
+		switch t := typ.(type) {
+		case string:
+			types = []string{t}
+		case []interface{}:
+			for _, elem := range t {
+				str, ok := elem.(string)
+				if !ok {
+					v.invalidf(scope, `"type" should be a string or array of strings`)
+					return
+				}
+				types = append(types, str)
+			}
+		default:
+			v.invalidf(scope, `"type" should be a string or array of strings`)
+			return
+		}
+
+		matched := false
+		for _, t := range types {
+			if jsonSchemaHasType(value, t) {
+				matched = true
+				break
+			}
+		}
+
+		if !matched {
+			v.fail(res, scope, "type", "expected %s, got %s",
+				strings.Join(types, " or "), jsonSchemaTypeOf(value))
+		}
+	}
+
+	if enum, ok := s["enum"]; ok {
+		values, ok := enum.([]interface{})
+		if !ok {
+			v.invalidf(scope, `"enum" should be an array`)
+			return
+		}
+
+		matched := false
+		for _, e := range values {
+			if reflect.DeepEqual(e, value) {
+				matched = true
+				break
+			}
+		}
+
+		if !matched {
+			v.fail(res, scope, "enum", "value %s is not one of allowed values",
+				formatEqualValue(value))
+		}
+	}
+
+	if c, ok := s["const"]; ok {
+		if !reflect.DeepEqual(c, value) {
+			v.fail(res, scope, "const", "expected %s, got %s",
+				formatEqualValue(c), formatEqualValue(value))
+		}
+	}
+}
+
+func (v *jsonSchemaValidator) validateNumber(
+	res *jsonSchemaResult, s map[string]interface{}, value interface{},
+	scope jsonSchemaScope,
+) {
+	num, _ := canonRat(value)
+
+	limit := func(kw string) *big.Rat {
+		lim, ok := s[kw]
+		if !ok {
+			return nil
+		}
+		r, ok := canonRat(lim)
+		if !ok || !isNumber(lim) {
+			v.invalidf(scope, "%q should be a number", kw)
+			return nil
+		}
+		return r
+	}
+
+	if m := limit("multipleOf"); m != nil {
+		if m.Sign() <= 0 {
+			v.invalidf(scope, `"multipleOf" should be greater than 0`)
+			return
+		}
+		if !new(big.Rat).Quo(num, m).IsInt() {
+			v.fail(res, scope, "multipleOf", "%v is not a multiple of %v",
+				value, s["multipleOf"])
+		}
+	}
+
+	if m := limit("maximum"); m != nil && num.Cmp(m) > 0 {
+		v.fail(res, scope, "maximum", "%v is greater than %v", value, s["maximum"])
+	}
+
+	if m := limit("exclusiveMaximum"); m != nil && num.Cmp(m) >= 0 {
+		v.fail(res, scope, "exclusiveMaximum", "%v is not less than %v",
+			value, s["exclusiveMaximum"])
+	}
+
+	if m := limit("minimum"); m != nil && num.Cmp(m) < 0 {
+		v.fail(res, scope, "minimum", "%v is less than %v", value, s["minimum"])
+	}
+
+	if m := limit("exclusiveMinimum"); m != nil && num.Cmp(m) <= 0 {
+		v.fail(res, scope, "exclusiveMinimum", "%v is not greater than %v",
+			value, s["exclusiveMinimum"])
+	}
+}
+
+func (v *jsonSchemaValidator) validateString(
+	res *jsonSchemaResult, s map[string]interface{}, value string,
+	scope jsonSchemaScope,
+) {
+	length := utf8.RuneCountInString(value)
+
+	if m, ok := v.count(s, "maxLength", scope); ok && length > m {
+		v.fail(res, scope, "maxLength", "length %d is greater than %d", length, m)
+	}
+
+	if m, ok := v.count(s, "minLength", scope); ok && length < m {
+		v.fail(res, scope, "minLength", "length %d is less than %d", length, m)
+	}
+
+	if pattern, ok := s["pattern"]; ok {
+		re := v.regexp(pattern, "pattern", scope)
+		if re != nil && !re.MatchString(value) {
+			v.fail(res, scope, "pattern", "%q does not match pattern %q",
+				value, pattern)
+		}
+	}
+}
+
+func (v *jsonSchemaValidator) validateArray(
+	res *jsonSchemaResult, s map[string]interface{}, value []interface{},
+	scope jsonSchemaScope,
+) {
+	if m, ok := v.count(s, "maxItems", scope); ok && len(value) > m {
+		v.fail(res, scope, "maxItems", "array length %d is greater than %d",
+			len(value), m)
+	}
+
+	if m, ok := v.count(s, "minItems", scope); ok && len(value) < m {
+		v.fail(res, scope, "minItems", "array length %d is less than %d",
+			len(value), m)
+	}
+
+	if unique, _ := s["uniqueItems"].(bool); unique {
+	outer:
+		for i := 0; i < len(value); i++ {
+			for j := i + 1; j < len(value); j++ {
+				if reflect.DeepEqual(value[i], value[j]) {
+					v.fail(res, scope, "uniqueItems",
+						"items at indices %d and %d are equal", i, j)
+					break outer
+				}
+			}
+		}
+	}
+}
+
+func (v *jsonSchemaValidator) validateObject(
+	res *jsonSchemaResult, s map[string]interface{}, value map[string]interface{},
+	scope jsonSchemaScope,
+) {
+	if m, ok := v.count(s, "maxProperties", scope); ok && len(value) > m {
+		v.fail(res, scope, "maxProperties",
+			"number of properties %d is greater than %d", len(value), m)
+	}
+
+	if m, ok := v.count(s, "minProperties", scope); ok && len(value) < m {
+		v.fail(res, scope, "minProperties",
+			"number of properties %d is less than %d", len(value), m)
+	}
+
+	if required, ok := s["required"]; ok {
+		names, ok := v.strings(required, "required", scope)
+		if ok {
+			for _, name := range names {
+				if _, ok := value[name]; !ok {
+					v.fail(res, scope, "required", "missing property %q", name)
+				}
+			}
+		}
+	}
+
+	if deps, ok := s["dependentRequired"].(map[string]interface{}); ok {
+		for _, k := range sortedKeys(deps) {
+			if _, ok := value[k]; !ok {
+				continue
+			}
+			names, ok := v.strings(deps[k], "dependentRequired", scope)
+			if !ok {
+				return
+			}
+			for _, name := range names {
+				if _, ok := value[name]; !ok {
+					v.fail(res, scope, "dependentRequired",
+						"property %q requires property %q", k, name)
+				}
+			}
+		}
+	}
+}
+
+func (v *jsonSchemaValidator) validateApplicators(
+	res *jsonSchemaResult, s map[string]interface{}, value interface{},
+	scope jsonSchemaScope,
+) {
+	for _, kw := range []string{"allOf", "anyOf", "oneOf"} {
+		subs, ok := s[kw]
+		if !ok {
+			continue
+		}
+
+		arr, ok := subs.([]interface{})
+		if !ok || len(arr) == 0 {
+			v.invalidf(scope, "%q should be a non-empty array", kw)
+			return
+		}
+
+		matched := 0
+		results := make([]*jsonSchemaResult, len(arr))
+
+		for n, sub := range arr {
+			results[n] = v.validate(sub, value, scope.keyword(kw, strconv.Itoa(n)))
+			if results[n].valid() {
+				matched++
+			}
+		}
+
+		switch kw {
+		case "allOf":
+			for _, r := range results {
+				res.merge(r)
+			}
+
+		case "anyOf":
+			if matched == 0 {
+				v.fail(res, scope, kw, "value does not match any schema")
+			}
+			for _, r := range results {
+				if r.valid() {
+					res.mergeAnnotations(r)
+				}
+			}
+
+		case "oneOf":
+			if matched != 1 {
+				v.fail(res, scope, kw,
+					"value matches %d schemas, expected exactly one", matched)
+			} else {
+				for _, r := range results {
+					if r.valid() {
+						res.mergeAnnotations(r)
+					}
+				}
+			}
+		}
+	}
+
+	if not, ok := s["not"]; ok {
+		if v.validate(not, value, scope.keyword("not")).valid() {
+			v.fail(res, scope, "not", "value should not match schema")
+		}
+	}
+
+	if cond, ok := s["if"]; ok {
+		condRes := v.validate(cond, value, scope.keyword("if"))
+
+		if condRes.valid() {
+			res.mergeAnnotations(condRes)
+			if then, ok := s["then"]; ok {
+				res.merge(v.validate(then, value, scope.keyword("then")))
+			}
+		} else if els, ok := s["else"]; ok {
+			res.merge(v.validate(els, value, scope.keyword("else")))
+		}
+	}
+
+	switch val := value.(type) {
+	case []interface{}:
+		v.applyItems(res, s, val, scope)
+
+	case map[string]interface{}:
+		v.applyProperties(res, s, val, scope)
+	}
+}
+
+func (v *jsonSchemaValidator) applyItems(
+	res *jsonSchemaResult, s map[string]interface{}, value []interface{},
+	scope jsonSchemaScope,
+) {
+	prefixKw := "prefixItems"
+	restKw := "items"
+
+	prefix, hasPrefix := s["prefixItems"]
+	if !hasPrefix {
+		// draft 2019-09: "items" array and "additionalItems"
+		if arr, ok := s["items"].([]interface{}); ok {
+			prefix, hasPrefix = arr, true
+			prefixKw = "items"
+			restKw = "additionalItems"
+		}
+	}
+
+	start := 0
+
+	if hasPrefix {
+		arr, ok := prefix.([]interface{})
+		if !ok {
+			v.invalidf(scope, "%q should be an array", prefixKw)
+			return
+		}
+
+		for n := 0; n < len(arr) && n < len(value); n++ {
+			res.merge(v.validate(arr[n], value[n],
+				scope.keyword(prefixKw, strconv.Itoa(n)).elem(n)))
+			res.evalItem(n)
+		}
+
+		start = len(arr)
+	}
+
+	if rest, ok := s[restKw]; ok {
+		for n := start; n < len(value); n++ {
+			res.merge(v.validate(rest, value[n], scope.keyword(restKw).elem(n)))
+			res.evalItem(n)
+		}
+	}
+
+	if contains, ok := s["contains"]; ok {
+		minContains, hasMin := v.count(s, "minContains", scope)
+		if !hasMin {
+			minContains = 1
+		}
+		maxContains, hasMax := v.count(s, "maxContains", scope)
+
+		matched := 0
+		for n, elem := range value {
+			if v.validate(contains, elem, scope.keyword("contains").elem(n)).valid() {
+				matched++
+				res.evalItem(n)
+			}
+		}
+
+		if matched < minContains {
+			if hasMin {
+				v.fail(res, scope, "minContains",
+					"array contains %d matching items, expected at least %d",
+					matched, minContains)
+			} else {
+				v.fail(res, scope, "contains", "array contains no matching items")
+			}
+		}
+
+		if hasMax && matched > maxContains {
+			v.fail(res, scope, "maxContains",
+				"array contains %d matching items, expected at most %d",
+				matched, maxContains)
+		}
+	}
+}
+
+func (v *jsonSchemaValidator) applyProperties(
+	res *jsonSchemaResult, s map[string]interface{}, value map[string]interface{},
+	scope jsonSchemaScope,
+) {
+	keys := sortedKeys(value)
+	matched := map[string]bool{}
+
+	if props, ok := s["properties"]; ok {
+		m, ok := props.(map[string]interface{})
+		if !ok {
+			v.invalidf(scope, `"properties" should be an object`)
+			return
+		}
+
+		for _, k := range keys {
+			if sub, ok := m[k]; ok {
+				res.merge(v.validate(sub, value[k],
+					scope.keyword("properties", k).elem(k)))
+				res.evalProp(k)
+				matched[k] = true
+			}
+		}
+	}
+
+	if patterns, ok := s["patternProperties"]; ok {
+		m, ok := patterns.(map[string]interface{})
+		if !ok {
+			v.invalidf(scope, `"patternProperties" should be an object`)
+			return
+		}
+
+		for _, pattern := range sortedKeys(m) {
+			re := v.regexp(pattern, "patternProperties", scope)
+			if re == nil {
+				return
+			}
+			for _, k := range keys {
+				if re.MatchString(k) {
+					res.merge(v.validate(m[pattern], value[k],
+						scope.keyword("patternProperties", pattern).elem(k)))
+					res.evalProp(k)
+					matched[k] = true
+				}
+			}
+		}
+	}
+
+	if additional, ok := s["additionalProperties"]; ok {
+		for _, k := range keys {
+			if !matched[k] {
+				res.merge(v.validate(additional, value[k],
+					scope.keyword("additionalProperties").elem(k)))
+				res.evalProp(k)
+			}
+		}
+	}
+
+	if names, ok := s["propertyNames"]; ok {
+		for _, k := range keys {
+			res.merge(v.validate(names, k, scope.keyword("propertyNames").elem(k)))
+		}
+	}
+
+	for _, kw := range []string{"dependentSchemas", "dependencies"} {
+		deps, ok := s[kw].(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		for _, k := range sortedKeys(deps) {
+			if _, ok := value[k]; !ok {
+				continue
+			}
+
+			// draft 7 "dependencies" may also list required properties
+			if names, ok := deps[k].([]interface{}); ok && kw == "dependencies" {
+				for _, name := range names {
+					if str, ok := name.(string); ok {
+						if _, ok := value[str]; !ok {
+							v.fail(res, scope, kw,
+								"property %q requires property %q", k, str)
+						}
+					}
+				}
+				continue
+			}
+
+			res.merge(v.validate(deps[k], value, scope.keyword(kw, k)))
+		}
+	}
+}
+
+func (v *jsonSchemaValidator) validateUnevaluatedItems(
+	res *jsonSchemaResult, s map[string]interface{}, value []interface{},
+	scope jsonSchemaScope,
+) {
+	unevaluated, ok := s["unevaluatedItems"]
+	if !ok {
+		return
+	}
+
+	for n := range value {
+		if !res.items[n] {
+			res.merge(v.validate(unevaluated, value[n],
+				scope.keyword("unevaluatedItems").elem(n)))
+			res.evalItem(n)
+		}
+	}
+}
+
+func (v *jsonSchemaValidator) validateUnevaluatedProps(
+	res *jsonSchemaResult, s map[string]interface{}, value map[string]interface{},
+	scope jsonSchemaScope,
+) {
+	unevaluated, ok := s["unevaluatedProperties"]
+	if !ok {
+		return
+	}
+
+	for _, k := range sortedKeys(value) {
+		if !res.props[k] {
+			res.merge(v.validate(unevaluated, value[k],
+				scope.keyword("unevaluatedProperties").elem(k)))
+			res.evalProp(k)
+		}
+	}
+}
+
+// Get non-negative integer keyword.
+func (v *jsonSchemaValidator) count(
+	s map[string]interface{}, kw string, scope jsonSchemaScope,
+) (int, bool) {
+	val, ok := s[kw]
+	if !ok {
+		return 0, false
+	}
+
+	r, ok := canonRat(val)
+	if !ok || !isNumber(val) || !r.IsInt() || r.Sign() < 0 || !r.Num().IsInt64() {
+		v.invalidf(scope, "%q should be a non-negative integer", kw)
+		return 0, false
+	}
+
+	return int(r.Num().Int64()), true
+}
+
+func (v *jsonSchemaValidator) strings(
+	val interface{}, kw string, scope jsonSchemaScope,
+) ([]string, bool) {
+	arr, ok := val.([]interface{})
+	if !ok {
+		v.invalidf(scope, "%q should be an array of strings", kw)
+		return nil, false
+	}
+
+	ret := make([]string, 0, len(arr))
+	for _, elem := range arr {
+		str, ok := elem.(string)
+		if !ok {
+			v.invalidf(scope, "%q should be an array of strings", kw)
+			return nil, false
+		}
+		ret = append(ret, str)
+	}
+
+	return ret, true
+}
+
+func (v *jsonSchemaValidator) regexp(
+	pattern interface{}, kw string, scope jsonSchemaScope,
+) *regexp.Regexp {
+	str, ok := pattern.(string)
+	if !ok {
+		v.invalidf(scope, "%q should be a string", kw)
+		return nil
+	}
+
+	if re, ok := v.regexps[str]; ok {
+		return re
+	}
+
+	re, err := regexp.Compile(str)
+	if err != nil {
+		v.invalidf(scope, "invalid %q regexp %q: %s", kw, str, err)
+		return nil
+	}
+
+	v.regexps[str] = re
+	return re
+}
+
+func jsonSchemaHasType(value interface{}, typ string) bool {
+	switch typ {
+	case "null":
+		return value == nil
+	case "boolean":
+		_, ok := value.(bool)
+		return ok
+	case "object":
+		_, ok := value.(map[string]interface{})
+		return ok
+	case "array":
+		_, ok := value.([]interface{})
+		return ok
+	case "string":
+		_, ok := value.(string)
+		return ok
+	case "number":
+		return isNumber(value)
+	case "integer":
+		if !isNumber(value) {
+			return false
+		}
+		r, ok := canonRat(value)
+		return ok && r.IsInt()
+	}
+	return false
+}
+
+func jsonSchemaTypeOf(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case map[string]interface{}:
+		return "object"
+	case []interface{}:
+		return "array"
+	case string:
+		return "string"
+	}
+	if jsonSchemaHasType(value, "integer") {
+		return "integer"
+	}
+	if isNumber(value) {
+		return "number"
+	}
+	return fmt.Sprintf("%T", value)
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
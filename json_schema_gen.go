@@ -0,0 +1,324 @@
+package httpexpect
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+var (
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	timeType          = reflect.TypeOf(time.Time{})
+	jsonNumberType    = reflect.TypeOf(json.Number(""))
+)
+
+// "format" is not asserted by validator, so RFC 3339 is checked by pattern
+const jsonSchemaDateTimePattern = `^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}` +
+	`(\.\d+)?(Z|[+-]\d{2}:\d{2})$`
+
+// Generator of JSON Schema (draft 2020-12) from Go type.
+//
+// Generated schema describes JSON produced by encoding/json for given
+// type, and additionally takes into account "required" option of "json"
+// tag and "required" rule of "validate" tag:
+//   - every field without "omitempty" option is required, because
+//     encoding/json always emits it
+//   - pointers, slices, maps, and interfaces are nullable
+//   - objects generated from structs don't allow unknown fields
+//   - named structs are placed into "$defs", which allows recursive types
+type jsonSchemaGenerator struct {
+	defs  map[string]interface{}
+	names map[reflect.Type]string
+}
+
+func jsonSchemaFromType(typ reflect.Type) (interface{}, error) {
+	gen := &jsonSchemaGenerator{
+		defs:  map[string]interface{}{},
+		names: map[reflect.Type]string{},
+	}
+
+	schema, err := gen.schema(typ, false)
+	if err != nil {
+		return nil, err
+	}
+
+	root := map[string]interface{}{
+		"$schema": jsonSchemaDraft202012,
+	}
+
+	switch s := schema.(type) {
+	case bool:
+		// true is equivalent to empty schema
+
+	case map[string]interface{}:
+		for k, v := range s {
+			root[k] = v
+		}
+	}
+
+	if len(gen.defs) != 0 {
+		root["$defs"] = gen.defs
+	}
+
+	return canonJSONNumbers(root), nil
+}
+
+func (g *jsonSchemaGenerator) schema(
+	typ reflect.Type, quoted bool,
+) (interface{}, error) {
+	switch {
+	case typ == timeType:
+		return map[string]interface{}{
+			"type":    "string",
+			"format":  "date-time",
+			"pattern": jsonSchemaDateTimePattern,
+		}, nil
+
+	case typ == jsonNumberType:
+		return map[string]interface{}{"type": "number"}, nil
+
+	case typ.Kind() != reflect.Ptr &&
+		(typ.Implements(jsonMarshalerType) ||
+			reflect.PtrTo(typ).Implements(jsonMarshalerType)):
+		// custom encoding, anything is allowed
+		return true, nil
+
+	case typ.Kind() != reflect.Ptr &&
+		(typ.Implements(textMarshalerType) ||
+			reflect.PtrTo(typ).Implements(textMarshalerType)):
+		return map[string]interface{}{"type": "string"}, nil
+	}
+
+	if quoted {
+		switch typ.Kind() {
+		case reflect.Bool, reflect.String,
+			reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+			reflect.Uintptr, reflect.Float32, reflect.Float64:
+			return map[string]interface{}{"type": "string"}, nil
+		}
+	}
+
+	switch typ.Kind() {
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}, nil
+
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}, nil
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		bits := uint(typ.Bits())
+		return map[string]interface{}{
+			"type":    "integer",
+			"minimum": json.Number(strconv.FormatInt(-1<<(bits-1), 10)),
+			"maximum": json.Number(strconv.FormatInt(1<<(bits-1)-1, 10)),
+		}, nil
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Uintptr:
+		bits := uint(typ.Bits())
+		return map[string]interface{}{
+			"type":    "integer",
+			"minimum": json.Number("0"),
+			"maximum": json.Number(strconv.FormatUint(math.MaxUint64>>(64-bits), 10)),
+		}, nil
+
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}, nil
+
+	case reflect.Interface:
+		return true, nil
+
+	case reflect.Ptr:
+		schema, err := g.schema(typ.Elem(), quoted)
+		if err != nil {
+			return nil, err
+		}
+		return jsonSchemaNullable(schema), nil
+
+	case reflect.Slice:
+		if typ.Elem().Kind() == reflect.Uint8 &&
+			!reflect.PtrTo(typ.Elem()).Implements(jsonMarshalerType) &&
+			!reflect.PtrTo(typ.Elem()).Implements(textMarshalerType) {
+			// []byte is encoded as base64 string
+			return jsonSchemaNullable(map[string]interface{}{"type": "string"}), nil
+		}
+
+		items, err := g.schema(typ.Elem(), false)
+		if err != nil {
+			return nil, err
+		}
+		return jsonSchemaNullable(map[string]interface{}{
+			"type":  "array",
+			"items": items,
+		}), nil
+
+	case reflect.Array:
+		items, err := g.schema(typ.Elem(), false)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{
+			"type":     "array",
+			"items":    items,
+			"minItems": json.Number(strconv.Itoa(typ.Len())),
+			"maxItems": json.Number(strconv.Itoa(typ.Len())),
+		}, nil
+
+	case reflect.Map:
+		return g.mapSchema(typ)
+
+	case reflect.Struct:
+		return g.structSchema(typ)
+
+	default:
+		return nil, fmt.Errorf("unsupported type %s", typ)
+	}
+}
+
+func (g *jsonSchemaGenerator) mapSchema(typ reflect.Type) (interface{}, error) {
+	schema := map[string]interface{}{"type": "object"}
+
+	switch typ.Key().Kind() {
+	case reflect.String:
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		schema["propertyNames"] = map[string]interface{}{"pattern": "^-?[0-9]+$"}
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Uintptr:
+		schema["propertyNames"] = map[string]interface{}{"pattern": "^[0-9]+$"}
+
+	default:
+		if !reflect.PtrTo(typ.Key()).Implements(textMarshalerType) {
+			return nil, fmt.Errorf("unsupported map key type %s", typ.Key())
+		}
+	}
+
+	props, err := g.schema(typ.Elem(), false)
+	if err != nil {
+		return nil, err
+	}
+	schema["additionalProperties"] = props
+
+	return jsonSchemaNullable(schema), nil
+}
+
+func (g *jsonSchemaGenerator) structSchema(typ reflect.Type) (interface{}, error) {
+	if typ.Name() == "" {
+		return g.structBody(typ)
+	}
+
+	name, ok := g.names[typ]
+	if !ok {
+		name = g.defName(typ)
+		g.names[typ] = name
+
+		// register name before generating body, for recursive types
+		g.defs[name] = true
+
+		body, err := g.structBody(typ)
+		if err != nil {
+			return nil, err
+		}
+		g.defs[name] = body
+	}
+
+	return map[string]interface{}{
+		"$ref": "#/$defs/" + escapeJSONPointer(name),
+	}, nil
+}
+
+func (g *jsonSchemaGenerator) defName(typ reflect.Type) string {
+	name := typ.Name()
+
+	for n := 2; ; n++ {
+		if _, ok := g.defs[name]; !ok {
+			return name
+		}
+		name = typ.Name() + strconv.Itoa(n)
+	}
+}
+
+func (g *jsonSchemaGenerator) structBody(typ reflect.Type) (interface{}, error) {
+	props := map[string]interface{}{}
+	required := []interface{}{}
+
+	for _, field := range strictDecodeFields(typ) {
+		schema, err := g.schema(field.typ, field.quoted)
+		if err != nil {
+			return nil, fmt.Errorf("field %q: %s", field.name, err)
+		}
+
+		if field.nonEmpty {
+			schema = jsonSchemaNonEmpty(schema)
+		}
+
+		props[field.name] = schema
+
+		if field.required || !field.omitEmpty {
+			required = append(required, field.name)
+		}
+	}
+
+	return map[string]interface{}{
+		"type":                 "object",
+		"properties":           props,
+		"required":             required,
+		"additionalProperties": false,
+	}, nil
+}
+
+// Allow null in addition to given schema.
+func jsonSchemaNullable(schema interface{}) interface{} {
+	obj, ok := schema.(map[string]interface{})
+	if !ok {
+		return schema
+	}
+
+	if typ, ok := obj["type"].(string); ok {
+		ret := map[string]interface{}{}
+		for k, v := range obj {
+			ret[k] = v
+		}
+		ret["type"] = []interface{}{typ, "null"}
+		return ret
+	}
+
+	// unlike anyOf, if-else reports errors from nested schema
+	return map[string]interface{}{
+		"if":   map[string]interface{}{"type": "null"},
+		"else": schema,
+	}
+}
+
+// Disallow null and empty string, as "required" validation rule does.
+func jsonSchemaNonEmpty(schema interface{}) interface{} {
+	obj, ok := schema.(map[string]interface{})
+	if !ok {
+		return schema
+	}
+
+	if els, ok := obj["else"]; ok && len(obj) == 2 {
+		return jsonSchemaNonEmpty(els)
+	}
+
+	ret := map[string]interface{}{}
+	for k, v := range obj {
+		ret[k] = v
+	}
+
+	if types, ok := obj["type"].([]interface{}); ok {
+		ret["type"] = types[0]
+	}
+	if ret["type"] == "string" {
+		ret["minLength"] = json.Number("1")
+	}
+
+	return ret
+}
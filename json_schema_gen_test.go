@@ -0,0 +1,296 @@
+package httpexpect
+
+import (
+	"encoding/json"
+	"math"
+	"net"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type jsonSchemaGenNode struct {
+	Value    int                  `json:"value"`
+	Children []*jsonSchemaGenNode `json:"children,omitempty"`
+}
+
+type jsonSchemaGenMarshaler struct{}
+
+func (jsonSchemaGenMarshaler) MarshalJSON() ([]byte, error) {
+	return []byte(`"custom"`), nil
+}
+
+func matchesGeneratedSchema(t *testing.T, example, value interface{}) bool {
+	schema, err := jsonSchemaFromType(reflect.TypeOf(example))
+	require.NoError(t, err)
+
+	index := newJSONSchemaIndex()
+	require.NoError(t, index.add(jsonSchemaDefaultURI, schema))
+
+	chain := newMockChain(t).enter("test")
+	defer chain.leave()
+
+	canon, ok := canonValue(chain, value)
+	require.True(t, ok)
+
+	errs, err := newJSONSchemaValidator(index).run(
+		index.resources[jsonSchemaDefaultURI], canon)
+	require.NoError(t, err)
+
+	return len(errs) == 0
+}
+
+func TestJSONSchemaGen_Types(t *testing.T) {
+	type Base struct {
+		ID string `json:"id"`
+	}
+
+	type Embedding struct {
+		Base
+		Name    string `json:"name"`
+		Skipped string `json:"-"`
+		private string //nolint
+	}
+
+	cases := []struct {
+		name    string
+		example interface{}
+		valid   []interface{}
+		invalid []interface{}
+	}{
+		{
+			name:    "bool",
+			example: false,
+			valid:   []interface{}{true},
+			invalid: []interface{}{nil, 1, "true"},
+		},
+		{
+			name:    "int8",
+			example: int8(0),
+			valid:   []interface{}{-128, 127},
+			invalid: []interface{}{-129, 128, 1.5, nil},
+		},
+		{
+			name:    "int64",
+			example: int64(0),
+			valid:   []interface{}{int64(math.MinInt64), int64(math.MaxInt64)},
+			invalid: []interface{}{json.Number("9223372036854775808")},
+		},
+		{
+			name:    "uint64",
+			example: uint64(0),
+			valid:   []interface{}{0, uint64(math.MaxUint64)},
+			invalid: []interface{}{-1, json.Number("18446744073709551616")},
+		},
+		{
+			name:    "float",
+			example: float32(0),
+			valid:   []interface{}{1.5, 1},
+			invalid: []interface{}{"1.5"},
+		},
+		{
+			name:    "string",
+			example: "",
+			valid:   []interface{}{"", "a"},
+			invalid: []interface{}{nil, 1},
+		},
+		{
+			name:    "bytes",
+			example: []byte{},
+			valid:   []interface{}{"YWJj", nil},
+			invalid: []interface{}{[]interface{}{1}},
+		},
+		{
+			name:    "pointer",
+			example: new(int),
+			valid:   []interface{}{1, nil},
+			invalid: []interface{}{"1"},
+		},
+		{
+			name:    "slice",
+			example: []string{},
+			valid:   []interface{}{[]string{"a"}, nil},
+			invalid: []interface{}{[]int{1}, "a"},
+		},
+		{
+			name:    "array",
+			example: [2]int{},
+			valid:   []interface{}{[]int{1, 2}},
+			invalid: []interface{}{[]int{1}, []int{1, 2, 3}, nil},
+		},
+		{
+			name:    "map",
+			example: map[int]bool{},
+			valid:   []interface{}{map[string]interface{}{"-1": true}, nil},
+			invalid: []interface{}{
+				map[string]interface{}{"a": true},
+				map[string]interface{}{"1": 1},
+			},
+		},
+		{
+			name:    "interface",
+			example: []interface{}{},
+			valid:   []interface{}{[]interface{}{nil, 1, "a"}},
+		},
+		{
+			name:    "time",
+			example: time.Time{},
+			valid: []interface{}{
+				"2020-01-01T00:00:00Z",
+				"2020-01-01T00:00:00.123+02:00",
+			},
+			invalid: []interface{}{"2020-01-01", 1},
+		},
+		{
+			name:    "json.Number",
+			example: json.Number(""),
+			valid:   []interface{}{1.5},
+			invalid: []interface{}{"1.5"},
+		},
+		{
+			name:    "json.Marshaler",
+			example: jsonSchemaGenMarshaler{},
+			valid:   []interface{}{"custom", 1, nil},
+		},
+		{
+			name:    "encoding.TextMarshaler",
+			example: net.IP{},
+			valid:   []interface{}{"127.0.0.1"},
+			invalid: []interface{}{[]int{127, 0, 0, 1}},
+		},
+		{
+			name: "quoted",
+			example: struct {
+				N int  `json:"n,string"`
+				B bool `json:"b,string"`
+			}{},
+			valid: []interface{}{
+				map[string]interface{}{"n": "1", "b": "true"},
+			},
+			invalid: []interface{}{
+				map[string]interface{}{"n": 1, "b": "true"},
+			},
+		},
+		{
+			name:    "embedded",
+			example: Embedding{},
+			valid: []interface{}{
+				map[string]interface{}{"id": "a", "name": "b"},
+			},
+			invalid: []interface{}{
+				map[string]interface{}{"name": "b"},
+				map[string]interface{}{"id": "a", "name": "b", "Base": nil},
+				map[string]interface{}{"id": "a", "name": "b", "Skipped": ""},
+				map[string]interface{}{"id": "a", "name": "b", "private": ""},
+			},
+		},
+		{
+			name:    "recursive",
+			example: jsonSchemaGenNode{},
+			valid: []interface{}{
+				map[string]interface{}{"value": 1},
+				map[string]interface{}{"value": 1, "children": []interface{}{
+					map[string]interface{}{"value": 2},
+					nil,
+				}},
+			},
+			invalid: []interface{}{
+				map[string]interface{}{"value": 1, "children": []interface{}{
+					map[string]interface{}{"value": "2"},
+				}},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			for _, value := range tc.valid {
+				assert.True(t, matchesGeneratedSchema(t, tc.example, value),
+					"value: %v", value)
+			}
+			for _, value := range tc.invalid {
+				assert.False(t, matchesGeneratedSchema(t, tc.example, value),
+					"value: %v", value)
+			}
+		})
+	}
+}
+
+func TestJSONSchemaGen_Required(t *testing.T) {
+	type Item struct {
+		A string   `json:"a"`
+		B string   `json:"b,omitempty"`
+		C string   `json:"c,omitempty,required"`
+		D string   `json:"d,omitempty" validate:"required"`
+		E *int     `json:"e" validate:"omitempty,required"`
+		F []string `json:"f,omitempty" validate:"required"`
+	}
+
+	full := func() map[string]interface{} {
+		return map[string]interface{}{
+			"a": "", "b": "", "c": "", "d": "d", "e": 1, "f": []string{},
+		}
+	}
+
+	assert.True(t, matchesGeneratedSchema(t, Item{}, full()))
+
+	for _, name := range []string{"a", "c", "d", "e", "f"} {
+		value := full()
+		delete(value, name)
+		assert.False(t, matchesGeneratedSchema(t, Item{}, value), "field: %s", name)
+	}
+
+	value := full()
+	delete(value, "b")
+	assert.True(t, matchesGeneratedSchema(t, Item{}, value))
+
+	for name, empty := range map[string]interface{}{"d": "", "e": nil, "f": nil} {
+		value := full()
+		value[name] = empty
+		assert.False(t, matchesGeneratedSchema(t, Item{}, value), "field: %s", name)
+	}
+}
+
+func TestJSONSchemaGen_Defs(t *testing.T) {
+	type Node = jsonSchemaGenNode
+
+	schema, err := jsonSchemaFromType(reflect.TypeOf(struct {
+		A Node  `json:"a"`
+		B *Node `json:"b"`
+	}{}))
+	require.NoError(t, err)
+
+	root := schema.(map[string]interface{})
+	defs := root["$defs"].(map[string]interface{})
+
+	assert.Equal(t, jsonSchemaDraft202012, root["$schema"])
+	assert.Equal(t, 1, len(defs))
+	assert.Contains(t, defs, "jsonSchemaGenNode")
+
+	props := root["properties"].(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{
+		"$ref": "#/$defs/jsonSchemaGenNode",
+	}, props["a"])
+	assert.Equal(t, map[string]interface{}{
+		"if":   map[string]interface{}{"type": "null"},
+		"else": map[string]interface{}{"$ref": "#/$defs/jsonSchemaGenNode"},
+	}, props["b"])
+}
+
+func TestJSONSchemaGen_Unsupported(t *testing.T) {
+	for _, example := range []interface{}{
+		make(chan int),
+		func() {},
+		complex(1, 2),
+		map[[2]int]string{},
+		struct {
+			F func() `json:"f"`
+		}{},
+	} {
+		_, err := jsonSchemaFromType(reflect.TypeOf(example))
+		assert.Error(t, err, "example: %T", example)
+	}
+}
@@ -0,0 +1,566 @@
+package httpexpect
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func validateModernSchema(
+	t *testing.T, schema string, value interface{},
+) ([]jsonSchemaError, error) {
+	data, err := loadSchema([]byte(schema))
+	require.NoError(t, err)
+
+	index := newJSONSchemaIndex()
+	require.NoError(t, index.add(jsonSchemaDefaultURI, data))
+
+	chain := newMockChain(t).enter("test")
+	defer chain.leave()
+
+	canon, ok := canonValue(chain, value)
+	require.True(t, ok)
+
+	return newJSONSchemaValidator(index).run(index.resources[jsonSchemaDefaultURI], canon)
+}
+
+func TestJSONSchema_Keywords(t *testing.T) {
+	cases := []struct {
+		name    string
+		schema  string
+		valid   []interface{}
+		invalid []interface{}
+	}{
+		{
+			name:    "boolean true",
+			schema:  `true`,
+			valid:   []interface{}{nil, 1, "a"},
+			invalid: []interface{}{},
+		},
+		{
+			name:    "boolean false",
+			schema:  `false`,
+			invalid: []interface{}{nil, 1, "a"},
+		},
+		{
+			name:    "type",
+			schema:  `{"type": ["integer", "null"]}`,
+			valid:   []interface{}{1, 1.0, nil, json.Number("12345678901234567890")},
+			invalid: []interface{}{1.5, "1", true},
+		},
+		{
+			name:    "enum and const",
+			schema:  `{"enum": [1, "a", {"b": [2]}], "not": {"const": "a"}}`,
+			valid:   []interface{}{1.0, map[string]interface{}{"b": []int{2}}},
+			invalid: []interface{}{"a", 2, map[string]interface{}{"b": []int{3}}},
+		},
+		{
+			name:    "numeric",
+			schema:  `{"minimum": 1, "exclusiveMaximum": 10, "multipleOf": 0.5}`,
+			valid:   []interface{}{1, 1.5, 9.5},
+			invalid: []interface{}{0.5, 10, 2.25},
+		},
+		{
+			name:    "numeric exact",
+			schema:  `{"maximum": 12345678901234567890}`,
+			valid:   []interface{}{uint64(12345678901234567890)},
+			invalid: []interface{}{uint64(12345678901234567891)},
+		},
+		{
+			name:    "string",
+			schema:  `{"minLength": 2, "maxLength": 3, "pattern": "^[a-zа-я]+$"}`,
+			valid:   []interface{}{"ab", "абв", 123},
+			invalid: []interface{}{"a", "abcd", "AB"},
+		},
+		{
+			name:    "array",
+			schema:  `{"minItems": 1, "maxItems": 3, "uniqueItems": true}`,
+			valid:   []interface{}{[]int{1}, []interface{}{1, "1", 2}},
+			invalid: []interface{}{[]int{}, []int{1, 2, 3, 4}, []interface{}{1, 1.0}},
+		},
+		{
+			name:    "prefixItems and items",
+			schema:  `{"prefixItems": [{"type": "string"}], "items": {"type": "integer"}}`,
+			valid:   []interface{}{[]interface{}{}, []interface{}{"a", 1, 2}},
+			invalid: []interface{}{[]interface{}{1}, []interface{}{"a", "b"}},
+		},
+		{
+			name:    "items array and additionalItems",
+			schema:  `{"items": [{"type": "string"}], "additionalItems": false}`,
+			valid:   []interface{}{[]interface{}{"a"}},
+			invalid: []interface{}{[]interface{}{"a", 1}},
+		},
+		{
+			name:    "contains",
+			schema:  `{"contains": {"type": "string"}, "minContains": 2, "maxContains": 3}`,
+			valid:   []interface{}{[]interface{}{"a", 1, "b"}},
+			invalid: []interface{}{[]interface{}{"a", 1}, []string{"a", "b", "c", "d"}},
+		},
+		{
+			name: "object",
+			schema: `{
+				"properties": {"a": {"type": "integer"}},
+				"patternProperties": {"^x-": {"type": "string"}},
+				"additionalProperties": false,
+				"required": ["a"],
+				"minProperties": 1,
+				"maxProperties": 2
+			}`,
+			valid: []interface{}{
+				map[string]interface{}{"a": 1},
+				map[string]interface{}{"a": 1, "x-b": "c"},
+			},
+			invalid: []interface{}{
+				map[string]interface{}{},
+				map[string]interface{}{"a": "1"},
+				map[string]interface{}{"a": 1, "b": 2},
+				map[string]interface{}{"a": 1, "x-b": 2},
+				map[string]interface{}{"a": 1, "x-b": "c", "x-c": "d"},
+			},
+		},
+		{
+			name:    "propertyNames",
+			schema:  `{"propertyNames": {"maxLength": 2}}`,
+			valid:   []interface{}{map[string]interface{}{"ab": 1}},
+			invalid: []interface{}{map[string]interface{}{"abc": 1}},
+		},
+		{
+			name: "dependentRequired and dependentSchemas",
+			schema: `{
+				"dependentRequired": {"a": ["b"]},
+				"dependentSchemas": {"c": {"required": ["d"]}}
+			}`,
+			valid: []interface{}{
+				map[string]interface{}{"a": 1, "b": 2},
+				map[string]interface{}{"c": 1, "d": 2},
+				map[string]interface{}{"b": 1},
+			},
+			invalid: []interface{}{
+				map[string]interface{}{"a": 1},
+				map[string]interface{}{"c": 1},
+			},
+		},
+		{
+			name: "allOf, anyOf, oneOf",
+			schema: `{
+				"allOf": [{"minimum": 0}],
+				"anyOf": [{"maximum": 5}, {"minimum": 10}],
+				"oneOf": [{"multipleOf": 2}, {"multipleOf": 3}]
+			}`,
+			valid:   []interface{}{2, 3, 12 + 2},
+			invalid: []interface{}{-2, 6, 7, 12},
+		},
+		{
+			name: "if, then, else",
+			schema: `{
+				"if": {"type": "string"},
+				"then": {"minLength": 2},
+				"else": {"type": "integer"}
+			}`,
+			valid:   []interface{}{"ab", 1},
+			invalid: []interface{}{"a", 1.5},
+		},
+		{
+			name: "unevaluatedProperties",
+			schema: `{
+				"allOf": [{"properties": {"a": true}}],
+				"if": {"properties": {"kind": {"const": "b"}}},
+				"then": {"properties": {"b": true}},
+				"properties": {"kind": true},
+				"unevaluatedProperties": false
+			}`,
+			valid: []interface{}{
+				map[string]interface{}{"a": 1, "kind": "a"},
+				map[string]interface{}{"b": 1, "kind": "b"},
+			},
+			invalid: []interface{}{
+				map[string]interface{}{"b": 1, "kind": "a"},
+				map[string]interface{}{"c": 1},
+			},
+		},
+		{
+			name: "unevaluatedProperties with failed branch",
+			schema: `{
+				"anyOf": [
+					{"properties": {"a": {"type": "string"}}},
+					{"properties": {"b": true}}
+				],
+				"unevaluatedProperties": false
+			}`,
+			valid: []interface{}{
+				map[string]interface{}{"a": "x"},
+				map[string]interface{}{"b": 1},
+			},
+			invalid: []interface{}{
+				map[string]interface{}{"a": 1},
+			},
+		},
+		{
+			name: "unevaluatedItems",
+			schema: `{
+				"prefixItems": [true],
+				"contains": {"type": "string"},
+				"unevaluatedItems": {"type": "integer"}
+			}`,
+			valid:   []interface{}{[]interface{}{nil, "a", 1}},
+			invalid: []interface{}{[]interface{}{nil, "a", 1.5}},
+		},
+		{
+			name: "$defs and $ref",
+			schema: `{
+				"$defs": {
+					"pos": {"type": "integer", "minimum": 1},
+					"a/b": {"const": "x"}
+				},
+				"properties": {
+					"n": {"$ref": "#/$defs/pos"},
+					"s": {"$ref": "#/$defs/a~1b"}
+				}
+			}`,
+			valid: []interface{}{map[string]interface{}{"n": 1, "s": "x"}},
+			invalid: []interface{}{
+				map[string]interface{}{"n": 0},
+				map[string]interface{}{"s": "y"},
+			},
+		},
+		{
+			name: "$anchor and nested $id",
+			schema: `{
+				"$id": "https://example.com/root.json",
+				"$defs": {
+					"item": {
+						"$id": "item.json",
+						"$anchor": "item",
+						"type": "object",
+						"properties": {"next": {"$ref": "#"}}
+					}
+				},
+				"items": {"$ref": "item.json#item"}
+			}`,
+			valid: []interface{}{
+				[]interface{}{map[string]interface{}{"next": map[string]interface{}{}}},
+			},
+			invalid: []interface{}{
+				[]interface{}{map[string]interface{}{"next": 1}},
+			},
+		},
+		{
+			name: "recursive $ref",
+			schema: `{
+				"type": "object",
+				"properties": {"children": {"type": "array", "items": {"$ref": "#"}}},
+				"required": ["name"]
+			}`,
+			valid: []interface{}{
+				map[string]interface{}{"name": "a", "children": []interface{}{
+					map[string]interface{}{"name": "b"},
+				}},
+			},
+			invalid: []interface{}{
+				map[string]interface{}{"name": "a", "children": []interface{}{
+					map[string]interface{}{},
+				}},
+			},
+		},
+		{
+			name: "$dynamicRef",
+			schema: `{
+				"$id": "https://example.com/strings.json",
+				"$ref": "list.json",
+				"$defs": {
+					"elem": {"$dynamicAnchor": "elem", "type": "string"},
+					"list": {
+						"$id": "list.json",
+						"type": "array",
+						"items": {"$dynamicRef": "#elem"},
+						"$defs": {
+							"elem": {"$dynamicAnchor": "elem"}
+						}
+					}
+				}
+			}`,
+			valid:   []interface{}{[]interface{}{"a", "b"}},
+			invalid: []interface{}{[]interface{}{"a", 1}},
+		},
+		{
+			name: "$recursiveRef",
+			schema: `{
+				"$schema": "https://json-schema.org/draft/2019-09/schema",
+				"$id": "https://example.com/strict.json",
+				"$recursiveAnchor": true,
+				"$ref": "tree.json",
+				"unevaluatedProperties": false,
+				"$defs": {
+					"tree": {
+						"$id": "tree.json",
+						"$recursiveAnchor": true,
+						"type": "object",
+						"properties": {
+							"data": true,
+							"children": {"type": "array", "items": {"$recursiveRef": "#"}}
+						}
+					}
+				}
+			}`,
+			valid: []interface{}{
+				map[string]interface{}{"children": []interface{}{
+					map[string]interface{}{"data": 1},
+				}},
+			},
+			invalid: []interface{}{
+				map[string]interface{}{"children": []interface{}{
+					map[string]interface{}{"extra": 1},
+				}},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			for _, value := range tc.valid {
+				errs, err := validateModernSchema(t, tc.schema, value)
+				require.NoError(t, err)
+				assert.Empty(t, errs, "value: %v", value)
+			}
+			for _, value := range tc.invalid {
+				errs, err := validateModernSchema(t, tc.schema, value)
+				require.NoError(t, err)
+				assert.NotEmpty(t, errs, "value: %v", value)
+			}
+		})
+	}
+}
+
+func TestJSONSchema_Errors(t *testing.T) {
+	schema := `{
+		"$defs": {
+			"price": {"type": "number", "minimum": 0}
+		},
+		"type": "object",
+		"properties": {
+			"items": {
+				"type": "array",
+				"items": {
+					"properties": {"price": {"$ref": "#/$defs/price"}},
+					"required": ["sku"]
+				}
+			}
+		},
+		"unevaluatedProperties": false
+	}`
+
+	value := map[string]interface{}{
+		"items": []interface{}{
+			map[string]interface{}{"sku": "a", "price": 1},
+			map[string]interface{}{"price": -1},
+		},
+		"extra": true,
+	}
+
+	errs, err := validateModernSchema(t, schema, value)
+	require.NoError(t, err)
+
+	var messages []string
+	for _, e := range errs {
+		messages = append(messages, e.Error())
+	}
+
+	assert.Equal(t, []string{
+		"at $.items[1]: required: missing property \"sku\"" +
+			" (#/properties/items/items/required)",
+		"at $.items[1].price: minimum: -1 is less than 0" +
+			" (#/properties/items/items/properties/price/$ref/minimum)",
+		"at $.extra: false: no value is allowed (#/unevaluatedProperties)",
+	}, messages)
+}
+
+func TestJSONSchema_Invalid(t *testing.T) {
+	cases := []struct {
+		name   string
+		schema string
+		value  interface{}
+	}{
+		{"bad schema type", `{"items": 1}`, []interface{}{"a"}},
+		{"bad type keyword", `{"type": 1}`, nil},
+		{"bad enum", `{"enum": 1}`, nil},
+		{"bad minimum", `{"minimum": "1"}`, 1},
+		{"bad minLength", `{"minLength": -1}`, "a"},
+		{"bad pattern", `{"pattern": "["}`, "a"},
+		{"bad required", `{"required": [1]}`, map[string]interface{}{}},
+		{"empty anyOf", `{"anyOf": []}`, nil},
+		{"unresolved $ref", `{"$ref": "#/$defs/missing"}`, nil},
+		{"unresolved anchor", `{"$ref": "#missing"}`, nil},
+		{"unresolved document", `{"$ref": "other.json"}`, nil},
+		{"infinite recursion", `{"$ref": "#"}`, nil},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := validateModernSchema(t, tc.schema, tc.value)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestJSONSchema_Draft(t *testing.T) {
+	assert.True(t, isModernJSONSchema(map[string]interface{}{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+	}))
+	assert.True(t, isModernJSONSchema(map[string]interface{}{
+		"$schema": "https://json-schema.org/draft/2019-09/schema#",
+	}))
+	assert.False(t, isModernJSONSchema(map[string]interface{}{
+		"$schema": "http://json-schema.org/draft-07/schema#",
+	}))
+	assert.False(t, isModernJSONSchema(map[string]interface{}{}))
+	assert.False(t, isModernJSONSchema(true))
+}
+
+func TestSchemaRegistry(t *testing.T) {
+	files := map[string]string{
+		"user.json": `{
+			"type": "object",
+			"properties": {
+				"name": {"type": "string"},
+				"address": {"$ref": "common/address.json"},
+				"tags": {"$ref": "common/defs.json#/$defs/tags"}
+			},
+			"required": ["name"],
+			"unevaluatedProperties": false
+		}`,
+		"common/address.json": `{
+			"$id": "https://example.com/address",
+			"type": "object",
+			"properties": {"city": {"$ref": "defs.json#city"}},
+			"required": ["city"]
+		}`,
+		"common/defs.json": `{
+			"$defs": {
+				"tags": {"type": "array", "items": {"type": "string"}},
+				"city": {"$anchor": "city", "type": "string", "minLength": 1}
+			}
+		}`,
+		"common/README.md": `not a schema`,
+	}
+
+	tempdir, err := ioutil.TempDir("", "httpexpect")
+	require.NoError(t, err)
+	defer os.RemoveAll(tempdir)
+
+	for name, content := range files {
+		p := filepath.Join(tempdir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(p), 0755))
+		require.NoError(t, ioutil.WriteFile(p, []byte(content), 0644))
+	}
+
+	// address.json uses "defs.json" relative to its "$id"
+	addDefs := func(registry *SchemaRegistry) {
+		require.NoError(t, registry.Add("https://example.com/defs.json",
+			files["common/defs.json"]))
+	}
+
+	good := map[string]interface{}{
+		"name":    "John",
+		"address": map[string]interface{}{"city": "Paris"},
+		"tags":    []interface{}{"a"},
+	}
+
+	bad := map[string]interface{}{
+		"name":    "John",
+		"address": map[string]interface{}{"city": ""},
+		"tags":    []interface{}{1},
+		"age":     30,
+	}
+
+	check := func(t *testing.T, registry *SchemaRegistry) {
+		reporter := newMockReporter(t)
+
+		NewValue(reporter, good).Schema(registry.Ref("user.json")).
+			chain.assertNotFailed(t)
+
+		NewValue(reporter, good).Schema(registry.Ref("file:///user.json")).
+			chain.assertNotFailed(t)
+
+		NewValue(reporter, "Paris").
+			Schema(registry.Ref("https://example.com/address#/properties/city")).
+			chain.assertNotFailed(t)
+
+		NewValue(reporter, []interface{}{"a"}).
+			Schema(registry.Ref("common/defs.json#/$defs/tags")).
+			chain.assertNotFailed(t)
+
+		NewValue(reporter, bad).Schema(registry.Ref("user.json")).
+			chain.assertFailed(t)
+
+		NewValue(reporter, good).Schema(registry.Ref("missing.json")).
+			chain.assertFailed(t)
+	}
+
+	t.Run("AddDir", func(t *testing.T) {
+		registry := NewSchemaRegistry()
+		require.NoError(t, registry.AddDir(tempdir))
+		addDefs(registry)
+
+		check(t, registry)
+	})
+
+	t.Run("AddFS", func(t *testing.T) {
+		registry := NewSchemaRegistry()
+		require.NoError(t, registry.AddFS(http.Dir(tempdir)))
+		addDefs(registry)
+
+		check(t, registry)
+	})
+
+	t.Run("Add", func(t *testing.T) {
+		registry := NewSchemaRegistry()
+		for name, content := range files {
+			if filepath.Ext(name) == ".json" {
+				require.NoError(t, registry.Add(name, content))
+			}
+		}
+		addDefs(registry)
+
+		check(t, registry)
+	})
+
+	t.Run("failure errors", func(t *testing.T) {
+		registry := NewSchemaRegistry()
+		require.NoError(t, registry.AddDir(tempdir))
+		addDefs(registry)
+
+		handler := &mockAssertionHandler{}
+		chain := newChainWithConfig("test", Config{
+			AssertionHandler: handler,
+		}.withDefaults())
+
+		newValue(chain, bad).Schema(registry.Ref("user.json"))
+
+		require.NotNil(t, handler.failure)
+		assert.Equal(t, AssertMatchSchema, handler.failure.Type)
+		require.Equal(t, 4, len(handler.failure.Errors))
+		assert.Contains(t, handler.failure.Errors[1].Error(), "at $.address.city: minLength:")
+		assert.Contains(t, handler.failure.Errors[2].Error(), "at $.tags[0]: type:")
+		assert.Contains(t, handler.failure.Errors[3].Error(), "at $.age: false:")
+	})
+
+	t.Run("invalid schemas", func(t *testing.T) {
+		registry := NewSchemaRegistry()
+
+		assert.Error(t, registry.Add("a.json", `{`))
+		assert.Error(t, registry.Add("a.json", `123`))
+		assert.Error(t, registry.Add("a.json", func() {}))
+		assert.Error(t, registry.AddDir(filepath.Join(tempdir, "missing")))
+		assert.Error(t, registry.AddFS(http.Dir(filepath.Join(tempdir, "missing"))))
+
+		reporter := newMockReporter(t)
+
+		NewValue(reporter, 1).Schema(&SchemaRef{}).chain.assertFailed(t)
+	})
+}
@@ -0,0 +1,449 @@
+package httpexpect
+
+import (
+	"errors"
+	"fmt"
+)
+
+// JSONAPI provides methods to inspect JSON:API document
+// (https://jsonapi.org/format/).
+type JSONAPI struct {
+	noCopy   noCopy
+	chain    *chain
+	follower *linkFollower
+	value    map[string]interface{}
+}
+
+func newJSONAPI(
+	parent *chain, follower *linkFollower, val map[string]interface{},
+) *JSONAPI {
+	j := &JSONAPI{chain: parent.clone(), follower: follower, value: nil}
+
+	opChain := j.chain.enter("")
+	defer opChain.leave()
+
+	if val == nil {
+		opChain.fail(AssertionFailure{
+			Type:   AssertNotNil,
+			Actual: &AssertionValue{val},
+			Errors: []error{
+				errors.New("expected: non-nil JSON:API document"),
+			},
+		})
+	} else {
+		j.value = val
+	}
+
+	return j
+}
+
+// Raw returns underlying top-level object of the document.
+//
+// Example:
+//
+//	doc := resp.JSONAPI()
+//	assert.Contains(t, doc.Raw(), "data")
+func (j *JSONAPI) Raw() map[string]interface{} {
+	return j.value
+}
+
+// Alias is similar to Value.Alias.
+func (j *JSONAPI) Alias(name string) *JSONAPI {
+	opChain := j.chain.enter("Alias(%q)", name)
+	defer opChain.leave()
+
+	j.chain.setAlias(name)
+	return j
+}
+
+// Chain returns public handle to assertion chain of JSONAPI, which may be
+// used to implement custom matchers. See Chain for details.
+func (j *JSONAPI) Chain() *Chain {
+	return &Chain{chain: j.chain}
+}
+
+// AsWarning is similar to Value.AsWarning.
+func (j *JSONAPI) AsWarning() *JSONAPI {
+	opChain := j.chain.enter("AsWarning()")
+	defer opChain.leave()
+
+	warnChain := j.chain.clone()
+	warnChain.setWarning()
+
+	return &JSONAPI{
+		chain:    warnChain,
+		follower: j.follower,
+		value:    j.value,
+	}
+}
+
+// Data returns a new Value instance with primary data of the document.
+// Primary data is null, a resource object, or an array of resource objects.
+//
+// If document has no primary data, failure is reported.
+//
+// Example:
+//
+//	doc := resp.JSONAPI()
+//	doc.Data().Object().HasValue("type", "articles")
+func (j *JSONAPI) Data() *Value {
+	opChain := j.chain.enter("Data()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newValue(opChain, nil)
+	}
+
+	data, ok := j.value["data"]
+	if !ok {
+		opChain.fail(AssertionFailure{
+			Type:     AssertContainsKey,
+			Actual:   &AssertionValue{j.value},
+			Expected: &AssertionValue{"data"},
+			Errors: []error{
+				errors.New("expected: document contains primary data"),
+			},
+		})
+		return newValue(opChain, nil)
+	}
+
+	return newValue(opChain, data)
+}
+
+// Included returns a new Array instance with included resource objects.
+//
+// If document has no included resources, returned array is empty.
+//
+// Example:
+//
+//	doc := resp.JSONAPI()
+//	doc.Included().Length().IsEqual(2)
+func (j *JSONAPI) Included() *Array {
+	opChain := j.chain.enter("Included()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newArray(opChain, nil)
+	}
+
+	included, _ := j.value["included"].([]interface{})
+	if included == nil {
+		included = []interface{}{}
+	}
+
+	return newArray(opChain, included)
+}
+
+// Relationships returns a new Object instance with relationships of
+// primary resource object.
+//
+// If primary data is not a single resource object, failure is reported.
+// If resource has no relationships, returned object is empty.
+//
+// Example:
+//
+//	doc := resp.JSONAPI()
+//	doc.Relationships().Value("author").Object().
+//		Value("data").Object().HasValue("id", "9")
+func (j *JSONAPI) Relationships() *Object {
+	opChain := j.chain.enter("Relationships()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newObject(opChain, nil)
+	}
+
+	resource, ok := j.value["data"].(map[string]interface{})
+	if !ok {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{j.value["data"]},
+			Errors: []error{
+				errors.New("expected: primary data is a single resource object"),
+			},
+		})
+		return newObject(opChain, nil)
+	}
+
+	relationships, _ := resource["relationships"].(map[string]interface{})
+	if relationships == nil {
+		relationships = map[string]interface{}{}
+	}
+
+	return newObject(opChain, relationships)
+}
+
+// Meta returns a new Object instance with top-level meta information.
+//
+// If document has no top-level meta, failure is reported.
+//
+// Example:
+//
+//	doc := resp.JSONAPI()
+//	doc.Meta().HasValue("total", 42)
+func (j *JSONAPI) Meta() *Object {
+	opChain := j.chain.enter("Meta()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newObject(opChain, nil)
+	}
+
+	meta, ok := j.value["meta"].(map[string]interface{})
+	if !ok {
+		opChain.fail(AssertionFailure{
+			Type:     AssertContainsKey,
+			Actual:   &AssertionValue{j.value},
+			Expected: &AssertionValue{"meta"},
+			Errors: []error{
+				errors.New("expected: document contains top-level meta"),
+			},
+		})
+		return newObject(opChain, nil)
+	}
+
+	return newObject(opChain, meta)
+}
+
+// Links returns a new Object instance with top-level links.
+//
+// If document has no top-level links, returned object is empty.
+//
+// Example:
+//
+//	doc := resp.JSONAPI()
+//	doc.Links().ContainsKey("next")
+func (j *JSONAPI) Links() *Object {
+	opChain := j.chain.enter("Links()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newObject(opChain, nil)
+	}
+
+	links, _ := j.value["links"].(map[string]interface{})
+	if links == nil {
+		links = map[string]interface{}{}
+	}
+
+	return newObject(opChain, links)
+}
+
+// Follow returns a new Request instance for GET request to the link with
+// given name. The link is looked up in top-level links, and then in links
+// of primary resource object.
+//
+// Link URL is resolved relative to the URL of the request that received
+// this response. If the response was received using Expect, new request
+// is created by the same Expect and has same builders and matchers.
+//
+// If there is no such link, failure is reported.
+//
+// Example:
+//
+//	doc := resp.JSONAPI()
+//	doc.Follow("next").Expect().Status(http.StatusOK).JSONAPI()
+func (j *JSONAPI) Follow(rel string) *Request {
+	opChain := j.chain.enter("Follow(%q)", rel)
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return j.follower.follow(opChain, "")
+	}
+
+	href, ok := j.findLink(rel)
+	if !ok {
+		opChain.fail(AssertionFailure{
+			Type:     AssertContainsKey,
+			Actual:   &AssertionValue{j.value["links"]},
+			Expected: &AssertionValue{rel},
+			Errors: []error{
+				fmt.Errorf("expected: document contains %q link", rel),
+			},
+		})
+		return j.follower.follow(opChain, "")
+	}
+
+	return j.follower.follow(opChain, href)
+}
+
+func (j *JSONAPI) findLink(rel string) (string, bool) {
+	if links, ok := j.value["links"].(map[string]interface{}); ok {
+		if href, ok := jsonapiLinkHref(links[rel]); ok {
+			return href, true
+		}
+	}
+
+	if resource, ok := j.value["data"].(map[string]interface{}); ok {
+		if links, ok := resource["links"].(map[string]interface{}); ok {
+			if href, ok := jsonapiLinkHref(links[rel]); ok {
+				return href, true
+			}
+		}
+	}
+
+	return "", false
+}
+
+// Link is either a string with URL, or a link object with "href" member.
+func jsonapiLinkHref(link interface{}) (string, bool) {
+	switch l := link.(type) {
+	case string:
+		return l, true
+
+	case map[string]interface{}:
+		href, ok := l["href"].(string)
+		return href, ok
+	}
+
+	return "", false
+}
+
+// Check top-level structure of JSON:API document.
+func validateJSONAPI(doc map[string]interface{}) error {
+	_, hasData := doc["data"]
+	_, hasErrors := doc["errors"]
+	_, hasMeta := doc["meta"]
+	_, hasIncluded := doc["included"]
+
+	if !hasData && !hasErrors && !hasMeta {
+		return errors.New(
+			`document should contain at least one of "data", "errors" or "meta"`)
+	}
+
+	if hasData && hasErrors {
+		return errors.New(`document should not contain both "data" and "errors"`)
+	}
+
+	if hasIncluded && !hasData {
+		return errors.New(`document should not contain "included" without "data"`)
+	}
+
+	if hasData {
+		switch data := doc["data"].(type) {
+		case nil:
+		case map[string]interface{}:
+			if err := validateJSONAPIResource(data); err != nil {
+				return fmt.Errorf("invalid primary data: %s", err)
+			}
+		case []interface{}:
+			for n, elem := range data {
+				if err := validateJSONAPIResource(elem); err != nil {
+					return fmt.Errorf("invalid primary data at index %d: %s", n, err)
+				}
+			}
+		default:
+			return errors.New(
+				`"data" should be null, resource object or array of resource objects`)
+		}
+	}
+
+	if hasIncluded {
+		included, ok := doc["included"].([]interface{})
+		if !ok {
+			return errors.New(`"included" should be array of resource objects`)
+		}
+		for n, elem := range included {
+			if err := validateJSONAPIResource(elem); err != nil {
+				return fmt.Errorf("invalid included resource at index %d: %s", n, err)
+			}
+		}
+	}
+
+	if hasErrors {
+		errs, ok := doc["errors"].([]interface{})
+		if !ok {
+			return errors.New(`"errors" should be array of error objects`)
+		}
+		for n, elem := range errs {
+			if _, ok := elem.(map[string]interface{}); !ok {
+				return fmt.Errorf("error at index %d should be an object", n)
+			}
+		}
+	}
+
+	if hasMeta {
+		if _, ok := doc["meta"].(map[string]interface{}); !ok {
+			return errors.New(`"meta" should be an object`)
+		}
+	}
+
+	if links, ok := doc["links"]; ok {
+		if err := validateJSONAPILinks(links); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func validateJSONAPIResource(value interface{}) error {
+	resource, ok := value.(map[string]interface{})
+	if !ok {
+		return errors.New("resource should be an object")
+	}
+
+	if typ, ok := resource["type"].(string); !ok || typ == "" {
+		return errors.New(`resource should have non-empty string "type"`)
+	}
+
+	_, hasID := resource["id"].(string)
+	_, hasLID := resource["lid"].(string)
+
+	if !hasID && !hasLID {
+		return errors.New(`resource should have string "id" or "lid"`)
+	}
+
+	if attrs, ok := resource["attributes"]; ok {
+		if _, ok := attrs.(map[string]interface{}); !ok {
+			return errors.New(`resource "attributes" should be an object`)
+		}
+	}
+
+	if rels, ok := resource["relationships"]; ok {
+		relsObj, ok := rels.(map[string]interface{})
+		if !ok {
+			return errors.New(`resource "relationships" should be an object`)
+		}
+		for name, rel := range relsObj {
+			relObj, ok := rel.(map[string]interface{})
+			if !ok {
+				return fmt.Errorf("relationship %q should be an object", name)
+			}
+			_, hasLinks := relObj["links"]
+			_, hasData := relObj["data"]
+			_, hasMeta := relObj["meta"]
+			if !hasLinks && !hasData && !hasMeta {
+				return fmt.Errorf(
+					`relationship %q should contain "links", "data" or "meta"`, name)
+			}
+		}
+	}
+
+	if links, ok := resource["links"]; ok {
+		if err := validateJSONAPILinks(links); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func validateJSONAPILinks(value interface{}) error {
+	links, ok := value.(map[string]interface{})
+	if !ok {
+		return errors.New(`"links" should be an object`)
+	}
+
+	for name, link := range links {
+		if link == nil {
+			continue
+		}
+		if _, ok := jsonapiLinkHref(link); !ok {
+			return fmt.Errorf(
+				"link %q should be null, string or object with string \"href\"", name)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,250 @@
+package httpexpect
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newMockLinkFollower(t *testing.T) *linkFollower {
+	return &linkFollower{
+		config: newMockConfig(newMockReporter(t)),
+		base:   &url.URL{Scheme: "http", Host: "example.com", Path: "/api/articles"},
+	}
+}
+
+func TestJSONAPI_FailedChain(t *testing.T) {
+	check := func(value *JSONAPI) {
+		value.chain.assertFailed(t)
+
+		value.Alias("foo")
+
+		value.Data().chain.assertFailed(t)
+		value.Included().chain.assertFailed(t)
+		value.Relationships().chain.assertFailed(t)
+		value.Meta().chain.assertFailed(t)
+		value.Links().chain.assertFailed(t)
+		value.Follow("next").chain.assertFailed(t)
+	}
+
+	t.Run("failed chain", func(t *testing.T) {
+		chain := newMockChain(t)
+		chain.setFailed()
+
+		value := newJSONAPI(chain, newMockLinkFollower(t), map[string]interface{}{})
+
+		check(value)
+	})
+
+	t.Run("nil value", func(t *testing.T) {
+		chain := newMockChain(t)
+
+		value := newJSONAPI(chain, newMockLinkFollower(t), nil)
+
+		check(value)
+	})
+}
+
+func TestJSONAPI_Alias(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	parent := newChainWithDefaults("JSONAPI()", reporter)
+	value := newJSONAPI(parent, newMockLinkFollower(t), map[string]interface{}{})
+	assert.Equal(t, []string{"JSONAPI()"}, value.chain.context.Path)
+	assert.Equal(t, []string{"JSONAPI()"}, value.chain.context.AliasedPath)
+
+	value.Alias("foo")
+	assert.Equal(t, []string{"JSONAPI()"}, value.chain.context.Path)
+	assert.Equal(t, []string{"foo"}, value.chain.context.AliasedPath)
+
+	childValue := value.Links()
+	assert.Equal(t, []string{"JSONAPI()", "Links()"},
+		childValue.chain.context.Path)
+	assert.Equal(t, []string{"foo", "Links()"},
+		childValue.chain.context.AliasedPath)
+}
+
+func TestJSONAPI_Members(t *testing.T) {
+	t.Run("single resource", func(t *testing.T) {
+		doc := map[string]interface{}{
+			"data": map[string]interface{}{
+				"type": "articles",
+				"id":   "1",
+				"attributes": map[string]interface{}{
+					"title": "JSON:API paints my bikeshed!",
+				},
+				"relationships": map[string]interface{}{
+					"author": map[string]interface{}{
+						"data": map[string]interface{}{"type": "people", "id": "9"},
+					},
+				},
+			},
+			"included": []interface{}{
+				map[string]interface{}{"type": "people", "id": "9"},
+			},
+			"meta": map[string]interface{}{"total": 1.0},
+		}
+
+		value := newJSONAPI(newMockChain(t), newMockLinkFollower(t), doc)
+
+		assert.Equal(t, doc, value.Raw())
+
+		value.Data().Object().HasValue("id", "1")
+		value.Included().Length().IsEqual(1)
+		value.Relationships().Value("author").Object().
+			Value("data").Object().HasValue("id", "9")
+		value.Meta().HasValue("total", 1)
+		value.Links().IsEmpty()
+
+		value.chain.assertNotFailed(t)
+	})
+
+	t.Run("collection", func(t *testing.T) {
+		doc := map[string]interface{}{
+			"data": []interface{}{
+				map[string]interface{}{"type": "articles", "id": "1"},
+				map[string]interface{}{"type": "articles", "id": "2"},
+			},
+		}
+
+		value := newJSONAPI(newMockChain(t), newMockLinkFollower(t), doc)
+
+		value.Data().Array().Length().IsEqual(2)
+		value.Included().IsEmpty()
+		value.chain.assertNotFailed(t)
+
+		value.Relationships().chain.assertFailed(t)
+		value.chain.assertFailed(t)
+	})
+
+	t.Run("no data", func(t *testing.T) {
+		doc := map[string]interface{}{
+			"errors": []interface{}{},
+		}
+
+		value := newJSONAPI(newMockChain(t), newMockLinkFollower(t), doc)
+
+		value.Data().chain.assertFailed(t)
+		value.Meta().chain.assertFailed(t)
+	})
+}
+
+func TestJSONAPI_Follow(t *testing.T) {
+	doc := map[string]interface{}{
+		"links": map[string]interface{}{
+			"self": "http://example.com/api/articles?page=1",
+			"next": map[string]interface{}{"href": "/api/articles?page=2"},
+			"prev": nil,
+		},
+		"data": map[string]interface{}{
+			"type": "articles",
+			"id":   "1",
+			"links": map[string]interface{}{
+				"related": "1/author",
+			},
+		},
+	}
+
+	cases := []struct {
+		rel  string
+		url  string
+		fail bool
+	}{
+		{rel: "self", url: "http://example.com/api/articles?page=1"},
+		{rel: "next", url: "http://example.com/api/articles?page=2"},
+		{rel: "related", url: "http://example.com/api/1/author"},
+		{rel: "prev", fail: true},
+		{rel: "last", fail: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.rel, func(t *testing.T) {
+			value := newJSONAPI(newMockChain(t), newMockLinkFollower(t), doc)
+
+			req := value.Follow(tc.rel)
+
+			if tc.fail {
+				req.chain.assertFailed(t)
+				value.chain.assertFailed(t)
+				return
+			}
+
+			req.chain.assertNotFailed(t)
+			value.chain.assertNotFailed(t)
+
+			assert.True(t, req.encodeRequest(newMockChain(t)))
+
+			assert.Equal(t, "GET", req.httpReq.Method)
+			assert.Equal(t, tc.url, req.httpReq.URL.String())
+		})
+	}
+}
+
+func TestJSONAPI_Validate(t *testing.T) {
+	resource := func(kv ...interface{}) map[string]interface{} {
+		r := map[string]interface{}{"type": "articles", "id": "1"}
+		for n := 0; n < len(kv); n += 2 {
+			r[kv[n].(string)] = kv[n+1]
+		}
+		return r
+	}
+
+	valid := []map[string]interface{}{
+		{"data": nil},
+		{"data": resource()},
+		{"data": []interface{}{resource(), resource()}},
+		{"data": map[string]interface{}{"type": "articles", "lid": "tmp"}},
+		{"data": resource(), "included": []interface{}{resource()}},
+		{"errors": []interface{}{map[string]interface{}{"status": "404"}}},
+		{"meta": map[string]interface{}{}},
+		{"data": nil, "links": map[string]interface{}{
+			"self": "/", "next": nil, "prev": map[string]interface{}{"href": "/"},
+		}},
+		{"data": resource(
+			"attributes", map[string]interface{}{},
+			"relationships", map[string]interface{}{
+				"author": map[string]interface{}{"data": nil},
+			},
+			"links", map[string]interface{}{"self": "/"},
+		)},
+	}
+
+	invalid := []map[string]interface{}{
+		{},
+		{"links": map[string]interface{}{}},
+		{"data": nil, "errors": []interface{}{}},
+		{"included": []interface{}{}, "meta": map[string]interface{}{}},
+		{"data": "foo"},
+		{"data": map[string]interface{}{"id": "1"}},
+		{"data": map[string]interface{}{"type": "", "id": "1"}},
+		{"data": map[string]interface{}{"type": "articles"}},
+		{"data": map[string]interface{}{"type": "articles", "id": 1.0}},
+		{"data": []interface{}{resource(), "foo"}},
+		{"data": resource(), "included": "foo"},
+		{"data": resource(), "included": []interface{}{"foo"}},
+		{"errors": "foo"},
+		{"errors": []interface{}{"foo"}},
+		{"meta": "foo"},
+		{"data": nil, "links": "foo"},
+		{"data": nil, "links": map[string]interface{}{"self": 1.0}},
+		{"data": nil, "links": map[string]interface{}{
+			"self": map[string]interface{}{"title": "foo"},
+		}},
+		{"data": resource("attributes", "foo")},
+		{"data": resource("relationships", "foo")},
+		{"data": resource("relationships", map[string]interface{}{"author": "foo"})},
+		{"data": resource("relationships", map[string]interface{}{
+			"author": map[string]interface{}{},
+		})},
+		{"data": resource("links", "foo")},
+	}
+
+	for n, doc := range valid {
+		assert.NoError(t, validateJSONAPI(doc), "valid[%d]", n)
+	}
+
+	for n, doc := range invalid {
+		assert.Error(t, validateJSONAPI(doc), "invalid[%d]", n)
+	}
+}
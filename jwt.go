@@ -0,0 +1,640 @@
+package httpexpect
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"math"
+	"math/big"
+	"strings"
+	"time"
+)
+
+// JWT provides methods to inspect attached JSON Web Token (RFC 7519).
+//
+// Only compact JWS serialization is supported. Header and claims are decoded
+// without verification; use Verify or VerifyJWKS to check the signature.
+type JWT struct {
+	noCopy noCopy
+	chain  *chain
+	value  string
+
+	header    map[string]interface{}
+	claims    map[string]interface{}
+	signed    []byte
+	signature []byte
+}
+
+// NewJWT returns a new JWT instance.
+//
+// If reporter is nil, the function panics.
+// If value is not a well-formed JWT, failure is reported.
+//
+// Example:
+//
+//	jwt := NewJWT(t, "eyJhbGciOiJIUzI1NiJ9.eyJzdWIiOiJqb2huIn0.3Rx...")
+//
+//	jwt.Header().Value("alg").IsEqual("HS256")
+//	jwt.Claims().Value("sub").IsEqual("john")
+//	jwt.Verify([]byte("secret"))
+func NewJWT(reporter Reporter, value string) *JWT {
+	return newJWT(newChainWithDefaults("JWT()", reporter), value)
+}
+
+// NewJWTC returns a new JWT instance with config.
+//
+// Requirements for config are same as for WithConfig function.
+// If value is not a well-formed JWT, failure is reported.
+//
+// See NewJWT for usage example.
+func NewJWTC(config Config, value string) *JWT {
+	return newJWT(newChainWithConfig("JWT()", config.withDefaults()), value)
+}
+
+func newJWT(parent *chain, val string) *JWT {
+	j := &JWT{chain: parent.clone(), value: val}
+
+	opChain := j.chain.enter("")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return j
+	}
+
+	parts := strings.Split(val, ".")
+	if len(parts) != 3 {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{val},
+			Errors: []error{
+				errors.New("expected: JWT in compact form" +
+					" (three dot-separated base64url parts)"),
+			},
+		})
+		return j
+	}
+
+	header, err := jwtDecodeSegment(parts[0])
+	if err != nil {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{val},
+			Errors: []error{
+				errors.New("expected: JWT with valid header"),
+				err,
+			},
+		})
+		return j
+	}
+
+	claims, err := jwtDecodeSegment(parts[1])
+	if err != nil {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{val},
+			Errors: []error{
+				errors.New("expected: JWT with valid claims"),
+				err,
+			},
+		})
+		return j
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{val},
+			Errors: []error{
+				errors.New("expected: JWT with valid signature encoding"),
+				err,
+			},
+		})
+		return j
+	}
+
+	j.header = header
+	j.claims = claims
+	j.signed = []byte(parts[0] + "." + parts[1])
+	j.signature = signature
+
+	return j
+}
+
+func jwtDecodeSegment(segment string) (map[string]interface{}, error) {
+	data, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(segment, "="))
+	if err != nil {
+		return nil, err
+	}
+
+	var value map[string]interface{}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	if value == nil {
+		return nil, errors.New("segment is not a JSON object")
+	}
+
+	return value, nil
+}
+
+// Raw returns underlying token string attached to JWT.
+// This is the value originally passed to NewJWT.
+//
+// Example:
+//
+//	jwt := NewJWT(t, token)
+//	assert.Equal(t, token, jwt.Raw())
+func (j *JWT) Raw() string {
+	return j.value
+}
+
+// Alias is similar to Value.Alias.
+func (j *JWT) Alias(name string) *JWT {
+	opChain := j.chain.enter("Alias(%q)", name)
+	defer opChain.leave()
+
+	j.chain.setAlias(name)
+	return j
+}
+
+// Chain returns public handle to assertion chain of JWT, which may be
+// used to implement custom matchers. See Chain for details.
+func (j *JWT) Chain() *Chain {
+	return &Chain{chain: j.chain}
+}
+
+// AsWarning is similar to Value.AsWarning.
+func (j *JWT) AsWarning() *JWT {
+	opChain := j.chain.enter("AsWarning()")
+	defer opChain.leave()
+
+	warnChain := j.chain.clone()
+	warnChain.setWarning()
+
+	return &JWT{
+		chain:     warnChain,
+		value:     j.value,
+		header:    j.header,
+		claims:    j.claims,
+		signed:    j.signed,
+		signature: j.signature,
+	}
+}
+
+// Header returns a new Object instance with decoded JOSE header.
+//
+// Example:
+//
+//	jwt := NewJWT(t, token)
+//	jwt.Header().Value("alg").IsEqual("RS256")
+func (j *JWT) Header() *Object {
+	opChain := j.chain.enter("Header()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newObject(opChain, nil)
+	}
+
+	return newObject(opChain, j.header)
+}
+
+// Claims returns a new Object instance with decoded claims set.
+//
+// Example:
+//
+//	jwt := NewJWT(t, token)
+//	jwt.Claims().Value("sub").IsEqual("john")
+//	jwt.Claims().ContainsKey("iss")
+func (j *JWT) Claims() *Object {
+	opChain := j.chain.enter("Claims()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newObject(opChain, nil)
+	}
+
+	return newObject(opChain, j.claims)
+}
+
+// ExpiresAt returns a new DateTime instance with "exp" claim.
+//
+// If claim is missing or is not a number, failure is reported.
+//
+// Example:
+//
+//	jwt := NewJWT(t, token)
+//	jwt.ExpiresAt().Gt(time.Now())
+func (j *JWT) ExpiresAt() *DateTime {
+	opChain := j.chain.enter("ExpiresAt()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newDateTime(opChain, time.Unix(0, 0))
+	}
+
+	return newDateTime(opChain, j.timeClaim(opChain, "exp"))
+}
+
+// IssuedAt returns a new DateTime instance with "iat" claim.
+//
+// If claim is missing or is not a number, failure is reported.
+//
+// Example:
+//
+//	jwt := NewJWT(t, token)
+//	jwt.IssuedAt().Le(time.Now())
+func (j *JWT) IssuedAt() *DateTime {
+	opChain := j.chain.enter("IssuedAt()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newDateTime(opChain, time.Unix(0, 0))
+	}
+
+	return newDateTime(opChain, j.timeClaim(opChain, "iat"))
+}
+
+func (j *JWT) timeClaim(opChain *chain, name string) time.Time {
+	value, ok := j.claims[name]
+	if !ok {
+		opChain.fail(AssertionFailure{
+			Type:     AssertContainsKey,
+			Actual:   &AssertionValue{j.claims},
+			Expected: &AssertionValue{name},
+			Errors: []error{
+				fmt.Errorf("expected: JWT contains %q claim", name),
+			},
+		})
+		return time.Unix(0, 0)
+	}
+
+	seconds, ok := value.(float64)
+	if !ok {
+		opChain.fail(AssertionFailure{
+			Type:   AssertType,
+			Actual: &AssertionValue{value},
+			Errors: []error{
+				fmt.Errorf("expected: %q claim is a numeric date", name),
+			},
+		})
+		return time.Unix(0, 0)
+	}
+
+	sec, frac := math.Modf(seconds)
+
+	return time.Unix(int64(sec), int64(frac*1e9))
+}
+
+// Verify succeeds if token signature is valid for given key.
+//
+// Supported algorithms and keys:
+//   - HS256, HS384, HS512: []byte or string with shared secret
+//   - RS256, RS384, RS512, PS256, PS384, PS512: *rsa.PublicKey
+//   - ES256, ES384, ES512: *ecdsa.PublicKey
+//   - EdDSA: ed25519.PublicKey
+//
+// Private keys (*rsa.PrivateKey, *ecdsa.PrivateKey, ed25519.PrivateKey) are
+// accepted as well; their public part is used. Algorithm "none" is never
+// accepted.
+//
+// Example:
+//
+//	jwt := NewJWT(t, token)
+//	jwt.Verify([]byte("secret"))
+func (j *JWT) Verify(key interface{}) *JWT {
+	opChain := j.chain.enter("Verify()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return j
+	}
+
+	if key == nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected nil key"),
+			},
+		})
+		return j
+	}
+
+	if err := jwtVerify(j.algorithm(), key, j.signed, j.signature); err != nil {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{j.value},
+			Errors: []error{
+				errors.New("expected: JWT signature is valid for given key"),
+				err,
+			},
+		})
+	}
+
+	return j
+}
+
+// VerifyJWKS succeeds if token signature is valid for a key from given
+// JSON Web Key Set (RFC 7517).
+//
+// If token header has "kid" parameter, only the key with the same "kid"
+// is used. Otherwise, every key of matching type is tried.
+//
+// Example:
+//
+//	jwt := NewJWT(t, token)
+//	jwt.VerifyJWKS(`{"keys": [{"kty": "EC", "crv": "P-256", "x": "...", "y": "..."}]}`)
+func (j *JWT) VerifyJWKS(jwks string) *JWT {
+	opChain := j.chain.enter("VerifyJWKS()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return j
+	}
+
+	keys, err := jwtParseJWKS(jwks)
+	if err != nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("invalid JWKS"),
+				err,
+			},
+		})
+		return j
+	}
+
+	kid, _ := j.header["kid"].(string)
+	alg := j.algorithm()
+
+	var errs []error
+
+	for _, k := range keys {
+		if kid != "" && k.kid != kid {
+			continue
+		}
+		if k.alg != "" && k.alg != alg {
+			continue
+		}
+		err := jwtVerify(alg, k.key, j.signed, j.signature)
+		if err == nil {
+			return j
+		}
+		errs = append(errs, err)
+	}
+
+	if len(errs) == 0 {
+		errs = append(errs, fmt.Errorf("no key found for kid %q and alg %q", kid, alg))
+	}
+
+	opChain.fail(AssertionFailure{
+		Type:   AssertValid,
+		Actual: &AssertionValue{j.value},
+		Errors: append([]error{
+			errors.New("expected: JWT signature is valid for a key from JWKS"),
+		}, errs...),
+	})
+
+	return j
+}
+
+func (j *JWT) algorithm() string {
+	alg, _ := j.header["alg"].(string)
+	return alg
+}
+
+func jwtVerify(alg string, key interface{}, signed, signature []byte) error {
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		key = &k.PublicKey
+	case *ecdsa.PrivateKey:
+		key = &k.PublicKey
+	case ed25519.PrivateKey:
+		key = k.Public()
+	case string:
+		key = []byte(k)
+	}
+
+	hashFn := func(h crypto.Hash) []byte {
+		hasher := h.New()
+		hasher.Write(signed)
+		return hasher.Sum(nil)
+	}
+
+	switch alg {
+	case "HS256", "HS384", "HS512":
+		secret, ok := key.([]byte)
+		if !ok {
+			return fmt.Errorf("algorithm %s requires []byte key, got %T", alg, key)
+		}
+		var hashNew func() hash.Hash
+		switch alg {
+		case "HS256":
+			hashNew = sha256.New
+		case "HS384":
+			hashNew = sha512.New384
+		default:
+			hashNew = sha512.New
+		}
+		mac := hmac.New(hashNew, secret)
+		mac.Write(signed)
+		if !hmac.Equal(mac.Sum(nil), signature) {
+			return errors.New("signature mismatch")
+		}
+		return nil
+
+	case "RS256", "RS384", "RS512", "PS256", "PS384", "PS512":
+		pub, ok := key.(*rsa.PublicKey)
+		if !ok {
+			return fmt.Errorf("algorithm %s requires *rsa.PublicKey, got %T", alg, key)
+		}
+		h := jwtHash(alg)
+		if alg[0] == 'P' {
+			return rsa.VerifyPSS(pub, h, hashFn(h), signature,
+				&rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthAuto})
+		}
+		return rsa.VerifyPKCS1v15(pub, h, hashFn(h), signature)
+
+	case "ES256", "ES384", "ES512":
+		pub, ok := key.(*ecdsa.PublicKey)
+		if !ok {
+			return fmt.Errorf("algorithm %s requires *ecdsa.PublicKey, got %T", alg, key)
+		}
+		size := (pub.Curve.Params().BitSize + 7) / 8
+		if len(signature) != 2*size {
+			return errors.New("invalid signature length")
+		}
+		r := new(big.Int).SetBytes(signature[:size])
+		s := new(big.Int).SetBytes(signature[size:])
+		if !ecdsa.Verify(pub, hashFn(jwtHash(alg)), r, s) {
+			return errors.New("signature mismatch")
+		}
+		return nil
+
+	case "EdDSA":
+		pub, ok := key.(ed25519.PublicKey)
+		if !ok {
+			return fmt.Errorf("algorithm %s requires ed25519.PublicKey, got %T", alg, key)
+		}
+		if !ed25519.Verify(pub, signed, signature) {
+			return errors.New("signature mismatch")
+		}
+		return nil
+
+	case "", "none":
+		return fmt.Errorf("unsecured JWT (alg %q) can't be verified", alg)
+
+	default:
+		return fmt.Errorf("unsupported algorithm %q", alg)
+	}
+}
+
+func jwtHash(alg string) crypto.Hash {
+	switch alg[2:] {
+	case "384":
+		return crypto.SHA384
+	case "512":
+		return crypto.SHA512
+	default:
+		return crypto.SHA256
+	}
+}
+
+type jwtKey struct {
+	kid string
+	alg string
+	key interface{}
+}
+
+func jwtParseJWKS(jwks string) ([]jwtKey, error) {
+	var set struct {
+		Keys []struct {
+			Kty string `json:"kty"`
+			Kid string `json:"kid"`
+			Alg string `json:"alg"`
+			Crv string `json:"crv"`
+			N   string `json:"n"`
+			E   string `json:"e"`
+			X   string `json:"x"`
+			Y   string `json:"y"`
+			K   string `json:"k"`
+		} `json:"keys"`
+	}
+
+	if err := json.Unmarshal([]byte(jwks), &set); err != nil {
+		return nil, err
+	}
+
+	var keys []jwtKey
+
+	for n, k := range set.Keys {
+		var (
+			key interface{}
+			err error
+		)
+
+		switch k.Kty {
+		case "RSA":
+			key, err = jwtParseRSAKey(k.N, k.E)
+		case "EC":
+			key, err = jwtParseECKey(k.Crv, k.X, k.Y)
+		case "OKP":
+			key, err = jwtParseOKPKey(k.Crv, k.X)
+		case "oct":
+			key, err = base64.RawURLEncoding.DecodeString(k.K)
+		default:
+			err = fmt.Errorf("unsupported key type %q", k.Kty)
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("key %d: %w", n, err)
+		}
+
+		keys = append(keys, jwtKey{kid: k.Kid, alg: k.Alg, key: key})
+	}
+
+	return keys, nil
+}
+
+func jwtParseRSAKey(n, e string) (*rsa.PublicKey, error) {
+	nBytes, err := base64.RawURLEncoding.DecodeString(n)
+	if err != nil {
+		return nil, err
+	}
+
+	eBytes, err := base64.RawURLEncoding.DecodeString(e)
+	if err != nil {
+		return nil, err
+	}
+
+	exp := new(big.Int).SetBytes(eBytes)
+	if !exp.IsInt64() || exp.Int64() > math.MaxInt32 {
+		return nil, errors.New("invalid RSA exponent")
+	}
+
+	return &rsa.PublicKey{
+		N: new(big.Int).SetBytes(nBytes),
+		E: int(exp.Int64()),
+	}, nil
+}
+
+func jwtParseECKey(crv, x, y string) (*ecdsa.PublicKey, error) {
+	var curve elliptic.Curve
+
+	switch crv {
+	case "P-256":
+		curve = elliptic.P256()
+	case "P-384":
+		curve = elliptic.P384()
+	case "P-521":
+		curve = elliptic.P521()
+	default:
+		return nil, fmt.Errorf("unsupported curve %q", crv)
+	}
+
+	xBytes, err := base64.RawURLEncoding.DecodeString(x)
+	if err != nil {
+		return nil, err
+	}
+
+	yBytes, err := base64.RawURLEncoding.DecodeString(y)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ecdsa.PublicKey{
+		Curve: curve,
+		X:     new(big.Int).SetBytes(xBytes),
+		Y:     new(big.Int).SetBytes(yBytes),
+	}, nil
+}
+
+func jwtParseOKPKey(crv, x string) (ed25519.PublicKey, error) {
+	if crv != "Ed25519" {
+		return nil, fmt.Errorf("unsupported curve %q", crv)
+	}
+
+	xBytes, err := base64.RawURLEncoding.DecodeString(x)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(xBytes) != ed25519.PublicKeySize {
+		return nil, errors.New("invalid Ed25519 key size")
+	}
+
+	return ed25519.PublicKey(xBytes), nil
+}
@@ -0,0 +1,459 @@
+package httpexpect
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func signTestJWT(
+	t *testing.T, header, claims map[string]interface{}, key interface{},
+) string {
+	encode := func(v interface{}) string {
+		b, err := json.Marshal(v)
+		require.NoError(t, err)
+		return base64.RawURLEncoding.EncodeToString(b)
+	}
+
+	signed := encode(header) + "." + encode(claims)
+	alg, _ := header["alg"].(string)
+
+	digest := func(h crypto.Hash) []byte {
+		hasher := h.New()
+		hasher.Write([]byte(signed))
+		return hasher.Sum(nil)
+	}
+
+	var (
+		sig []byte
+		err error
+	)
+
+	switch k := key.(type) {
+	case []byte:
+		mac := hmac.New(jwtHash(alg).New, k)
+		mac.Write([]byte(signed))
+		sig = mac.Sum(nil)
+
+	case *rsa.PrivateKey:
+		if alg[0] == 'P' {
+			sig, err = rsa.SignPSS(rand.Reader, k, jwtHash(alg), digest(jwtHash(alg)),
+				&rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash})
+		} else {
+			sig, err = rsa.SignPKCS1v15(rand.Reader, k, jwtHash(alg), digest(jwtHash(alg)))
+		}
+
+	case *ecdsa.PrivateKey:
+		var r, s *big.Int
+		r, s, err = ecdsa.Sign(rand.Reader, k, digest(jwtHash(alg)))
+		size := (k.Curve.Params().BitSize + 7) / 8
+		sig = make([]byte, 2*size)
+		copy(sig[size-len(r.Bytes()):size], r.Bytes())
+		copy(sig[2*size-len(s.Bytes()):], s.Bytes())
+
+	case ed25519.PrivateKey:
+		sig = ed25519.Sign(k, []byte(signed))
+
+	case nil:
+		sig = []byte{}
+	}
+
+	require.NoError(t, err)
+
+	return signed + "." + base64.RawURLEncoding.EncodeToString(sig)
+}
+
+func TestJWT_FailedChain(t *testing.T) {
+	check := func(value *JWT) {
+		value.chain.assertFailed(t)
+
+		value.Alias("foo")
+
+		value.Header().chain.assertFailed(t)
+		value.Claims().chain.assertFailed(t)
+		value.ExpiresAt().chain.assertFailed(t)
+		value.IssuedAt().chain.assertFailed(t)
+
+		value.Verify([]byte("secret"))
+		value.VerifyJWKS(`{"keys": []}`)
+	}
+
+	t.Run("failed chain", func(t *testing.T) {
+		chain := newMockChain(t)
+		chain.setFailed()
+
+		value := newJWT(chain, signTestJWT(t,
+			map[string]interface{}{"alg": "HS256"},
+			map[string]interface{}{"sub": "john"},
+			[]byte("secret")))
+
+		check(value)
+	})
+
+	t.Run("invalid value", func(t *testing.T) {
+		chain := newMockChain(t)
+
+		value := newJWT(chain, "bad")
+
+		check(value)
+	})
+}
+
+func TestJWT_Constructors(t *testing.T) {
+	token := signTestJWT(t,
+		map[string]interface{}{"alg": "HS256"},
+		map[string]interface{}{"sub": "john"},
+		[]byte("secret"))
+
+	t.Run("reporter", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		value := NewJWT(reporter, token)
+		value.Claims().Value("sub").IsEqual("john")
+		value.chain.assertNotFailed(t)
+	})
+
+	t.Run("config", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		value := NewJWTC(Config{
+			Reporter: reporter,
+		}, token)
+		value.Claims().Value("sub").IsEqual("john")
+		value.chain.assertNotFailed(t)
+	})
+
+	t.Run("chain", func(t *testing.T) {
+		chain := newMockChain(t)
+		value := newJWT(chain, token)
+		assert.NotSame(t, value.chain, chain)
+		assert.Equal(t, value.chain.context.Path, chain.context.Path)
+	})
+}
+
+func TestJWT_Alias(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	value := NewJWT(reporter, signTestJWT(t,
+		map[string]interface{}{"alg": "HS256"},
+		map[string]interface{}{"sub": "john"},
+		[]byte("secret")))
+	assert.Equal(t, []string{"JWT()"}, value.chain.context.Path)
+	assert.Equal(t, []string{"JWT()"}, value.chain.context.AliasedPath)
+
+	value.Alias("foo")
+	assert.Equal(t, []string{"JWT()"}, value.chain.context.Path)
+	assert.Equal(t, []string{"foo"}, value.chain.context.AliasedPath)
+
+	childValue := value.Claims()
+	assert.Equal(t, []string{"JWT()", "Claims()"}, childValue.chain.context.Path)
+	assert.Equal(t, []string{"foo", "Claims()"}, childValue.chain.context.AliasedPath)
+}
+
+func TestJWT_Decode(t *testing.T) {
+	cases := []struct {
+		name  string
+		token string
+		fail  bool
+	}{
+		{
+			name:  "valid",
+			token: "eyJhbGciOiJIUzI1NiJ9.eyJzdWIiOiJqb2huIn0.c2ln",
+			fail:  false,
+		},
+		{
+			name:  "empty signature",
+			token: "eyJhbGciOiJub25lIn0.eyJzdWIiOiJqb2huIn0.",
+			fail:  false,
+		},
+		{
+			name:  "two parts",
+			token: "eyJhbGciOiJIUzI1NiJ9.eyJzdWIiOiJqb2huIn0",
+			fail:  true,
+		},
+		{
+			name:  "bad header base64",
+			token: "!!!.eyJzdWIiOiJqb2huIn0.c2ln",
+			fail:  true,
+		},
+		{
+			name:  "bad claims json",
+			token: "eyJhbGciOiJIUzI1NiJ9.bm90IGpzb24.c2ln",
+			fail:  true,
+		},
+		{
+			name:  "bad signature base64",
+			token: "eyJhbGciOiJIUzI1NiJ9.eyJzdWIiOiJqb2huIn0.!!!",
+			fail:  true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			reporter := newMockReporter(t)
+			value := NewJWT(reporter, tc.token)
+
+			if tc.fail {
+				value.chain.assertFailed(t)
+			} else {
+				value.chain.assertNotFailed(t)
+			}
+		})
+	}
+}
+
+func TestJWT_Getters(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	value := NewJWT(reporter, signTestJWT(t,
+		map[string]interface{}{"alg": "HS256", "typ": "JWT", "kid": "k1"},
+		map[string]interface{}{"sub": "john", "iat": 1000, "exp": 2000.5},
+		[]byte("secret")))
+
+	value.Header().IsEqual(map[string]interface{}{
+		"alg": "HS256",
+		"typ": "JWT",
+		"kid": "k1",
+	})
+	value.Claims().Value("sub").IsEqual("john")
+
+	value.IssuedAt().IsEqual(time.Unix(1000, 0))
+	value.ExpiresAt().IsEqual(time.Unix(2000, 500000000))
+
+	value.chain.assertNotFailed(t)
+
+	t.Run("missing claim", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		value := NewJWT(reporter, signTestJWT(t,
+			map[string]interface{}{"alg": "HS256"},
+			map[string]interface{}{"sub": "john"},
+			[]byte("secret")))
+
+		value.ExpiresAt().chain.assertFailed(t)
+		value.chain.assertFailed(t)
+	})
+
+	t.Run("non-numeric claim", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		value := NewJWT(reporter, signTestJWT(t,
+			map[string]interface{}{"alg": "HS256"},
+			map[string]interface{}{"iat": "yesterday"},
+			[]byte("secret")))
+
+		value.IssuedAt().chain.assertFailed(t)
+		value.chain.assertFailed(t)
+	})
+}
+
+func TestJWT_Verify(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	ecKey256, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	ecKey521, err := ecdsa.GenerateKey(elliptic.P521(), rand.Reader)
+	require.NoError(t, err)
+
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	otherRSAKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	cases := []struct {
+		alg       string
+		signKey   interface{}
+		verifyKey interface{}
+		wrongKey  interface{}
+	}{
+		{"HS256", []byte("secret"), "secret", []byte("other")},
+		{"HS384", []byte("secret"), []byte("secret"), []byte("other")},
+		{"HS512", []byte("secret"), []byte("secret"), []byte("other")},
+		{"RS256", rsaKey, &rsaKey.PublicKey, &otherRSAKey.PublicKey},
+		{"RS512", rsaKey, rsaKey, &otherRSAKey.PublicKey},
+		{"PS256", rsaKey, &rsaKey.PublicKey, &otherRSAKey.PublicKey},
+		{"ES256", ecKey256, &ecKey256.PublicKey, []byte("secret")},
+		{"ES512", ecKey521, &ecKey521.PublicKey, &ecKey256.PublicKey},
+		{"EdDSA", edKey, edKey.Public(), &rsaKey.PublicKey},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.alg, func(t *testing.T) {
+			token := signTestJWT(t,
+				map[string]interface{}{"alg": tc.alg},
+				map[string]interface{}{"sub": "john"},
+				tc.signKey)
+
+			reporter := newMockReporter(t)
+
+			NewJWT(reporter, token).
+				Verify(tc.verifyKey).
+				chain.assertNotFailed(t)
+
+			NewJWT(reporter, token).
+				Verify(tc.wrongKey).
+				chain.assertFailed(t)
+		})
+	}
+
+	t.Run("none", func(t *testing.T) {
+		token := signTestJWT(t,
+			map[string]interface{}{"alg": "none"},
+			map[string]interface{}{"sub": "john"},
+			nil)
+
+		reporter := newMockReporter(t)
+
+		NewJWT(reporter, token).
+			Verify([]byte("")).
+			chain.assertFailed(t)
+	})
+
+	t.Run("tampered", func(t *testing.T) {
+		token := signTestJWT(t,
+			map[string]interface{}{"alg": "HS256"},
+			map[string]interface{}{"sub": "john"},
+			[]byte("secret"))
+
+		other := signTestJWT(t,
+			map[string]interface{}{"alg": "HS256"},
+			map[string]interface{}{"sub": "admin"},
+			[]byte("other"))
+
+		tampered := token[:len(token)-43] + other[len(other)-43:]
+
+		reporter := newMockReporter(t)
+
+		NewJWT(reporter, tampered).
+			Verify([]byte("secret")).
+			chain.assertFailed(t)
+	})
+
+	t.Run("nil key", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		NewJWT(reporter, "eyJhbGciOiJIUzI1NiJ9.eyJzdWIiOiJqb2huIn0.c2ln").
+			Verify(nil).
+			chain.assertFailed(t)
+	})
+}
+
+func TestJWT_VerifyJWKS(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	edPub, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	b64 := base64.RawURLEncoding.EncodeToString
+
+	jwks := fmt.Sprintf(`{"keys": [
+		{"kty": "RSA", "kid": "rsa", "n": %q, "e": %q},
+		{"kty": "EC", "kid": "ec", "crv": "P-256", "x": %q, "y": %q},
+		{"kty": "OKP", "kid": "ed", "crv": "Ed25519", "x": %q},
+		{"kty": "oct", "kid": "hmac", "alg": "HS256", "k": %q}
+	]}`,
+		b64(rsaKey.N.Bytes()), b64(big.NewInt(int64(rsaKey.E)).Bytes()),
+		b64(ecKey.X.Bytes()), b64(ecKey.Y.Bytes()),
+		b64(edPub),
+		b64([]byte("secret")))
+
+	cases := []struct {
+		name    string
+		header  map[string]interface{}
+		signKey interface{}
+		fail    bool
+	}{
+		{
+			name:    "rsa by kid",
+			header:  map[string]interface{}{"alg": "RS256", "kid": "rsa"},
+			signKey: rsaKey,
+		},
+		{
+			name:    "ec by kid",
+			header:  map[string]interface{}{"alg": "ES256", "kid": "ec"},
+			signKey: ecKey,
+		},
+		{
+			name:    "ed without kid",
+			header:  map[string]interface{}{"alg": "EdDSA"},
+			signKey: edKey,
+		},
+		{
+			name:    "hmac by kid",
+			header:  map[string]interface{}{"alg": "HS256", "kid": "hmac"},
+			signKey: []byte("secret"),
+		},
+		{
+			name:    "wrong kid",
+			header:  map[string]interface{}{"alg": "RS256", "kid": "ec"},
+			signKey: rsaKey,
+			fail:    true,
+		},
+		{
+			name:    "unknown kid",
+			header:  map[string]interface{}{"alg": "RS256", "kid": "missing"},
+			signKey: rsaKey,
+			fail:    true,
+		},
+		{
+			name:    "alg mismatch",
+			header:  map[string]interface{}{"alg": "HS384", "kid": "hmac"},
+			signKey: []byte("secret"),
+			fail:    true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			token := signTestJWT(t, tc.header,
+				map[string]interface{}{"sub": "john"}, tc.signKey)
+
+			reporter := newMockReporter(t)
+
+			value := NewJWT(reporter, token).VerifyJWKS(jwks)
+
+			if tc.fail {
+				value.chain.assertFailed(t)
+			} else {
+				value.chain.assertNotFailed(t)
+			}
+		})
+	}
+
+	t.Run("invalid jwks", func(t *testing.T) {
+		token := signTestJWT(t,
+			map[string]interface{}{"alg": "HS256"},
+			map[string]interface{}{"sub": "john"},
+			[]byte("secret"))
+
+		for _, jwks := range []string{
+			`bad`,
+			`{"keys": [{"kty": "XYZ"}]}`,
+			`{"keys": [{"kty": "EC", "crv": "P-192"}]}`,
+		} {
+			reporter := newMockReporter(t)
+
+			NewJWT(reporter, token).
+				VerifyJWKS(jwks).
+				chain.assertFailed(t)
+		}
+	})
+}
@@ -0,0 +1,409 @@
+package httpexpect
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// Links provides methods to inspect links from "Link" header (RFC 8288).
+type Links struct {
+	noCopy noCopy
+	chain  *chain
+	value  []webLink
+}
+
+type webLink struct {
+	target *url.URL
+	rels   []string
+	params map[string]string
+}
+
+func newLinks(parent *chain, val []webLink) *Links {
+	return &Links{chain: parent.clone(), value: val}
+}
+
+// Alias is similar to Value.Alias.
+func (l *Links) Alias(name string) *Links {
+	opChain := l.chain.enter("Alias(%q)", name)
+	defer opChain.leave()
+
+	l.chain.setAlias(name)
+	return l
+}
+
+// Chain returns public handle to assertion chain of Links, which may be
+// used to implement custom matchers. See Chain for details.
+func (l *Links) Chain() *Chain {
+	return &Chain{chain: l.chain}
+}
+
+// AsWarning is similar to Value.AsWarning.
+func (l *Links) AsWarning() *Links {
+	opChain := l.chain.enter("AsWarning()")
+	defer opChain.leave()
+
+	warnChain := l.chain.clone()
+	warnChain.setWarning()
+
+	return &Links{
+		chain: warnChain,
+		value: l.value,
+	}
+}
+
+// Rels returns a new Array instance with sorted list of distinct relation
+// types of all links.
+//
+// Example:
+//
+//	links := resp.Links()
+//	links.Rels().ConsistsOf("first", "next", "last")
+func (l *Links) Rels() *Array {
+	opChain := l.chain.enter("Rels()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newArray(opChain, nil)
+	}
+
+	seen := map[string]bool{}
+	for _, link := range l.value {
+		for _, rel := range link.rels {
+			seen[rel] = true
+		}
+	}
+
+	rels := []string{}
+	for rel := range seen {
+		rels = append(rels, rel)
+	}
+	sort.Strings(rels)
+
+	values := []interface{}{}
+	for _, rel := range rels {
+		values = append(values, rel)
+	}
+
+	return newArray(opChain, values)
+}
+
+// HasRel succeeds if there is a link with given relation type.
+//
+// Relation types are compared case-insensitively.
+//
+// Example:
+//
+//	links := resp.Links()
+//	links.HasRel("next")
+func (l *Links) HasRel(rel string) *Links {
+	opChain := l.chain.enter("HasRel(%q)", rel)
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return l
+	}
+
+	if l.find(rel) == nil {
+		opChain.fail(AssertionFailure{
+			Type:     AssertContainsKey,
+			Actual:   &AssertionValue{l.rels()},
+			Expected: &AssertionValue{rel},
+			Errors: []error{
+				errors.New("expected: links contain given relation type"),
+			},
+		})
+	}
+
+	return l
+}
+
+// NotHasRel succeeds if there is no link with given relation type.
+//
+// Relation types are compared case-insensitively.
+//
+// Example:
+//
+//	links := resp.Links()
+//	links.NotHasRel("prev")
+func (l *Links) NotHasRel(rel string) *Links {
+	opChain := l.chain.enter("NotHasRel(%q)", rel)
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return l
+	}
+
+	if l.find(rel) != nil {
+		opChain.fail(AssertionFailure{
+			Type:     AssertNotContainsKey,
+			Actual:   &AssertionValue{l.rels()},
+			Expected: &AssertionValue{rel},
+			Errors: []error{
+				errors.New("expected: links do not contain given relation type"),
+			},
+		})
+	}
+
+	return l
+}
+
+// Rel returns a new URL instance with target of the first link with given
+// relation type. Relative targets are resolved against request URL.
+//
+// If there is no such link, failure is reported.
+//
+// Example:
+//
+//	links := resp.Links()
+//	links.Rel("next").Query().Value("page").Array().ConsistsOf("2")
+func (l *Links) Rel(rel string) *URL {
+	opChain := l.chain.enter("Rel(%q)", rel)
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newURL(opChain, "")
+	}
+
+	link := l.find(rel)
+	if link == nil {
+		opChain.fail(AssertionFailure{
+			Type:     AssertContainsKey,
+			Actual:   &AssertionValue{l.rels()},
+			Expected: &AssertionValue{rel},
+			Errors: []error{
+				errors.New("expected: links contain given relation type"),
+			},
+		})
+		return newURL(opChain, "")
+	}
+
+	return newURLFromValue(opChain, link.target)
+}
+
+// Params returns a new Object instance with target attributes of the first
+// link with given relation type, e.g. "title" or "type".
+//
+// Attribute names are lower-cased. "rel" attribute is not included.
+// If there is no such link, failure is reported.
+//
+// Example:
+//
+//	links := resp.Links()
+//	links.Params("alternate").Value("type").IsEqual("application/atom+xml")
+func (l *Links) Params(rel string) *Object {
+	opChain := l.chain.enter("Params(%q)", rel)
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newObject(opChain, nil)
+	}
+
+	link := l.find(rel)
+	if link == nil {
+		opChain.fail(AssertionFailure{
+			Type:     AssertContainsKey,
+			Actual:   &AssertionValue{l.rels()},
+			Expected: &AssertionValue{rel},
+			Errors: []error{
+				errors.New("expected: links contain given relation type"),
+			},
+		})
+		return newObject(opChain, nil)
+	}
+
+	params := map[string]interface{}{}
+	for k, v := range link.params {
+		params[k] = v
+	}
+
+	return newObject(opChain, params)
+}
+
+func (l *Links) find(rel string) *webLink {
+	return findWebLink(l.value, rel)
+}
+
+func (l *Links) rels() []string {
+	rels := []string{}
+	for _, link := range l.value {
+		rels = append(rels, link.rels...)
+	}
+	return rels
+}
+
+func findWebLink(links []webLink, rel string) *webLink {
+	for n := range links {
+		for _, r := range links[n].rels {
+			if strings.EqualFold(r, rel) {
+				return &links[n]
+			}
+		}
+	}
+	return nil
+}
+
+// Parse values of "Link" header fields.
+// Relative targets are resolved against base, if it's non-nil.
+func parseWebLinks(values []string, base *url.URL) ([]webLink, error) {
+	links := []webLink{}
+
+	for _, value := range values {
+		p := headerParser{input: value}
+
+		for {
+			p.skipSpaceAnd(',')
+			if p.done() {
+				break
+			}
+
+			link, err := p.parseLink()
+			if err != nil {
+				return nil, err
+			}
+
+			if base != nil {
+				link.target = base.ResolveReference(link.target)
+			}
+
+			links = append(links, link)
+		}
+	}
+
+	return links, nil
+}
+
+type headerParser struct {
+	input string
+	pos   int
+}
+
+func (p *headerParser) done() bool {
+	return p.pos >= len(p.input)
+}
+
+func (p *headerParser) peek() byte {
+	if p.done() {
+		return 0
+	}
+	return p.input[p.pos]
+}
+
+func (p *headerParser) skipSpaceAnd(extra byte) {
+	for !p.done() {
+		c := p.input[p.pos]
+		if c != ' ' && c != '\t' && c != extra {
+			return
+		}
+		p.pos++
+	}
+}
+
+func (p *headerParser) parseLink() (webLink, error) {
+	link := webLink{params: map[string]string{}}
+
+	if p.peek() != '<' {
+		return link, fmt.Errorf("expected '<' at position %d in %q", p.pos, p.input)
+	}
+	p.pos++
+
+	end := strings.IndexByte(p.input[p.pos:], '>')
+	if end < 0 {
+		return link, fmt.Errorf("unterminated link target in %q", p.input)
+	}
+
+	target, err := url.Parse(strings.TrimSpace(p.input[p.pos : p.pos+end]))
+	if err != nil {
+		return link, err
+	}
+	link.target = target
+	p.pos += end + 1
+
+	for {
+		p.skipSpaceAnd(0)
+		if p.done() || p.peek() == ',' {
+			break
+		}
+		if p.peek() != ';' {
+			return link, fmt.Errorf("expected ';' at position %d in %q", p.pos, p.input)
+		}
+		p.pos++
+		p.skipSpaceAnd(0)
+
+		name := p.parseToken()
+		if name == "" {
+			return link, fmt.Errorf("expected parameter name at position %d in %q",
+				p.pos, p.input)
+		}
+		name = strings.ToLower(strings.TrimSuffix(name, "*"))
+
+		var value string
+
+		p.skipSpaceAnd(0)
+		if p.peek() == '=' {
+			p.pos++
+			p.skipSpaceAnd(0)
+
+			if p.peek() == '"' {
+				value, err = p.parseQuoted()
+				if err != nil {
+					return link, err
+				}
+			} else {
+				value = p.parseToken()
+			}
+		}
+
+		if name == "rel" {
+			// first occurrence wins, see RFC 8288, section 3.3
+			if link.rels == nil {
+				link.rels = strings.Fields(value)
+			}
+		} else if _, ok := link.params[name]; !ok {
+			link.params[name] = value
+		}
+	}
+
+	return link, nil
+}
+
+const headerDelimiters = ";,= \t\"()"
+
+func (p *headerParser) parseToken() string {
+	start := p.pos
+	for !p.done() {
+		c := p.input[p.pos]
+		if strings.IndexByte(headerDelimiters, c) >= 0 {
+			break
+		}
+		p.pos++
+	}
+	return p.input[start:p.pos]
+}
+
+func (p *headerParser) parseQuoted() (string, error) {
+	var sb strings.Builder
+
+	p.pos++ // opening quote
+
+	for !p.done() {
+		c := p.input[p.pos]
+		p.pos++
+
+		switch c {
+		case '\\':
+			if !p.done() {
+				sb.WriteByte(p.input[p.pos])
+				p.pos++
+			}
+		case '"':
+			return sb.String(), nil
+		default:
+			sb.WriteByte(c)
+		}
+	}
+
+	return "", fmt.Errorf("unterminated quoted string in %q", p.input)
+}
@@ -0,0 +1,255 @@
+package httpexpect
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLinks_FailedChain(t *testing.T) {
+	chain := newMockChain(t)
+	chain.setFailed()
+
+	value := newLinks(chain, nil)
+	value.chain.assertFailed(t)
+
+	value.Alias("foo")
+
+	value.Rels().chain.assertFailed(t)
+	value.HasRel("next")
+	value.NotHasRel("next")
+	value.Rel("next").chain.assertFailed(t)
+	value.Params("next").chain.assertFailed(t)
+}
+
+func TestLinks_Alias(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	parent := newChainWithDefaults("Links()", reporter)
+	value := newLinks(parent, nil)
+	assert.Equal(t, []string{"Links()"}, value.chain.context.Path)
+	assert.Equal(t, []string{"Links()"}, value.chain.context.AliasedPath)
+
+	value.Alias("foo")
+	assert.Equal(t, []string{"Links()"}, value.chain.context.Path)
+	assert.Equal(t, []string{"foo"}, value.chain.context.AliasedPath)
+
+	childValue := value.Rels()
+	assert.Equal(t, []string{"Links()", "Rels()"}, childValue.chain.context.Path)
+	assert.Equal(t, []string{"foo", "Rels()"}, childValue.chain.context.AliasedPath)
+}
+
+func TestLinks_Rels(t *testing.T) {
+	links, err := parseWebLinks([]string{
+		`<http://example.com/2>; rel="next last", <http://example.com/1>; rel=first`,
+	}, nil)
+	require.NoError(t, err)
+
+	chain := newMockChain(t)
+	value := newLinks(chain, links)
+
+	value.Rels().IsEqual([]interface{}{"first", "last", "next"})
+	value.chain.assertNotFailed(t)
+
+	empty := newLinks(chain, nil)
+
+	empty.Rels().IsEmpty()
+	empty.chain.assertNotFailed(t)
+}
+
+func TestLinks_HasRel(t *testing.T) {
+	links, err := parseWebLinks([]string{
+		`<http://example.com/2>; rel=next`,
+	}, nil)
+	require.NoError(t, err)
+
+	cases := []struct {
+		name      string
+		rel       string
+		hasFailed bool
+		notFailed bool
+	}{
+		{name: "exact", rel: "next", hasFailed: false, notFailed: true},
+		{name: "case", rel: "NEXT", hasFailed: false, notFailed: true},
+		{name: "missing", rel: "prev", hasFailed: true, notFailed: false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			value := newLinks(newMockChain(t), links)
+			value.HasRel(tc.rel)
+			if tc.hasFailed {
+				value.chain.assertFailed(t)
+			} else {
+				value.chain.assertNotFailed(t)
+			}
+
+			value = newLinks(newMockChain(t), links)
+			value.NotHasRel(tc.rel)
+			if tc.notFailed {
+				value.chain.assertFailed(t)
+			} else {
+				value.chain.assertNotFailed(t)
+			}
+		})
+	}
+}
+
+func TestLinks_Rel(t *testing.T) {
+	base, _ := url.Parse("http://example.com/items?page=1")
+
+	links, err := parseWebLinks([]string{
+		`</items?page=2>; rel="next"; title="Next page"; type=application/json`,
+		`<http://other.com/items>; rel=alternate`,
+	}, base)
+	require.NoError(t, err)
+
+	t.Run("relative", func(t *testing.T) {
+		value := newLinks(newMockChain(t), links)
+
+		u := value.Rel("next")
+		u.Host().IsEqual("example.com")
+		u.Path().IsEqual("/items")
+		u.Query().Value("page").Array().IsEqual([]interface{}{"2"})
+
+		value.chain.assertNotFailed(t)
+	})
+
+	t.Run("absolute", func(t *testing.T) {
+		value := newLinks(newMockChain(t), links)
+
+		value.Rel("alternate").Host().IsEqual("other.com")
+
+		value.chain.assertNotFailed(t)
+	})
+
+	t.Run("params", func(t *testing.T) {
+		value := newLinks(newMockChain(t), links)
+
+		value.Params("next").IsEqual(map[string]interface{}{
+			"title": "Next page",
+			"type":  "application/json",
+		})
+		value.Params("alternate").IsEmpty()
+
+		value.chain.assertNotFailed(t)
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		value := newLinks(newMockChain(t), links)
+		value.Rel("prev").chain.assertFailed(t)
+		value.chain.assertFailed(t)
+
+		value = newLinks(newMockChain(t), links)
+		value.Params("prev").chain.assertFailed(t)
+		value.chain.assertFailed(t)
+	})
+}
+
+func TestLinks_Parse(t *testing.T) {
+	type linkTestResult struct {
+		target string
+		rels   []string
+		params map[string]string
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		cases := []struct {
+			name   string
+			values []string
+			result []linkTestResult
+		}{
+			{
+				name:   "empty",
+				values: nil,
+				result: []linkTestResult{},
+			},
+			{
+				name:   "single",
+				values: []string{`<http://a/1>; rel="next"`},
+				result: []linkTestResult{
+					{target: "http://a/1", rels: []string{"next"}},
+				},
+			},
+			{
+				name:   "multiple in one header",
+				values: []string{`<http://a/1>; rel=prev, <http://a/3>; rel=next`},
+				result: []linkTestResult{
+					{target: "http://a/1", rels: []string{"prev"}},
+					{target: "http://a/3", rels: []string{"next"}},
+				},
+			},
+			{
+				name:   "multiple headers",
+				values: []string{`<http://a/1>; rel=prev`, `<http://a/3>; rel=next`},
+				result: []linkTestResult{
+					{target: "http://a/1", rels: []string{"prev"}},
+					{target: "http://a/3", rels: []string{"next"}},
+				},
+			},
+			{
+				name:   "multiple rels",
+				values: []string{`<http://a/3>; rel="next  last"`},
+				result: []linkTestResult{
+					{target: "http://a/3", rels: []string{"next", "last"}},
+				},
+			},
+			{
+				name:   "quoted params",
+				values: []string{`<http://a/1>;rel=x;title="a, b; \"c\"",<http://a/2>`},
+				result: []linkTestResult{
+					{target: "http://a/1", rels: []string{"x"},
+						params: map[string]string{"title": `a, b; "c"`}},
+					{target: "http://a/2"},
+				},
+			},
+			{
+				name:   "first rel wins",
+				values: []string{`<http://a/1>; rel=first; REL=second; Title*=t`},
+				result: []linkTestResult{
+					{target: "http://a/1", rels: []string{"first"},
+						params: map[string]string{"title": "t"}},
+				},
+			},
+		}
+
+		for _, tc := range cases {
+			t.Run(tc.name, func(t *testing.T) {
+				links, err := parseWebLinks(tc.values, nil)
+				require.NoError(t, err)
+				require.Equal(t, len(tc.result), len(links))
+
+				for n, expected := range tc.result {
+					assert.Equal(t, expected.target, links[n].target.String())
+					assert.Equal(t, expected.rels, links[n].rels)
+
+					params := expected.params
+					if params == nil {
+						params = map[string]string{}
+					}
+					assert.Equal(t, params, links[n].params)
+				}
+			})
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		cases := []string{
+			`http://a/1; rel=next`,
+			`<http://a/1; rel=next`,
+			`<http://a/1> rel=next`,
+			`<http://a/1>; ="next"`,
+			`<http://a/1>; title="unterminated`,
+			`<http://[::1>; rel=next`,
+		}
+
+		for _, value := range cases {
+			t.Run(value, func(t *testing.T) {
+				_, err := parseWebLinks([]string{value}, nil)
+				assert.Error(t, err)
+			})
+		}
+	})
+}
@@ -86,6 +86,27 @@ func (m *Match) Alias(name string) *Match {
 	return m
 }
 
+// Chain returns public handle to assertion chain of Match, which may be
+// used to implement custom matchers. See Chain for details.
+func (m *Match) Chain() *Chain {
+	return &Chain{chain: m.chain}
+}
+
+// AsWarning is similar to Value.AsWarning.
+func (m *Match) AsWarning() *Match {
+	opChain := m.chain.enter("AsWarning()")
+	defer opChain.leave()
+
+	warnChain := m.chain.clone()
+	warnChain.setWarning()
+
+	return &Match{
+		chain:      warnChain,
+		submatches: m.submatches,
+		names:      m.names,
+	}
+}
+
 // Length returns a new Number instance with number of submatches.
 //
 // Example:
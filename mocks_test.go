@@ -195,9 +195,10 @@ func (l *mockLogger) Logf(message string, args ...interface{}) {
 }
 
 type mockReporter struct {
-	testing  *testing.T
-	reported bool
-	reportCb func()
+	testing     *testing.T
+	reported    bool
+	lastMessage string
+	reportCb    func()
 }
 
 func newMockReporter(t *testing.T) *mockReporter {
@@ -207,6 +208,7 @@ func newMockReporter(t *testing.T) *mockReporter {
 func (r *mockReporter) Errorf(message string, args ...interface{}) {
 	r.testing.Logf("Fail: "+message, args...)
 	r.reported = true
+	r.lastMessage = fmt.Sprintf(message, args...)
 
 	if r.reportCb != nil {
 		r.reportCb()
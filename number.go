@@ -1,18 +1,31 @@
 package httpexpect
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"math"
 	"math/big"
+	"strconv"
 )
 
 // Number provides methods to inspect attached float64 value
 // (Go representation of JSON number).
+//
+// If number was decoded without loss of precision (see ContentOpts),
+// Number additionally keeps its exact value, which is used by
+// IsEqualExact, NotEqualExact, IsInt, NotInt, IsUint, NotUint, and Decode.
+//
+// Comparison methods, like IsEqual, Gt, or InRange, convert their arguments
+// to canonical form: float64, or exact value if argument can't be represented
+// by float64 without loss of precision (e.g. uint64 above 2^53, json.Number,
+// or *big.Int). If either side has exact value, numbers are compared exactly,
+// and otherwise they are compared as float64.
 type Number struct {
 	noCopy noCopy
 	chain  *chain
 	value  float64
+	exact  json.Number
 }
 
 // NewNumber returns a new Number instance.
@@ -41,6 +54,12 @@ func newNumber(parent *chain, val float64) *Number {
 	return &Number{chain: parent.clone(), value: val}
 }
 
+func newNumberExact(parent *chain, val json.Number) *Number {
+	n := &Number{chain: parent.clone(), exact: val}
+	n.value, _ = strconv.ParseFloat(string(val), 64)
+	return n
+}
+
 // Raw returns underlying value attached to Number.
 // This is the value originally passed to NewNumber.
 //
@@ -74,7 +93,11 @@ func (n *Number) Decode(target interface{}) *Number {
 		return n
 	}
 
-	canonDecode(opChain, n.value, target)
+	if n.exact != "" {
+		canonDecode(opChain, n.exact, target)
+	} else {
+		canonDecode(opChain, n.value, target)
+	}
 	return n
 }
 
@@ -87,6 +110,27 @@ func (n *Number) Alias(name string) *Number {
 	return n
 }
 
+// Chain returns public handle to assertion chain of Number, which may be
+// used to implement custom matchers. See Chain for details.
+func (n *Number) Chain() *Chain {
+	return &Chain{chain: n.chain}
+}
+
+// AsWarning is similar to Value.AsWarning.
+func (n *Number) AsWarning() *Number {
+	opChain := n.chain.enter("AsWarning()")
+	defer opChain.leave()
+
+	warnChain := n.chain.clone()
+	warnChain.setWarning()
+
+	return &Number{
+		chain: warnChain,
+		value: n.value,
+		exact: n.exact,
+	}
+}
+
 // Path is similar to Value.Path.
 func (n *Number) Path(path string) *Value {
 	opChain := n.chain.enter("Path(%q)", path)
@@ -106,8 +150,9 @@ func (n *Number) Schema(schema interface{}) *Number {
 
 // IsEqual succeeds if number is equal to given value.
 //
-// value should have numeric type convertible to float64. Before comparison,
-// it is converted to float64.
+// value should have numeric type convertible to float64, or be json.Number,
+// *big.Int, *big.Float, or *big.Rat. Before comparison, it is converted to
+// canonical form (see Number).
 //
 // Example:
 //
@@ -122,15 +167,15 @@ func (n *Number) IsEqual(value interface{}) *Number {
 		return n
 	}
 
-	num, ok := canonNumber(opChain, value)
+	num, ok := canonNumberExact(opChain, value)
 	if !ok {
 		return n
 	}
 
-	if !(n.value == num) {
+	if cmp, ok := n.compare(num); !ok || cmp != 0 {
 		opChain.fail(AssertionFailure{
 			Type:     AssertEqual,
-			Actual:   &AssertionValue{n.value},
+			Actual:   &AssertionValue{n.exactValue()},
 			Expected: &AssertionValue{num},
 			Errors: []error{
 				errors.New("expected: numbers are equal"),
@@ -143,8 +188,9 @@ func (n *Number) IsEqual(value interface{}) *Number {
 
 // NotEqual succeeds if number is not equal to given value.
 //
-// value should have numeric type convertible to float64. Before comparison,
-// it is converted to float64.
+// value should have numeric type convertible to float64, or be json.Number,
+// *big.Int, *big.Float, or *big.Rat. Before comparison, it is converted to
+// canonical form (see Number).
 //
 // Example:
 //
@@ -159,15 +205,15 @@ func (n *Number) NotEqual(value interface{}) *Number {
 		return n
 	}
 
-	num, ok := canonNumber(opChain, value)
+	num, ok := canonNumberExact(opChain, value)
 	if !ok {
 		return n
 	}
 
-	if n.value == num {
+	if cmp, ok := n.compare(num); ok && cmp == 0 {
 		opChain.fail(AssertionFailure{
 			Type:     AssertNotEqual,
-			Actual:   &AssertionValue{n.value},
+			Actual:   &AssertionValue{n.exactValue()},
 			Expected: &AssertionValue{num},
 			Errors: []error{
 				errors.New("expected: numbers are non-equal"),
@@ -183,6 +229,111 @@ func (n *Number) Equal(value interface{}) *Number {
 	return n.IsEqual(value)
 }
 
+// IsEqualExact succeeds if number is exactly equal to given value,
+// without conversion to float64.
+//
+// value may be a string with decimal number, json.Number, *big.Int,
+// *big.Float, *big.Rat, or any integer or floating type.
+//
+// If number was decoded without loss of precision (see ContentOpts),
+// its exact value is compared. Otherwise, exact value of underlying
+// float64 is compared.
+//
+// Example:
+//
+//	number := resp.JSON(ContentOpts{UseNumber: true}).Number()
+//	number.IsEqualExact("12345678901234567890")
+//	number.IsEqualExact(uint64(12345678901234567890))
+func (n *Number) IsEqualExact(value interface{}) *Number {
+	opChain := n.chain.enter("IsEqualExact()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return n
+	}
+
+	actual, expected, ok := n.exactPair(opChain, value)
+	if !ok {
+		return n
+	}
+
+	if actual.Cmp(expected) != 0 {
+		opChain.fail(AssertionFailure{
+			Type:     AssertEqual,
+			Actual:   &AssertionValue{n.exactValue()},
+			Expected: &AssertionValue{json.Number(formatRat(expected))},
+			Errors: []error{
+				errors.New("expected: numbers are exactly equal"),
+			},
+		})
+	}
+
+	return n
+}
+
+// NotEqualExact succeeds if number is not exactly equal to given value.
+// See IsEqualExact for details.
+//
+// Example:
+//
+//	number := resp.JSON(ContentOpts{UseNumber: true}).Number()
+//	number.NotEqualExact("12345678901234567891")
+func (n *Number) NotEqualExact(value interface{}) *Number {
+	opChain := n.chain.enter("NotEqualExact()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return n
+	}
+
+	actual, expected, ok := n.exactPair(opChain, value)
+	if !ok {
+		return n
+	}
+
+	if actual.Cmp(expected) == 0 {
+		opChain.fail(AssertionFailure{
+			Type:     AssertNotEqual,
+			Actual:   &AssertionValue{n.exactValue()},
+			Expected: &AssertionValue{json.Number(formatRat(expected))},
+			Errors: []error{
+				errors.New("expected: numbers are not exactly equal"),
+			},
+		})
+	}
+
+	return n
+}
+
+func (n *Number) exactPair(
+	opChain *chain, value interface{},
+) (actual, expected *big.Rat, ok bool) {
+	expected, ok = canonRat(value)
+	if !ok {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				fmt.Errorf("unexpected non-number argument %v (%T)", value, value),
+			},
+		})
+		return nil, nil, false
+	}
+
+	actual, ok = canonRat(n.exactValue())
+	if !ok {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{n.value},
+			Errors: []error{
+				errors.New("expected: number is finite"),
+			},
+		})
+		return nil, nil, false
+	}
+
+	return actual, expected, true
+}
+
 // InDelta succeeds if two numerals are within delta of each other.
 //
 // Example:
@@ -285,8 +436,9 @@ func (n *Number) NotEqualDelta(value, delta float64) *Number {
 
 // InRange succeeds if number is within given range [min; max].
 //
-// min and max should have numeric type convertible to float64. Before comparison,
-// they are converted to float64.
+// min and max should have numeric type convertible to float64, or be json.Number,
+// *big.Int, *big.Float, or *big.Rat. Before comparison, they are converted to
+// canonical form (see Number).
 //
 // Example:
 //
@@ -302,20 +454,23 @@ func (n *Number) InRange(min, max interface{}) *Number {
 		return n
 	}
 
-	a, ok := canonNumber(opChain, min)
+	a, ok := canonNumberExact(opChain, min)
 	if !ok {
 		return n
 	}
 
-	b, ok := canonNumber(opChain, max)
+	b, ok := canonNumberExact(opChain, max)
 	if !ok {
 		return n
 	}
 
-	if !(n.value >= a && n.value <= b) {
+	cmpMin, okMin := n.compare(a)
+	cmpMax, okMax := n.compare(b)
+
+	if !(okMin && okMax && cmpMin >= 0 && cmpMax <= 0) {
 		opChain.fail(AssertionFailure{
 			Type:     AssertInRange,
-			Actual:   &AssertionValue{n.value},
+			Actual:   &AssertionValue{n.exactValue()},
 			Expected: &AssertionValue{AssertionRange{a, b}},
 			Errors: []error{
 				errors.New("expected: number is within given range"),
@@ -328,8 +483,9 @@ func (n *Number) InRange(min, max interface{}) *Number {
 
 // NotInRange succeeds if number is not within given range [min; max].
 //
-// min and max should have numeric type convertible to float64. Before comparison,
-// they are converted to float64.
+// min and max should have numeric type convertible to float64, or be json.Number,
+// *big.Int, *big.Float, or *big.Rat. Before comparison, they are converted to
+// canonical form (see Number).
 //
 // Example:
 //
@@ -344,20 +500,23 @@ func (n *Number) NotInRange(min, max interface{}) *Number {
 		return n
 	}
 
-	a, ok := canonNumber(opChain, min)
+	a, ok := canonNumberExact(opChain, min)
 	if !ok {
 		return n
 	}
 
-	b, ok := canonNumber(opChain, max)
+	b, ok := canonNumberExact(opChain, max)
 	if !ok {
 		return n
 	}
 
-	if n.value >= a && n.value <= b {
+	cmpMin, okMin := n.compare(a)
+	cmpMax, okMax := n.compare(b)
+
+	if okMin && okMax && cmpMin >= 0 && cmpMax <= 0 {
 		opChain.fail(AssertionFailure{
 			Type:     AssertNotInRange,
-			Actual:   &AssertionValue{n.value},
+			Actual:   &AssertionValue{n.exactValue()},
 			Expected: &AssertionValue{AssertionRange{a, b}},
 			Errors: []error{
 				errors.New("expected: number is not within given range"),
@@ -398,12 +557,12 @@ func (n *Number) InList(values ...interface{}) *Number {
 
 	var isListed bool
 	for _, v := range values {
-		num, ok := canonNumber(opChain, v)
+		num, ok := canonNumberExact(opChain, v)
 		if !ok {
 			return n
 		}
 
-		if n.value == num {
+		if cmp, ok := n.compare(num); ok && cmp == 0 {
 			isListed = true
 			// continue loop to check that all values are correct
 		}
@@ -412,7 +571,7 @@ func (n *Number) InList(values ...interface{}) *Number {
 	if !isListed {
 		opChain.fail(AssertionFailure{
 			Type:     AssertBelongs,
-			Actual:   &AssertionValue{n.value},
+			Actual:   &AssertionValue{n.exactValue()},
 			Expected: &AssertionValue{AssertionList(values)},
 			Errors: []error{
 				errors.New("expected: number is equal to one of the values"),
@@ -452,15 +611,15 @@ func (n *Number) NotInList(values ...interface{}) *Number {
 	}
 
 	for _, v := range values {
-		num, ok := canonNumber(opChain, v)
+		num, ok := canonNumberExact(opChain, v)
 		if !ok {
 			return n
 		}
 
-		if n.value == num {
+		if cmp, ok := n.compare(num); ok && cmp == 0 {
 			opChain.fail(AssertionFailure{
 				Type:     AssertNotBelongs,
-				Actual:   &AssertionValue{n.value},
+				Actual:   &AssertionValue{n.exactValue()},
 				Expected: &AssertionValue{AssertionList(values)},
 				Errors: []error{
 					errors.New("expected: number is not equal to any of the values"),
@@ -475,8 +634,9 @@ func (n *Number) NotInList(values ...interface{}) *Number {
 
 // Gt succeeds if number is greater than given value.
 //
-// value should have numeric type convertible to float64. Before comparison,
-// it is converted to float64.
+// value should have numeric type convertible to float64, or be json.Number,
+// *big.Int, *big.Float, or *big.Rat. Before comparison, it is converted to
+// canonical form (see Number).
 //
 // Example:
 //
@@ -491,15 +651,15 @@ func (n *Number) Gt(value interface{}) *Number {
 		return n
 	}
 
-	num, ok := canonNumber(opChain, value)
+	num, ok := canonNumberExact(opChain, value)
 	if !ok {
 		return n
 	}
 
-	if !(n.value > num) {
+	if cmp, ok := n.compare(num); !ok || cmp <= 0 {
 		opChain.fail(AssertionFailure{
 			Type:     AssertGt,
-			Actual:   &AssertionValue{n.value},
+			Actual:   &AssertionValue{n.exactValue()},
 			Expected: &AssertionValue{num},
 			Errors: []error{
 				errors.New("expected: number is larger than given value"),
@@ -512,8 +672,9 @@ func (n *Number) Gt(value interface{}) *Number {
 
 // Ge succeeds if number is greater than or equal to given value.
 //
-// value should have numeric type convertible to float64. Before comparison,
-// it is converted to float64.
+// value should have numeric type convertible to float64, or be json.Number,
+// *big.Int, *big.Float, or *big.Rat. Before comparison, it is converted to
+// canonical form (see Number).
 //
 // Example:
 //
@@ -528,15 +689,15 @@ func (n *Number) Ge(value interface{}) *Number {
 		return n
 	}
 
-	num, ok := canonNumber(opChain, value)
+	num, ok := canonNumberExact(opChain, value)
 	if !ok {
 		return n
 	}
 
-	if !(n.value >= num) {
+	if cmp, ok := n.compare(num); !ok || cmp < 0 {
 		opChain.fail(AssertionFailure{
 			Type:     AssertGe,
-			Actual:   &AssertionValue{n.value},
+			Actual:   &AssertionValue{n.exactValue()},
 			Expected: &AssertionValue{num},
 			Errors: []error{
 				errors.New("expected: number is larger than or equal to given value"),
@@ -549,8 +710,9 @@ func (n *Number) Ge(value interface{}) *Number {
 
 // Lt succeeds if number is lesser than given value.
 //
-// value should have numeric type convertible to float64. Before comparison,
-// it is converted to float64.
+// value should have numeric type convertible to float64, or be json.Number,
+// *big.Int, *big.Float, or *big.Rat. Before comparison, it is converted to
+// canonical form (see Number).
 //
 // Example:
 //
@@ -565,15 +727,15 @@ func (n *Number) Lt(value interface{}) *Number {
 		return n
 	}
 
-	num, ok := canonNumber(opChain, value)
+	num, ok := canonNumberExact(opChain, value)
 	if !ok {
 		return n
 	}
 
-	if !(n.value < num) {
+	if cmp, ok := n.compare(num); !ok || cmp >= 0 {
 		opChain.fail(AssertionFailure{
 			Type:     AssertLt,
-			Actual:   &AssertionValue{n.value},
+			Actual:   &AssertionValue{n.exactValue()},
 			Expected: &AssertionValue{num},
 			Errors: []error{
 				errors.New("expected: number is less than given value"),
@@ -586,8 +748,9 @@ func (n *Number) Lt(value interface{}) *Number {
 
 // Le succeeds if number is lesser than or equal to given value.
 //
-// value should have numeric type convertible to float64. Before comparison,
-// it is converted to float64.
+// value should have numeric type convertible to float64, or be json.Number,
+// *big.Int, *big.Float, or *big.Rat. Before comparison, it is converted to
+// canonical form (see Number).
 //
 // Example:
 //
@@ -602,15 +765,15 @@ func (n *Number) Le(value interface{}) *Number {
 		return n
 	}
 
-	num, ok := canonNumber(opChain, value)
+	num, ok := canonNumberExact(opChain, value)
 	if !ok {
 		return n
 	}
 
-	if !(n.value <= num) {
+	if cmp, ok := n.compare(num); !ok || cmp > 0 {
 		opChain.fail(AssertionFailure{
 			Type:     AssertLe,
-			Actual:   &AssertionValue{n.value},
+			Actual:   &AssertionValue{n.exactValue()},
 			Expected: &AssertionValue{num},
 			Errors: []error{
 				errors.New("expected: number is less than or equal to given value"),
@@ -680,7 +843,7 @@ func (n *Number) IsInt(bits ...int) *Number {
 		return n
 	}
 
-	inum, acc := big.NewFloat(n.value).Int(nil)
+	inum, acc := n.bigInt()
 	if !(acc == big.Exact) {
 		opChain.fail(AssertionFailure{
 			Type:   AssertValid,
@@ -769,7 +932,7 @@ func (n *Number) NotInt(bits ...int) *Number {
 	}
 
 	if !math.IsNaN(n.value) {
-		inum, acc := big.NewFloat(n.value).Int(nil)
+		inum, acc := n.bigInt()
 		if acc == big.Exact {
 			if len(bits) == 0 {
 				opChain.fail(AssertionFailure{
@@ -870,7 +1033,7 @@ func (n *Number) IsUint(bits ...int) *Number {
 		return n
 	}
 
-	inum, acc := big.NewFloat(n.value).Int(nil)
+	inum, acc := n.bigInt()
 	if !(acc == big.Exact) {
 		opChain.fail(AssertionFailure{
 			Type:   AssertValid,
@@ -967,7 +1130,7 @@ func (n *Number) NotUint(bits ...int) *Number {
 	}
 
 	if !math.IsNaN(n.value) {
-		inum, acc := big.NewFloat(n.value).Int(nil)
+		inum, acc := n.bigInt()
 		if acc == big.Exact {
 			imin := big.NewInt(0)
 			if inum.Cmp(imin) >= 0 {
@@ -1091,3 +1254,66 @@ func (b intBoundary) String() string {
 	}
 	return fmt.Sprintf("%s", b.val)
 }
+
+// Exact value of number, json.Number or float64.
+func (n *Number) exactValue() interface{} {
+	if n.exact != "" {
+		return n.exact
+	}
+	return n.value
+}
+
+// Compare number with canonical number (see canonNumberExact).
+// Returns false if numbers are not comparable, e.g. one of them is NaN.
+func (n *Number) compare(num interface{}) (int, bool) {
+	if _, isExact := num.(json.Number); isExact || n.exact != "" {
+		a, aok := decimalRat(n.exactValue())
+		b, bok := decimalRat(num)
+		if aok && bok {
+			return a.Cmp(b), true
+		}
+	}
+
+	f, ok := num.(float64)
+	if !ok {
+		f = canonFloat(num.(json.Number))
+	}
+
+	switch {
+	case n.value < f:
+		return -1, true
+	case n.value > f:
+		return 1, true
+	case n.value == f:
+		return 0, true
+	}
+
+	return 0, false
+}
+
+// Convert canonical number to big.Rat.
+// Unlike canonRat, float64 is converted using its shortest decimal
+// representation, i.e. float64(0.1) becomes exactly 1/10.
+func decimalRat(num interface{}) (*big.Rat, bool) {
+	if f, ok := num.(float64); ok {
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return nil, false
+		}
+		return canonRat(strconv.FormatFloat(f, 'g', -1, 64))
+	}
+	return canonRat(num)
+}
+
+// Convert number to integer, reporting conversion accuracy.
+func (n *Number) bigInt() (*big.Int, big.Accuracy) {
+	if n.exact != "" {
+		if r, ok := canonRat(n.exact); ok {
+			inum := new(big.Int).Quo(r.Num(), r.Denom())
+			if r.IsInt() {
+				return inum, big.Exact
+			}
+			return inum, big.Below
+		}
+	}
+	return big.NewFloat(n.value).Int(nil)
+}
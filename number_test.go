@@ -1,10 +1,13 @@
 package httpexpect
 
 import (
+	"encoding/json"
 	"math"
+	"math/big"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNumber_FailedChain(t *testing.T) {
@@ -23,6 +26,8 @@ func TestNumber_FailedChain(t *testing.T) {
 
 	value.IsEqual(0)
 	value.NotEqual(0)
+	value.IsEqualExact(0)
+	value.NotEqualExact(0)
 	value.InDelta(0, 0)
 	value.NotInDelta(0, 0)
 	value.InRange(0, 0)
@@ -248,6 +253,265 @@ func TestNumber_IsEqual(t *testing.T) {
 	})
 }
 
+func TestNumber_IsEqualExact(t *testing.T) {
+	cases := []struct {
+		name     string
+		number   *Number
+		expected interface{}
+		isEqual  bool
+	}{
+		{
+			name:     "float and string",
+			number:   NewNumber(newMockReporter(t), 123),
+			expected: "123",
+			isEqual:  true,
+		},
+		{
+			name:     "float and exponent string",
+			number:   NewNumber(newMockReporter(t), 1500),
+			expected: "1.5e3",
+			isEqual:  true,
+		},
+		{
+			name:     "float and inexact decimal",
+			number:   NewNumber(newMockReporter(t), 0.1),
+			expected: "0.1",
+			isEqual:  false,
+		},
+		{
+			name:     "exact and string",
+			number:   newNumberExact(newMockChain(t), "12345678901234567890"),
+			expected: "12345678901234567890",
+			isEqual:  true,
+		},
+		{
+			name:     "exact and off by one",
+			number:   newNumberExact(newMockChain(t), "12345678901234567890"),
+			expected: "12345678901234567891",
+			isEqual:  false,
+		},
+		{
+			name:     "exact and uint64",
+			number:   newNumberExact(newMockChain(t), "12345678901234567890"),
+			expected: uint64(12345678901234567890),
+			isEqual:  true,
+		},
+		{
+			name:     "exact and float",
+			number:   newNumberExact(newMockChain(t), "12345678901234567890"),
+			expected: 12345678901234567890.0,
+			isEqual:  false,
+		},
+		{
+			name:     "exact and big.Int",
+			number:   newNumberExact(newMockChain(t), "-98765432109876543210"),
+			expected: new(big.Int).Neg(mustBigInt("98765432109876543210")),
+			isEqual:  true,
+		},
+		{
+			name:     "exact decimal and big.Rat",
+			number:   newNumberExact(newMockChain(t), "0.10000000000000000001"),
+			expected: big.NewRat(1, 10),
+			isEqual:  false,
+		},
+		{
+			name:     "exact decimal and json.Number",
+			number:   newNumberExact(newMockChain(t), "0.10000000000000000001"),
+			expected: json.Number("1.0000000000000000001e-1"),
+			isEqual:  true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.number.IsEqualExact(tc.expected)
+			if tc.isEqual {
+				tc.number.chain.assertNotFailed(t)
+			} else {
+				tc.number.chain.assertFailed(t)
+			}
+			tc.number.chain.clearFailed()
+
+			tc.number.NotEqualExact(tc.expected)
+			if tc.isEqual {
+				tc.number.chain.assertFailed(t)
+			} else {
+				tc.number.chain.assertNotFailed(t)
+			}
+			tc.number.chain.clearFailed()
+		})
+	}
+
+	t.Run("invalid argument", func(t *testing.T) {
+		number := NewNumber(newMockReporter(t), 123)
+
+		number.IsEqualExact("abc")
+		number.chain.assertFailed(t)
+		number.chain.clearFailed()
+
+		number.NotEqualExact(true)
+		number.chain.assertFailed(t)
+		number.chain.clearFailed()
+	})
+
+	t.Run("non-finite number", func(t *testing.T) {
+		number := NewNumber(newMockReporter(t), math.Inf(1))
+
+		number.IsEqualExact(1)
+		number.chain.assertFailed(t)
+	})
+}
+
+func TestNumber_CompareExact(t *testing.T) {
+	cases := []struct {
+		name   string
+		number *Number
+		value  interface{}
+		cmp    int
+	}{
+		{
+			name:   "exact and uint64 below",
+			number: newNumberExact(newMockChain(t), "12345678901234567891"),
+			value:  uint64(12345678901234567890),
+			cmp:    1,
+		},
+		{
+			name:   "exact and uint64 equal",
+			number: newNumberExact(newMockChain(t), "12345678901234567891"),
+			value:  uint64(12345678901234567891),
+			cmp:    0,
+		},
+		{
+			name:   "exact and json.Number above",
+			number: newNumberExact(newMockChain(t), "12345678901234567891"),
+			value:  json.Number("12345678901234567892"),
+			cmp:    -1,
+		},
+		{
+			name:   "exact and big.Int equal",
+			number: newNumberExact(newMockChain(t), "-98765432109876543210"),
+			value:  new(big.Int).Neg(mustBigInt("98765432109876543210")),
+			cmp:    0,
+		},
+		{
+			name:   "exact decimal and float",
+			number: newNumberExact(newMockChain(t), "0.10000000000000000001"),
+			value:  0.1,
+			cmp:    1,
+		},
+		{
+			name:   "float and uint64",
+			number: NewNumber(newMockReporter(t), 12345678901234567890),
+			value:  uint64(12345678901234567891),
+			cmp:    -1,
+		},
+		{
+			name:   "float and small int",
+			number: NewNumber(newMockReporter(t), 123),
+			value:  int64(123),
+			cmp:    0,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			check := func(succeed bool) {
+				if succeed {
+					tc.number.chain.assertNotFailed(t)
+				} else {
+					tc.number.chain.assertFailed(t)
+				}
+				tc.number.chain.clearFailed()
+			}
+
+			tc.number.IsEqual(tc.value)
+			check(tc.cmp == 0)
+
+			tc.number.NotEqual(tc.value)
+			check(tc.cmp != 0)
+
+			tc.number.Gt(tc.value)
+			check(tc.cmp > 0)
+
+			tc.number.Ge(tc.value)
+			check(tc.cmp >= 0)
+
+			tc.number.Lt(tc.value)
+			check(tc.cmp < 0)
+
+			tc.number.Le(tc.value)
+			check(tc.cmp <= 0)
+
+			tc.number.InRange(tc.value, tc.value)
+			check(tc.cmp == 0)
+
+			tc.number.NotInRange(tc.value, tc.value)
+			check(tc.cmp != 0)
+
+			tc.number.InList(tc.value)
+			check(tc.cmp == 0)
+
+			tc.number.NotInList(tc.value)
+			check(tc.cmp != 0)
+		})
+	}
+
+	t.Run("failure values", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+
+		number := newNumberExact(newChainWithConfig("test", Config{
+			AssertionHandler: handler,
+		}.withDefaults()), "12345678901234567891")
+
+		number.IsEqual(uint64(12345678901234567890))
+
+		require.NotNil(t, handler.failure)
+		assert.Equal(t, &AssertionValue{json.Number("12345678901234567891")},
+			handler.failure.Actual)
+		assert.Equal(t, &AssertionValue{json.Number("12345678901234567890")},
+			handler.failure.Expected)
+	})
+}
+
+func TestNumber_Exact(t *testing.T) {
+	t.Run("integer", func(t *testing.T) {
+		number := newNumberExact(newMockChain(t), "12345678901234567891")
+
+		assert.Equal(t, 12345678901234567891.0, number.Raw())
+
+		number.IsInt()
+		number.chain.assertNotFailed(t)
+
+		number.IsInt(64)
+		number.chain.assertFailed(t)
+		number.chain.clearFailed()
+
+		number.IsUint(64)
+		number.chain.assertNotFailed(t)
+
+		var target uint64
+		number.Decode(&target)
+		number.chain.assertNotFailed(t)
+		assert.Equal(t, uint64(12345678901234567891), target)
+	})
+
+	t.Run("decimal", func(t *testing.T) {
+		number := newNumberExact(newMockChain(t), "1.00000000000000000001")
+
+		assert.Equal(t, 1.0, number.Raw())
+
+		number.IsInt()
+		number.chain.assertFailed(t)
+		number.chain.clearFailed()
+
+		number.NotInt()
+		number.chain.assertNotFailed(t)
+
+		number.NotUint()
+		number.chain.assertNotFailed(t)
+	})
+}
+
 func TestNumber_InDelta(t *testing.T) {
 	cases := map[string]struct {
 		number           float64
@@ -1017,3 +1281,11 @@ func TestNumber_IsFinite(t *testing.T) {
 		})
 	}
 }
+
+func mustBigInt(s string) *big.Int {
+	i, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		panic("invalid big.Int: " + s)
+	}
+	return i
+}
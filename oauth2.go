@@ -0,0 +1,473 @@
+package httpexpect
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// OAuth2Grant defines how OAuth2 access token is obtained from token endpoint.
+type OAuth2Grant int
+
+const (
+	// OAuth2ClientCredentials requests token using client id and secret
+	// ("client_credentials" grant, RFC 6749, section 4.4).
+	OAuth2ClientCredentials OAuth2Grant = iota
+
+	// OAuth2Password requests token using resource owner username and password
+	// ("password" grant, RFC 6749, section 4.3).
+	OAuth2Password
+
+	// OAuth2AuthorizationCode requests authorization code from authorization
+	// endpoint and exchanges it for token ("authorization_code" grant,
+	// RFC 6749, section 4.1), using PKCE with S256 challenge (RFC 7636).
+	//
+	// Authorization endpoint is expected to issue a redirect to RedirectURL
+	// without user interaction, which is usually the case for local stubs.
+	OAuth2AuthorizationCode
+)
+
+// OAuth2Config defines parameters for obtaining OAuth2 access tokens.
+//
+// TokenURL is required. AuthURL and RedirectURL are required only for
+// OAuth2AuthorizationCode grant. Username and Password are used only
+// for OAuth2Password grant.
+type OAuth2Config struct {
+	// Grant type used to obtain new token
+	Grant OAuth2Grant
+
+	// Token endpoint URL, e.g. "http://example.com/oauth/token"
+	TokenURL string
+
+	// Authorization endpoint URL, e.g. "http://example.com/oauth/authorize"
+	AuthURL string
+
+	// Redirection URL registered for the client
+	RedirectURL string
+
+	// Client credentials
+	// Sent in request body of token requests
+	ClientID     string
+	ClientSecret string
+
+	// Resource owner credentials
+	Username string
+	Password string
+
+	// Requested scopes
+	Scopes []string
+
+	// How long before actual expiration the token is considered expired
+	// If zero, 10 seconds are used
+	ExpiryDelta time.Duration
+
+	// Environment key under which the token is cached
+	// If empty, key is derived from TokenURL, ClientID and Username
+	EnvKey string
+}
+
+// OAuth2Token holds access token obtained from token endpoint.
+//
+// Tokens are cached in Environment, so one can inspect or replace them
+// using Expect.Env().
+type OAuth2Token struct {
+	AccessToken  string
+	TokenType    string
+	RefreshToken string
+
+	// Zero if token endpoint didn't report expiration time
+	Expiry time.Time
+}
+
+type oauth2Source struct {
+	mu sync.Mutex
+
+	config OAuth2Config
+	client Client
+	env    *Environment
+}
+
+func newOAuth2Source(config OAuth2Config, client Client, env *Environment) *oauth2Source {
+	if config.ExpiryDelta == 0 {
+		config.ExpiryDelta = 10 * time.Second
+	}
+
+	if config.EnvKey == "" {
+		config.EnvKey = fmt.Sprintf("oauth2.token:%s:%s:%s",
+			config.TokenURL, config.ClientID, config.Username)
+	}
+
+	return &oauth2Source{
+		config: config,
+		client: client,
+		env:    env,
+	}
+}
+
+func (s *oauth2Source) validate() error {
+	if s.config.TokenURL == "" {
+		return errors.New("OAuth2Config.TokenURL is empty")
+	}
+
+	switch s.config.Grant {
+	case OAuth2ClientCredentials, OAuth2Password:
+		break
+
+	case OAuth2AuthorizationCode:
+		if s.config.AuthURL == "" {
+			return errors.New("OAuth2Config.AuthURL is empty")
+		}
+		if s.config.RedirectURL == "" {
+			return errors.New("OAuth2Config.RedirectURL is empty")
+		}
+
+	default:
+		return fmt.Errorf("unknown OAuth2Config.Grant value %d", s.config.Grant)
+	}
+
+	return nil
+}
+
+// Wrap client so that it authorizes every request using cached token.
+// If client is *http.Client, only its Transport is replaced, because
+// it may hold state shared among requests like a cookie jar.
+func (s *oauth2Source) wrapClient(client Client) Client {
+	if httpClient, ok := client.(*http.Client); ok {
+		base := httpClient.Transport
+		if base == nil {
+			base = http.DefaultTransport
+		}
+
+		clientCopy := *httpClient
+		clientCopy.Transport = &oauth2Transport{source: s, base: base}
+
+		return &clientCopy
+	}
+
+	return &oauth2Client{source: s, base: client}
+}
+
+type oauth2Transport struct {
+	source *oauth2Source
+	base   http.RoundTripper
+}
+
+func (t *oauth2Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return t.source.send(req, t.base.RoundTrip)
+}
+
+type oauth2Client struct {
+	source *oauth2Source
+	base   Client
+}
+
+func (c *oauth2Client) Do(req *http.Request) (*http.Response, error) {
+	return c.source.send(req, c.base.Do)
+}
+
+// Send request with Authorization header.
+// If server responds with 401, token is renewed and request is resent once.
+func (s *oauth2Source) send(
+	req *http.Request, sendFn func(*http.Request) (*http.Response, error),
+) (*http.Response, error) {
+	token, err := s.token(false)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := sendFn(s.authorize(req, token))
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+
+	if req.Body != nil && req.Body != http.NoBody {
+		if bw, ok := req.Body.(*bodyWrapper); ok {
+			bw.Rewind()
+		} else if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return resp, nil
+			}
+			req.Body = body
+		} else {
+			return resp, nil
+		}
+	}
+
+	token, err = s.token(true)
+	if err != nil {
+		return resp, nil
+	}
+
+	_, _ = io.Copy(ioutil.Discard, resp.Body)
+	_ = resp.Body.Close()
+
+	return sendFn(s.authorize(req, token))
+}
+
+func (s *oauth2Source) authorize(req *http.Request, token *OAuth2Token) *http.Request {
+	tokenType := token.TokenType
+	if tokenType == "" || strings.EqualFold(tokenType, "bearer") {
+		tokenType = "Bearer"
+	}
+
+	reqCopy := req.Clone(req.Context())
+	reqCopy.Body = req.Body
+	reqCopy.Header.Set("Authorization", tokenType+" "+token.AccessToken)
+
+	return reqCopy
+}
+
+// Get cached token, or obtain a new one if it is missing or expired.
+// If renew is true, cached token is considered rejected by server.
+func (s *oauth2Source) token(renew bool) (*OAuth2Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var cached *OAuth2Token
+
+	if s.env.Has(s.config.EnvKey) {
+		cached, _ = s.env.Get(s.config.EnvKey).(*OAuth2Token)
+	}
+
+	if cached != nil && !renew && !s.expired(cached) {
+		return cached, nil
+	}
+
+	var (
+		token *OAuth2Token
+		err   error
+	)
+
+	if cached != nil && cached.RefreshToken != "" {
+		token, err = s.refresh(cached.RefreshToken)
+	}
+
+	if token == nil {
+		token, err = s.obtain()
+	}
+
+	if err != nil {
+		s.env.Delete(s.config.EnvKey)
+		return nil, err
+	}
+
+	if token.RefreshToken == "" && cached != nil {
+		token.RefreshToken = cached.RefreshToken
+	}
+
+	s.env.Put(s.config.EnvKey, token)
+
+	return token, nil
+}
+
+func (s *oauth2Source) expired(token *OAuth2Token) bool {
+	if token.Expiry.IsZero() {
+		return false
+	}
+
+	return !time.Now().Add(s.config.ExpiryDelta).Before(token.Expiry)
+}
+
+func (s *oauth2Source) obtain() (*OAuth2Token, error) {
+	params := url.Values{}
+
+	switch s.config.Grant {
+	case OAuth2ClientCredentials:
+		params.Set("grant_type", "client_credentials")
+
+	case OAuth2Password:
+		params.Set("grant_type", "password")
+		params.Set("username", s.config.Username)
+		params.Set("password", s.config.Password)
+
+	case OAuth2AuthorizationCode:
+		verifier, err := oauth2Random(32)
+		if err != nil {
+			return nil, err
+		}
+
+		code, err := s.authorizationCode(verifier)
+		if err != nil {
+			return nil, err
+		}
+
+		params.Set("grant_type", "authorization_code")
+		params.Set("code", code)
+		params.Set("redirect_uri", s.config.RedirectURL)
+		params.Set("code_verifier", verifier)
+	}
+
+	if len(s.config.Scopes) != 0 {
+		params.Set("scope", strings.Join(s.config.Scopes, " "))
+	}
+
+	return s.requestToken(params)
+}
+
+func (s *oauth2Source) refresh(refreshToken string) (*OAuth2Token, error) {
+	params := url.Values{}
+
+	params.Set("grant_type", "refresh_token")
+	params.Set("refresh_token", refreshToken)
+
+	return s.requestToken(params)
+}
+
+func (s *oauth2Source) authorizationCode(verifier string) (string, error) {
+	state, err := oauth2Random(16)
+	if err != nil {
+		return "", err
+	}
+
+	challenge := sha256.Sum256([]byte(verifier))
+
+	authURL, err := url.Parse(s.config.AuthURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid authorization url: %w", err)
+	}
+
+	query := authURL.Query()
+	query.Set("response_type", "code")
+	query.Set("client_id", s.config.ClientID)
+	query.Set("redirect_uri", s.config.RedirectURL)
+	query.Set("state", state)
+	query.Set("code_challenge",
+		base64.RawURLEncoding.EncodeToString(challenge[:]))
+	query.Set("code_challenge_method", "S256")
+	if len(s.config.Scopes) != 0 {
+		query.Set("scope", strings.Join(s.config.Scopes, " "))
+	}
+	authURL.RawQuery = query.Encode()
+
+	req, err := http.NewRequest(http.MethodGet, authURL.String(), nil)
+	if err != nil {
+		return "", err
+	}
+
+	client := s.client
+	if httpClient, ok := client.(*http.Client); ok {
+		clientCopy := *httpClient
+		clientCopy.CheckRedirect = func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		}
+		client = &clientCopy
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("authorization request failed: %w", err)
+	}
+
+	_, _ = io.Copy(ioutil.Discard, resp.Body)
+	_ = resp.Body.Close()
+
+	location, err := resp.Location()
+	if err != nil {
+		return "", fmt.Errorf(
+			"authorization endpoint responded with %s without redirect",
+			statusCodeText(resp.StatusCode))
+	}
+
+	redirect := location.Query()
+
+	if e := redirect.Get("error"); e != "" {
+		return "", fmt.Errorf("authorization endpoint returned error %q", e)
+	}
+
+	if redirect.Get("state") != state {
+		return "", errors.New("authorization endpoint returned mismatched state")
+	}
+
+	code := redirect.Get("code")
+	if code == "" {
+		return "", errors.New("authorization endpoint returned empty code")
+	}
+
+	return code, nil
+}
+
+func (s *oauth2Source) requestToken(params url.Values) (*OAuth2Token, error) {
+	params.Set("client_id", s.config.ClientID)
+	if s.config.ClientSecret != "" {
+		params.Set("client_secret", s.config.ClientSecret)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, s.config.TokenURL,
+		strings.NewReader(params.Encode()))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("token request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read token response: %w", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, fmt.Errorf("token endpoint responded with %s: %s",
+			statusCodeText(resp.StatusCode), strings.TrimSpace(string(body)))
+	}
+
+	var payload struct {
+		AccessToken  string      `json:"access_token"`
+		TokenType    string      `json:"token_type"`
+		RefreshToken string      `json:"refresh_token"`
+		ExpiresIn    json.Number `json:"expires_in"`
+	}
+
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, fmt.Errorf("failed to decode token response: %w", err)
+	}
+
+	if payload.AccessToken == "" {
+		return nil, errors.New("token endpoint returned empty access_token")
+	}
+
+	token := &OAuth2Token{
+		AccessToken:  payload.AccessToken,
+		TokenType:    payload.TokenType,
+		RefreshToken: payload.RefreshToken,
+	}
+
+	if payload.ExpiresIn != "" {
+		seconds, err := payload.ExpiresIn.Int64()
+		if err != nil {
+			return nil, fmt.Errorf("invalid expires_in in token response: %w", err)
+		}
+		if seconds > 0 {
+			token.Expiry = time.Now().Add(time.Duration(seconds) * time.Second)
+		}
+	}
+
+	return token, nil
+}
+
+func oauth2Random(n int) (string, error) {
+	buf := make([]byte, n)
+
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
@@ -3,7 +3,6 @@ package httpexpect
 import (
 	"errors"
 	"fmt"
-	"reflect"
 	"sort"
 )
 
@@ -116,6 +115,43 @@ func (o *Object) Decode(target interface{}) *Object {
 	return o
 }
 
+// DecodeStrict is similar to Decode, but additionally fails if object
+// contains fields not present in target struct, or if fields marked as
+// required in target struct are absent. Each offending field is reported
+// with its path.
+//
+// Field is marked as required using "required" option of json tag or
+// "required" rule of validate tag, in the same way as for Value.MatchesType.
+// Nested structs, slices, arrays, and maps are checked recursively.
+// Types implementing json.Unmarshaler are not inspected.
+//
+// Example:
+//
+//	type User struct {
+//		ID   int    `json:"id,required"`
+//		Name string `json:"name"`
+//	}
+//
+//	object := NewObject(t, map[string]interface{}{
+//		"id":    123,
+//		"name":  "John",
+//		"email": "john@example.com",
+//	})
+//
+//	var target User
+//	object.DecodeStrict(&target) // fails, "email" is unknown
+func (o *Object) DecodeStrict(target interface{}) *Object {
+	opChain := o.chain.enter("DecodeStrict()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return o
+	}
+
+	canonDecodeStrict(opChain, o.value, target)
+	return o
+}
+
 // Alias is similar to Value.Alias.
 func (o *Object) Alias(name string) *Object {
 	opChain := o.chain.enter("Alias(%q)", name)
@@ -125,6 +161,26 @@ func (o *Object) Alias(name string) *Object {
 	return o
 }
 
+// Chain returns public handle to assertion chain of Object, which may be
+// used to implement custom matchers. See Chain for details.
+func (o *Object) Chain() *Chain {
+	return &Chain{chain: o.chain}
+}
+
+// AsWarning is similar to Value.AsWarning.
+func (o *Object) AsWarning() *Object {
+	opChain := o.chain.enter("AsWarning()")
+	defer opChain.leave()
+
+	warnChain := o.chain.clone()
+	warnChain.setWarning()
+
+	return &Object{
+		chain: warnChain,
+		value: o.value,
+	}
+}
+
 // Path is similar to Value.Path.
 func (o *Object) Path(path string) *Value {
 	opChain := o.chain.enter("Path(%q)", path)
@@ -253,7 +309,7 @@ func (o *Object) HasValue(key string, value interface{}) *Object {
 		return o
 	}
 
-	if !reflect.DeepEqual(expected, o.value[key]) {
+	if !canonEqual(o.value[key], expected) {
 		opChain.fail(AssertionFailure{
 			Type:     AssertEqual,
 			Actual:   &AssertionValue{o.value[key]},
@@ -307,7 +363,7 @@ func (o *Object) NotHasValue(key string, value interface{}) *Object {
 		return o
 	}
 
-	if reflect.DeepEqual(expected, o.value[key]) {
+	if canonEqual(o.value[key], expected) {
 		opChain.fail(AssertionFailure{
 			Type:     AssertNotEqual,
 			Actual:   &AssertionValue{o.value[key]},
@@ -804,13 +860,98 @@ func (o *Object) IsEqual(value interface{}) *Object {
 		return o
 	}
 
-	if !reflect.DeepEqual(expected, o.value) {
+	if equal, diffs := equalValues(o.value, expected); !equal {
 		opChain.fail(AssertionFailure{
 			Type:     AssertEqual,
 			Actual:   &AssertionValue{o.value},
 			Expected: &AssertionValue{expected},
-			Errors: []error{
+			Errors: append([]error{
 				errors.New("expected: maps are equal"),
+			}, diffs...),
+		})
+	}
+
+	return o
+}
+
+// IsEqualWith succeeds if object is equal to given value, taking into
+// account given options. Before comparison, both values are converted to
+// canonical form.
+//
+// See EqualOption for supported options and path syntax.
+//
+// Example:
+//
+//	object := NewObject(t, map[string]interface{}{"id": 1, "price": 9.999})
+//	object.IsEqualWith(map[string]interface{}{"id": 2, "price": 10},
+//		IgnorePaths("$..id"), Tolerance("$..price", 0.01))
+func (o *Object) IsEqualWith(value interface{}, options ...EqualOption) *Object {
+	opChain := o.chain.enter("IsEqualWith()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return o
+	}
+
+	opts, ok := buildEqualOptions(opChain, options)
+	if !ok {
+		return o
+	}
+
+	expected, ok := canonMap(opChain, value)
+	if !ok {
+		return o
+	}
+
+	if diffs := compareWithOptions(opts, o.value, expected); len(diffs) != 0 {
+		opChain.fail(AssertionFailure{
+			Type:     AssertEqual,
+			Actual:   &AssertionValue{o.value},
+			Expected: &AssertionValue{expected},
+			Errors: append([]error{
+				errors.New("expected: values are equal with given options"),
+			}, diffs...),
+		})
+	}
+
+	return o
+}
+
+// NotEqualWith succeeds if object is not equal to given value, taking into
+// account given options. Before comparison, both values are converted to
+// canonical form.
+//
+// See EqualOption for supported options and path syntax.
+//
+// Example:
+//
+//	object := NewObject(t, map[string]interface{}{"id": 1, "price": 9.999})
+//	object.NotEqualWith(map[string]interface{}{"id": 2, "price": 10}, IgnorePaths("$..id"))
+func (o *Object) NotEqualWith(value interface{}, options ...EqualOption) *Object {
+	opChain := o.chain.enter("NotEqualWith()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return o
+	}
+
+	opts, ok := buildEqualOptions(opChain, options)
+	if !ok {
+		return o
+	}
+
+	expected, ok := canonMap(opChain, value)
+	if !ok {
+		return o
+	}
+
+	if diffs := compareWithOptions(opts, o.value, expected); len(diffs) == 0 {
+		opChain.fail(AssertionFailure{
+			Type:     AssertNotEqual,
+			Actual:   &AssertionValue{o.value},
+			Expected: &AssertionValue{expected},
+			Errors: []error{
+				errors.New("expected: values are non-equal with given options"),
 			},
 		})
 	}
@@ -840,7 +981,7 @@ func (o *Object) NotEqual(value interface{}) *Object {
 		return o
 	}
 
-	if reflect.DeepEqual(expected, o.value) {
+	if equal, _ := equalValues(o.value, expected); equal {
 		opChain.fail(AssertionFailure{
 			Type:     AssertNotEqual,
 			Actual:   &AssertionValue{o.value},
@@ -897,7 +1038,7 @@ func (o *Object) InList(values ...interface{}) *Object {
 			return o
 		}
 
-		if reflect.DeepEqual(expected, o.value) {
+		if canonEqual(o.value, expected) {
 			isListed = true
 			// continue loop to check that all values are correct
 		}
@@ -956,7 +1097,7 @@ func (o *Object) NotInList(values ...interface{}) *Object {
 			return o
 		}
 
-		if reflect.DeepEqual(expected, o.value) {
+		if canonEqual(o.value, expected) {
 			opChain.fail(AssertionFailure{
 				Type:     AssertNotBelongs,
 				Actual:   &AssertionValue{o.value},
@@ -1126,14 +1267,14 @@ func (o *Object) ContainsSubset(value interface{}) *Object {
 		return o
 	}
 
-	if !containsSubset(opChain, o.value, value) {
+	if ok, diffs := containsSubset(opChain, o.value, value); !ok {
 		opChain.fail(AssertionFailure{
 			Type:     AssertContainsSubset,
 			Actual:   &AssertionValue{o.value},
 			Expected: &AssertionValue{value},
-			Errors: []error{
+			Errors: append([]error{
 				errors.New("expected: map contains sub-map"),
-			},
+			}, diffs...),
 		})
 	}
 
@@ -1157,7 +1298,7 @@ func (o *Object) NotContainsSubset(value interface{}) *Object {
 		return o
 	}
 
-	if containsSubset(opChain, o.value, value) {
+	if ok, _ := containsSubset(opChain, o.value, value); ok {
 		opChain.fail(AssertionFailure{
 			Type:     AssertNotContainsSubset,
 			Actual:   &AssertionValue{o.value},
@@ -1220,7 +1361,7 @@ func containsValue(
 	}
 
 	for k, v := range obj {
-		if reflect.DeepEqual(canonVal, v) {
+		if canonEqual(v, canonVal) {
 			return k, true
 		}
 	}
@@ -1230,13 +1371,13 @@ func containsValue(
 
 func containsSubset(
 	opChain *chain, obj map[string]interface{}, val interface{},
-) bool {
+) (bool, []error) {
 	canonVal, ok := canonMap(opChain, val)
 	if !ok {
-		return false
+		return false, nil
 	}
 
-	return isSubset(obj, canonVal)
+	return subsetValues(obj, canonVal)
 }
 
 func isSubset(outer, inner map[string]interface{}) bool {
@@ -1255,7 +1396,7 @@ func isSubset(outer, inner map[string]interface{}) bool {
 			}
 		}
 
-		if !reflect.DeepEqual(ov, iv) {
+		if !canonEqual(ov, iv) {
 			return false
 		}
 	}
@@ -17,6 +17,7 @@ func TestObject_FailedChain(t *testing.T) {
 
 		var target interface{}
 		value.Decode(&target)
+		value.DecodeStrict(&target)
 
 		value.Keys().chain.assertFailed(t)
 		value.Values().chain.assertFailed(t)
@@ -26,6 +27,8 @@ func TestObject_FailedChain(t *testing.T) {
 		value.NotEmpty()
 		value.IsEqual(nil)
 		value.NotEqual(nil)
+		value.IsEqualWith(nil)
+		value.NotEqualWith(nil)
 		value.InList(nil)
 		value.NotInList(nil)
 		value.ContainsKey("foo")
@@ -248,6 +251,62 @@ func TestObject_Decode(t *testing.T) {
 	})
 }
 
+func TestObject_DecodeStrict(t *testing.T) {
+	type Item struct {
+		ID    int     `json:"id,required"`
+		Price float64 `json:"price"`
+	}
+
+	type Order struct {
+		Items []Item `json:"items,required"`
+	}
+
+	t.Run("exact fields", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		value := NewObject(reporter, map[string]interface{}{
+			"items": []interface{}{
+				map[string]interface{}{"id": 1, "price": 2.5},
+				map[string]interface{}{"id": 2},
+			},
+		})
+
+		var target Order
+		value.DecodeStrict(&target)
+
+		value.chain.assertNotFailed(t)
+		assert.Equal(t, Order{[]Item{{1, 2.5}, {2, 0}}}, target)
+	})
+
+	t.Run("nested mismatch", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		value := NewObject(reporter, map[string]interface{}{
+			"items": []interface{}{
+				map[string]interface{}{"id": 1, "price": 2.5},
+				map[string]interface{}{"price": 3.5, "discount": 1},
+			},
+		})
+
+		var target Order
+		value.DecodeStrict(&target)
+
+		value.chain.assertFailed(t)
+	})
+
+	t.Run("target is not struct", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		value := NewObject(reporter, map[string]interface{}{"foo": 123})
+
+		var target map[string]int
+		value.DecodeStrict(&target)
+
+		value.chain.assertNotFailed(t)
+		assert.Equal(t, map[string]int{"foo": 123}, target)
+	})
+}
+
 func TestObject_Alias(t *testing.T) {
 	reporter := newMockReporter(t)
 
@@ -369,6 +428,54 @@ func TestObject_IsEmpty(t *testing.T) {
 	})
 }
 
+func TestObject_IsEqualWith(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	value := NewObject(reporter, map[string]interface{}{
+		"id":    "b8e1",
+		"price": 9.999,
+		"tags":  []interface{}{"b", "a"},
+		"note":  nil,
+	})
+
+	expected := map[string]interface{}{
+		"price": 10,
+		"tags":  []interface{}{"a", "b"},
+	}
+
+	value.IsEqualWith(expected,
+		IgnorePaths("$.id"),
+		Tolerance("$.price", 0.01),
+		UnorderedAt("$.tags"),
+		TreatNullAsMissing())
+	value.chain.assertNotFailed(t)
+	value.chain.clearFailed()
+
+	value.NotEqualWith(expected,
+		IgnorePaths("$.id"),
+		Tolerance("$.price", 0.01),
+		UnorderedAt("$.tags"),
+		TreatNullAsMissing())
+	value.chain.assertFailed(t)
+	value.chain.clearFailed()
+
+	value.IsEqualWith(expected, IgnorePaths("$.id"), TreatNullAsMissing())
+	value.chain.assertFailed(t)
+	value.chain.clearFailed()
+
+	value.NotEqualWith(expected, IgnorePaths("$.id"), TreatNullAsMissing())
+	value.chain.assertNotFailed(t)
+	value.chain.clearFailed()
+
+	value.IsEqualWith(expected, IgnorePaths("id"))
+	value.chain.assertFailed(t)
+	value.chain.clearFailed()
+
+	value.IsEqualWith([]interface{}{})
+	value.chain.assertFailed(t)
+	value.chain.clearFailed()
+}
+
 func TestObject_IsEqual(t *testing.T) {
 	t.Run("empty", func(t *testing.T) {
 		reporter := newMockReporter(t)
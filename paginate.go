@@ -0,0 +1,292 @@
+package httpexpect
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// PaginateOpts define how Expect.Paginate retrieves subsequent pages.
+type PaginateOpts struct {
+	// JSONPath (RFC 9535) to array of items in page body, e.g. "$.items"
+	// Path should match exactly one node
+	// If empty, page body itself should be an array
+	ItemsPath string
+
+	// JSONPath (RFC 9535) to cursor of the next page in page body,
+	// e.g. "$.next_cursor"
+	// If empty, next page is found using "Link" header with rel="next"
+	// Pagination stops when cursor is missing, null or empty
+	// Cursor may be a string or a number; numbers are passed as is, without
+	// loss of precision
+	CursorPath string
+
+	// Name of query parameter used to pass cursor to the next page request
+	// If empty, "cursor" is used
+	CursorParam string
+
+	// Maximum number of pages to retrieve
+	// If zero, 100 is used
+	// If there are more pages, failure is reported
+	MaxPages int
+}
+
+const defaultMaxPages = 100
+
+type paginator struct {
+	opts PaginateOpts
+	req  *Request
+	url  *url.URL
+
+	itemsQuery  *jsonPathQuery
+	cursorQuery *jsonPathQuery
+}
+
+func newPaginator(req *Request, opts PaginateOpts) *paginator {
+	if opts.CursorParam == "" {
+		opts.CursorParam = "cursor"
+	}
+
+	if opts.MaxPages == 0 {
+		opts.MaxPages = defaultMaxPages
+	}
+
+	return &paginator{
+		opts: opts,
+		req:  req,
+	}
+}
+
+func (p *paginator) run(opChain *chain) []interface{} {
+	items := []interface{}{}
+
+	if p.opts.MaxPages < 0 {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected negative PaginateOpts.MaxPages"),
+			},
+		})
+		return nil
+	}
+
+	if p.req == nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected nil request"),
+			},
+		})
+		return nil
+	}
+
+	for _, path := range []struct {
+		name  string
+		value string
+		query **jsonPathQuery
+	}{
+		{"ItemsPath", p.opts.ItemsPath, &p.itemsQuery},
+		{"CursorPath", p.opts.CursorPath, &p.cursorQuery},
+	} {
+		if path.value == "" {
+			continue
+		}
+		query, err := parseJSONPath(path.value)
+		if err != nil {
+			opChain.fail(AssertionFailure{
+				Type: AssertUsage,
+				Errors: []error{
+					fmt.Errorf("invalid PaginateOpts.%s", path.name),
+					err,
+				},
+			})
+			return nil
+		}
+		*path.query = query
+	}
+
+	if p.req.chain.failed() {
+		opChain.fail(AssertionFailure{
+			Type: AssertOperation,
+			Errors: []error{
+				errors.New("can't paginate: request has failures"),
+			},
+		})
+		return nil
+	}
+
+	req := p.req
+
+	for page := 1; ; page++ {
+		pageChain := opChain.enter("Page(%d)", page)
+
+		pageItems, next, ok := p.fetch(pageChain, req)
+
+		pageChain.leave()
+
+		if !ok {
+			return nil
+		}
+
+		items = append(items, pageItems...)
+
+		if next == nil {
+			break
+		}
+
+		if page == p.opts.MaxPages {
+			opChain.fail(AssertionFailure{
+				Type: AssertOperation,
+				Errors: []error{
+					fmt.Errorf("pagination didn't complete within %d pages,"+
+						" next page: %s", p.opts.MaxPages, next.String()),
+				},
+			})
+			return nil
+		}
+
+		req = p.nextRequest(opChain, next)
+	}
+
+	return items
+}
+
+// Send page request and return its items and next page URL, if any.
+func (p *paginator) fetch(
+	opChain *chain, req *Request,
+) ([]interface{}, *url.URL, bool) {
+	resp := req.expect(opChain)
+	if resp == nil || opChain.treeFailed() {
+		return nil, nil, false
+	}
+
+	if p.url == nil {
+		p.url = req.httpReq.URL
+	}
+
+	// numeric cursors, like large ids, must be passed without loss of precision
+	body := resp.getJSON(opChain, ContentOpts{UseNumber: true})
+	if opChain.failed() {
+		return nil, nil, false
+	}
+
+	items, ok := p.items(opChain, body)
+	if !ok {
+		return nil, nil, false
+	}
+
+	if p.cursorQuery != nil {
+		next, ok := p.nextByCursor(opChain, body)
+		return items, next, ok
+	}
+
+	links, ok := resp.getLinks(opChain)
+	if !ok {
+		return nil, nil, false
+	}
+
+	if link := findWebLink(links, "next"); link != nil {
+		return items, link.target, true
+	}
+
+	return items, nil, true
+}
+
+func (p *paginator) items(opChain *chain, body interface{}) ([]interface{}, bool) {
+	value := body
+
+	if p.itemsQuery != nil {
+		nodes := p.itemsQuery.eval(body)
+		if len(nodes) != 1 {
+			opChain.fail(AssertionFailure{
+				Type:     AssertMatchPath,
+				Actual:   &AssertionValue{body},
+				Expected: &AssertionValue{p.opts.ItemsPath},
+				Errors: []error{
+					errors.New("expected: page body matches items json path"),
+					fmt.Errorf("path matched %d nodes, expected exactly one",
+						len(nodes)),
+				},
+			})
+			return nil, false
+		}
+		value = nodes[0].value
+	}
+
+	items, ok := value.([]interface{})
+	if !ok {
+		opChain.fail(AssertionFailure{
+			Type:   AssertType,
+			Actual: &AssertionValue{value},
+			Errors: []error{
+				errors.New("expected: page items are array"),
+			},
+		})
+		return nil, false
+	}
+
+	return items, true
+}
+
+func (p *paginator) nextByCursor(
+	opChain *chain, body interface{},
+) (*url.URL, bool) {
+	nodes := p.cursorQuery.eval(body)
+	if len(nodes) > 1 {
+		opChain.fail(AssertionFailure{
+			Type:     AssertMatchPath,
+			Actual:   &AssertionValue{body},
+			Expected: &AssertionValue{p.opts.CursorPath},
+			Errors: []error{
+				errors.New("expected: page body matches cursor json path"),
+				fmt.Errorf("path matched %d nodes, expected at most one",
+					len(nodes)),
+			},
+		})
+		return nil, false
+	}
+
+	if len(nodes) == 0 || nodes[0].value == nil || nodes[0].value == "" {
+		return nil, true
+	}
+
+	cursor := nodes[0].value
+
+	var cursorStr string
+
+	switch c := cursor.(type) {
+	case string:
+		cursorStr = c
+	case json.Number:
+		cursorStr = string(c)
+	case float64:
+		cursorStr = strconv.FormatFloat(c, 'f', -1, 64)
+	default:
+		opChain.fail(AssertionFailure{
+			Type:   AssertType,
+			Actual: &AssertionValue{cursor},
+			Errors: []error{
+				errors.New("expected: page cursor is string or number"),
+			},
+		})
+		return nil, false
+	}
+
+	next := *p.url
+	query := next.Query()
+	query.Set(p.opts.CursorParam, cursorStr)
+	next.RawQuery = query.Encode()
+
+	return &next, true
+}
+
+// Build request for the next page, with same method, config, headers
+// and body as the first request.
+func (p *paginator) nextRequest(opChain *chain, next *url.URL) *Request {
+	req := p.req.replay(opChain)
+	req.httpReq.URL = next
+
+	return req
+}
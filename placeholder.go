@@ -0,0 +1,358 @@
+package httpexpect
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Placeholder is a matcher that may be used in place of a value anywhere
+// inside expected value passed to IsEqual, NotEqual, IsEqualWith,
+// NotEqualWith, ContainsSubset, and NotContainsSubset methods.
+//
+// Instead of being compared for equality, placeholder checks that actual
+// value at the same place satisfies its condition. If it doesn't, failure
+// reports path to the mismatched value.
+//
+// Placeholders may be nested into maps, slices, and exported struct fields.
+//
+// Example:
+//
+//	object.IsEqual(map[string]interface{}{
+//		"id":        httpexpect.UUID(),
+//		"name":      "John",
+//		"email":     httpexpect.Regexp(`^\S+@\S+$`),
+//		"age":       httpexpect.NumberInRange(18, 99),
+//		"createdAt": httpexpect.DateTimeAfter(startTime),
+//		"meta":      httpexpect.Any(),
+//	})
+type Placeholder struct {
+	name  string
+	match func(value interface{}) error
+}
+
+// String returns placeholder name, e.g. "<AnyString()>".
+func (p *Placeholder) String() string {
+	return "<" + p.name + ">"
+}
+
+// MarshalJSON implements json.Marshaler.
+//
+// Placeholder is marshaled into a string with its name, which is used
+// when expected value is printed in failure report.
+func (p *Placeholder) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+
+	if err := enc.Encode(p.String()); err != nil {
+		return nil, err
+	}
+
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// Any returns a Placeholder that matches any value, including null.
+func Any() *Placeholder {
+	return &Placeholder{
+		name: "Any()",
+		match: func(value interface{}) error {
+			return nil
+		},
+	}
+}
+
+// AnyString returns a Placeholder that matches any string.
+func AnyString() *Placeholder {
+	return &Placeholder{
+		name: "AnyString()",
+		match: func(value interface{}) error {
+			if _, ok := value.(string); !ok {
+				return fmt.Errorf("expected string, got %s", formatEqualValue(value))
+			}
+			return nil
+		},
+	}
+}
+
+// Regexp returns a Placeholder that matches strings matching given
+// regular expression.
+//
+// If regular expression is invalid, placeholder doesn't match any value.
+func Regexp(re string) *Placeholder {
+	rx, err := regexp.Compile(re)
+
+	return &Placeholder{
+		name: fmt.Sprintf("Regexp(%q)", re),
+		match: func(value interface{}) error {
+			if err != nil {
+				return fmt.Errorf("invalid regexp %q: %s", re, err)
+			}
+			s, ok := value.(string)
+			if !ok {
+				return fmt.Errorf("expected string, got %s", formatEqualValue(value))
+			}
+			if !rx.MatchString(s) {
+				return fmt.Errorf("expected string matching %q, got %q", re, s)
+			}
+			return nil
+		},
+	}
+}
+
+// NumberInRange returns a Placeholder that matches numbers in given
+// inclusive range. Bounds may be of any numeric type.
+func NumberInRange(min, max interface{}) *Placeholder {
+	minVal, minErr := placeholderNumber(min)
+	maxVal, maxErr := placeholderNumber(max)
+
+	return &Placeholder{
+		name: fmt.Sprintf("NumberInRange(%v, %v)", min, max),
+		match: func(value interface{}) error {
+			if minErr != nil {
+				return minErr
+			}
+			if maxErr != nil {
+				return maxErr
+			}
+			r, ok := canonRat(value)
+			if !ok || !isNumber(value) {
+				return fmt.Errorf("expected number, got %s", formatEqualValue(value))
+			}
+			n, _ := r.Float64()
+			if n < minVal || n > maxVal {
+				return fmt.Errorf("expected number in range [%v; %v], got %v",
+					min, max, n)
+			}
+			return nil
+		},
+	}
+}
+
+// DateTimeAfter returns a Placeholder that matches strings with date and
+// time in RFC 3339 format that is after given time.
+func DateTimeAfter(t time.Time) *Placeholder {
+	return &Placeholder{
+		name: fmt.Sprintf("DateTimeAfter(%s)", t.Format(time.RFC3339Nano)),
+		match: func(value interface{}) error {
+			s, ok := value.(string)
+			if !ok {
+				return fmt.Errorf("expected string, got %s", formatEqualValue(value))
+			}
+			dt, err := time.Parse(time.RFC3339Nano, s)
+			if err != nil {
+				return fmt.Errorf("expected RFC 3339 date and time, got %q", s)
+			}
+			if !dt.After(t) {
+				return fmt.Errorf("expected date and time after %s, got %q",
+					t.Format(time.RFC3339Nano), s)
+			}
+			return nil
+		},
+	}
+}
+
+var placeholderUUID = regexp.MustCompile(
+	`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// UUID returns a Placeholder that matches strings with UUID in canonical
+// textual representation, e.g. "f81d4fae-7dec-11d0-a765-00a0c91e6bf6".
+func UUID() *Placeholder {
+	return &Placeholder{
+		name: "UUID()",
+		match: func(value interface{}) error {
+			s, ok := value.(string)
+			if !ok {
+				return fmt.Errorf("expected string, got %s", formatEqualValue(value))
+			}
+			if !placeholderUUID.MatchString(s) {
+				return fmt.Errorf("expected UUID, got %q", s)
+			}
+			return nil
+		},
+	}
+}
+
+// Func returns a Placeholder that matches values for which given function
+// returns true.
+//
+// Function receives value in canonical form: nil, bool, float64,
+// json.Number, string, []interface{}, or map[string]interface{}.
+//
+// Example:
+//
+//	object.IsEqual(map[string]interface{}{
+//		"count": httpexpect.Func(func(value interface{}) bool {
+//			n, ok := value.(float64)
+//			return ok && int(n)%2 == 0
+//		}),
+//	})
+func Func(fn func(value interface{}) bool) *Placeholder {
+	return &Placeholder{
+		name: "Func()",
+		match: func(value interface{}) error {
+			if fn == nil {
+				return errors.New("unexpected nil function")
+			}
+			if !fn(value) {
+				return fmt.Errorf("function returned false for %s",
+					formatEqualValue(value))
+			}
+			return nil
+		},
+	}
+}
+
+func placeholderNumber(value interface{}) (f float64, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("invalid number %v: %v", value, r)
+		}
+	}()
+	return reflect.ValueOf(value).Convert(reflect.TypeOf(float64(0))).Float(), nil
+}
+
+var placeholderType = reflect.TypeOf((*Placeholder)(nil))
+
+// cache for mayHavePlaceholders, reflect.Type => bool
+var placeholderTypes sync.Map
+
+// Check if values of given type may contain placeholders, i.e. if
+// canonValue should traverse them instead of just marshaling.
+func mayHavePlaceholders(typ reflect.Type) bool {
+	if cached, ok := placeholderTypes.Load(typ); ok {
+		return cached.(bool)
+	}
+
+	// result for type is cached only when computed from the top, because
+	// intermediate results for recursive types may be incomplete
+	result := checkPlaceholderType(typ, map[reflect.Type]bool{})
+	placeholderTypes.Store(typ, result)
+
+	return result
+}
+
+func checkPlaceholderType(typ reflect.Type, visiting map[reflect.Type]bool) bool {
+	if typ == placeholderType || typ.Kind() == reflect.Interface {
+		return true
+	}
+
+	// values with custom marshaling are opaque
+	if typ.Implements(jsonMarshalerType) || typ.Implements(textMarshalerType) ||
+		reflect.PtrTo(typ).Implements(jsonMarshalerType) ||
+		reflect.PtrTo(typ).Implements(textMarshalerType) {
+		return false
+	}
+
+	if visiting[typ] {
+		return false
+	}
+	visiting[typ] = true
+	defer delete(visiting, typ)
+
+	switch typ.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Slice, reflect.Array:
+		return checkPlaceholderType(typ.Elem(), visiting)
+
+	case reflect.Struct:
+		for _, field := range strictDecodeFields(typ) {
+			if checkPlaceholderType(field.typ, visiting) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// Check if canonical value contains placeholders.
+func hasPlaceholders(value interface{}) bool {
+	switch v := value.(type) {
+	case *Placeholder:
+		return true
+
+	case []interface{}:
+		for _, elem := range v {
+			if hasPlaceholders(elem) {
+				return true
+			}
+		}
+
+	case map[string]interface{}:
+		for _, elem := range v {
+			if hasPlaceholders(elem) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// Check if canonical values are equal.
+// Expected value may contain placeholders; in this case, returned
+// errors describe mismatches, with paths to mismatched values.
+func equalValues(actual, expected interface{}) (bool, []error) {
+	if !hasPlaceholders(expected) {
+		return canonEqual(actual, expected), nil
+	}
+
+	diffs := compareWithOptions(&equalOptions{}, actual, expected)
+
+	return len(diffs) == 0, diffs
+}
+
+// Check if canonical map is a subset of another canonical map.
+// Nested maps are checked recursively, other values should be equal.
+// Inner map may contain placeholders; in this case, returned errors
+// describe mismatches, with paths to mismatched values.
+func subsetValues(outer, inner map[string]interface{}) (bool, []error) {
+	if !hasPlaceholders(inner) {
+		return isSubset(outer, inner), nil
+	}
+
+	var diffs []error
+	subsetDiffs(&diffs, nil, outer, inner)
+
+	return len(diffs) == 0, diffs
+}
+
+func subsetDiffs(
+	diffs *[]error, path []interface{}, outer, inner map[string]interface{},
+) {
+	keys := make([]string, 0, len(inner))
+	for k := range inner {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	opts := &equalOptions{}
+
+	for _, k := range keys {
+		iv := inner[k]
+		childPath := appendEqualPath(path, k)
+
+		ov, ok := outer[k]
+		if !ok {
+			*diffs = append(*diffs, fmt.Errorf("at %s: missing member",
+				formatEqualPath(childPath)))
+			continue
+		}
+
+		if ovm, ok := ov.(map[string]interface{}); ok {
+			if ivm, ok := iv.(map[string]interface{}); ok {
+				subsetDiffs(diffs, childPath, ovm, ivm)
+				continue
+			}
+		}
+
+		opts.compare(diffs, childPath, ov, iv)
+	}
+}
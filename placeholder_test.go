@@ -0,0 +1,428 @@
+package httpexpect
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlaceholder_Match(t *testing.T) {
+	after := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	isEven := func(value interface{}) bool {
+		n, ok := value.(float64)
+		return ok && int(n)%2 == 0
+	}
+
+	cases := []struct {
+		name        string
+		placeholder *Placeholder
+		matches     []interface{}
+		misses      []interface{}
+	}{
+		{
+			name:        "Any",
+			placeholder: Any(),
+			matches: []interface{}{
+				nil, true, 1.0, "", []interface{}{}, map[string]interface{}{},
+			},
+		},
+		{
+			name:        "AnyString",
+			placeholder: AnyString(),
+			matches:     []interface{}{"", "foo"},
+			misses:      []interface{}{nil, 1.0, []interface{}{"foo"}},
+		},
+		{
+			name:        "Regexp",
+			placeholder: Regexp(`^a+b$`),
+			matches:     []interface{}{"ab", "aaab"},
+			misses:      []interface{}{"b", "abb", nil, 1.0},
+		},
+		{
+			name:        "Regexp invalid",
+			placeholder: Regexp(`[`),
+			misses:      []interface{}{"[", ""},
+		},
+		{
+			name:        "NumberInRange",
+			placeholder: NumberInRange(1, 2.5),
+			matches:     []interface{}{1.0, 2.0, 2.5},
+			misses:      []interface{}{0.9, 2.6, "1", nil},
+		},
+		{
+			name:        "NumberInRange invalid",
+			placeholder: NumberInRange("1", 2),
+			misses:      []interface{}{1.0, 2.0},
+		},
+		{
+			name:        "DateTimeAfter",
+			placeholder: DateTimeAfter(after),
+			matches: []interface{}{
+				"2020-01-01T00:00:01Z",
+				"2020-01-01T03:00:00+02:00",
+				"2021-06-01T12:00:00.123Z",
+			},
+			misses: []interface{}{
+				"2020-01-01T00:00:00Z",
+				"2019-12-31T23:59:59Z",
+				"2020-01-01T01:00:00+02:00",
+				"2021-06-01",
+				1.0,
+			},
+		},
+		{
+			name:        "UUID",
+			placeholder: UUID(),
+			matches: []interface{}{
+				"f81d4fae-7dec-11d0-a765-00a0c91e6bf6",
+				"F81D4FAE-7DEC-11D0-A765-00A0C91E6BF6",
+			},
+			misses: []interface{}{
+				"f81d4fae7dec11d0a76500a0c91e6bf6",
+				"f81d4fae-7dec-11d0-a765-00a0c91e6bf",
+				"g81d4fae-7dec-11d0-a765-00a0c91e6bf6",
+				nil,
+			},
+		},
+		{
+			name:        "Func",
+			placeholder: Func(isEven),
+			matches:     []interface{}{0.0, 2.0},
+			misses:      []interface{}{1.0, "2"},
+		},
+		{
+			name:        "Func nil",
+			placeholder: Func(nil),
+			misses:      []interface{}{1.0},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			for _, value := range tc.matches {
+				assert.NoError(t, tc.placeholder.match(value), "value: %v", value)
+			}
+			for _, value := range tc.misses {
+				assert.Error(t, tc.placeholder.match(value), "value: %v", value)
+			}
+		})
+	}
+}
+
+func TestPlaceholder_String(t *testing.T) {
+	assert.Equal(t, "<Any()>", Any().String())
+	assert.Equal(t, "<UUID()>", UUID().String())
+	assert.Equal(t, `<Regexp("^a$")>`, Regexp("^a$").String())
+	assert.Equal(t, "<NumberInRange(1, 5)>", NumberInRange(1, 5).String())
+
+	assert.Equal(t, "<Func()>", Func(func(interface{}) bool { return true }).String())
+
+	b, err := json.Marshal(map[string]interface{}{"id": UUID()})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"id":"<UUID()>"}`, string(b))
+}
+
+func TestPlaceholder_Canon(t *testing.T) {
+	type item struct {
+		ID    interface{} `json:"id"`
+		Price float64     `json:"price"`
+	}
+
+	type order struct {
+		Items []item                 `json:"items"`
+		Meta  map[string]interface{} `json:"meta"`
+	}
+
+	id := UUID()
+	anyVal := Any()
+
+	chain := newMockChain(t).enter("test")
+	defer chain.leave()
+
+	value, ok := canonValue(chain, &order{
+		Items: []item{{ID: id, Price: 1}},
+		Meta:  map[string]interface{}{"tags": []interface{}{anyVal, "x"}},
+	})
+	require.True(t, ok)
+
+	assert.Equal(t, map[string]interface{}{
+		"items": []interface{}{
+			map[string]interface{}{"id": id, "price": 1.0},
+		},
+		"meta": map[string]interface{}{
+			"tags": []interface{}{anyVal, "x"},
+		},
+	}, value)
+
+	assert.True(t, hasPlaceholders(value))
+
+	plain, ok := canonValue(chain, map[string]interface{}{"id": "<UUID()>"})
+	require.True(t, ok)
+
+	assert.Equal(t, map[string]interface{}{"id": "<UUID()>"}, plain)
+	assert.False(t, hasPlaceholders(plain))
+
+	mixed, ok := canonValue(chain, map[string]interface{}{
+		"id":   id,
+		"name": "<UUID()>",
+	})
+	require.True(t, ok)
+
+	assert.Equal(t, map[string]interface{}{"id": id, "name": "<UUID()>"}, mixed)
+}
+
+func TestPlaceholder_CanonStruct(t *testing.T) {
+	type base struct {
+		ID interface{} `json:"id"`
+	}
+
+	type extra struct {
+		Tag interface{} `json:"tag"`
+	}
+
+	type entity struct {
+		base
+		*extra
+		Name  interface{} `json:"name,omitempty"`
+		Count int         `json:"count,string"`
+		When  time.Time   `json:"when"`
+		skip  interface{}
+	}
+
+	id := UUID()
+	when := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	chain := newMockChain(t).enter("test")
+	defer chain.leave()
+
+	value, ok := canonValue(chain, entity{
+		base:  base{ID: id},
+		Count: 3,
+		When:  when,
+		skip:  Any(),
+	})
+	require.True(t, ok)
+
+	assert.Equal(t, map[string]interface{}{
+		"id":    id,
+		"count": "3",
+		"when":  "2024-01-01T00:00:00Z",
+	}, value)
+
+	value, ok = canonValue(chain, &entity{
+		extra: &extra{Tag: AnyString()},
+		Name:  Any(),
+	})
+	require.True(t, ok)
+
+	obj := value.(map[string]interface{})
+	assert.Nil(t, obj["id"])
+	assert.IsType(t, &Placeholder{}, obj["tag"])
+	assert.IsType(t, &Placeholder{}, obj["name"])
+
+	type node struct {
+		Next *node
+	}
+
+	n := &node{}
+	n.Next = n
+
+	_, ok = canonValue(newMockChain(t).enter("test"),
+		map[string]interface{}{"node": []interface{}{n}})
+	assert.False(t, ok)
+}
+
+func TestPlaceholder_Types(t *testing.T) {
+	type plain struct {
+		A int
+		B []string
+	}
+
+	type nested struct {
+		A []map[string]*plain
+		B *Placeholder
+	}
+
+	type recursive struct {
+		Next  *recursive
+		Value interface{}
+	}
+
+	assert.False(t, mayHavePlaceholders(reflect.TypeOf(0)))
+	assert.False(t, mayHavePlaceholders(reflect.TypeOf(plain{})))
+	assert.False(t, mayHavePlaceholders(reflect.TypeOf(time.Time{})))
+	assert.False(t, mayHavePlaceholders(reflect.TypeOf(json.Number(""))))
+	assert.True(t, mayHavePlaceholders(reflect.TypeOf(nested{})))
+	assert.True(t, mayHavePlaceholders(reflect.TypeOf(&recursive{})))
+	assert.True(t, mayHavePlaceholders(reflect.TypeOf(map[string]interface{}{})))
+	assert.True(t, mayHavePlaceholders(reflect.TypeOf(Any())))
+}
+
+func TestPlaceholder_Equal(t *testing.T) {
+	actual := map[string]interface{}{
+		"id":   "f81d4fae-7dec-11d0-a765-00a0c91e6bf6",
+		"name": "John",
+		"items": []interface{}{
+			map[string]interface{}{"sku": "a-1", "qty": 2.0},
+			map[string]interface{}{"sku": "b-2", "qty": 50.0},
+		},
+	}
+
+	t.Run("match", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		value := NewValue(reporter, actual)
+
+		expected := map[string]interface{}{
+			"id":   UUID(),
+			"name": AnyString(),
+			"items": []interface{}{
+				map[string]interface{}{"sku": Regexp(`^\w-\d$`), "qty": Any()},
+				map[string]interface{}{"sku": "b-2", "qty": NumberInRange(1, 100)},
+			},
+		}
+
+		value.IsEqual(expected)
+		value.chain.assertNotFailed(t)
+		value.chain.clearFailed()
+
+		value.NotEqual(expected)
+		value.chain.assertFailed(t)
+		value.chain.clearFailed()
+
+		value.Object().IsEqual(expected)
+		value.chain.assertNotFailed(t)
+		value.chain.clearFailed()
+
+		value.Object().Value("items").Array().IsEqual(expected["items"])
+		value.chain.assertNotFailed(t)
+		value.chain.clearFailed()
+	})
+
+	t.Run("mismatch", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+		chain := newChainWithConfig("test", Config{
+			AssertionHandler: handler,
+		}.withDefaults())
+
+		newObject(chain, actual).IsEqual(map[string]interface{}{
+			"id":   UUID(),
+			"name": "John",
+			"items": []interface{}{
+				map[string]interface{}{"sku": "a-1", "qty": Any()},
+				map[string]interface{}{"sku": "b-2", "qty": NumberInRange(1, 10)},
+			},
+		})
+
+		require.NotNil(t, handler.failure)
+		require.Equal(t, 2, len(handler.failure.Errors))
+		assert.Contains(t,
+			handler.failure.Errors[1].Error(), "at $.items[1].qty: ")
+	})
+
+	t.Run("literal placeholder name", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		value := NewValue(reporter, map[string]interface{}{
+			"id":   "f81d4fae-7dec-11d0-a765-00a0c91e6bf6",
+			"name": "John",
+		})
+
+		value.IsEqual(map[string]interface{}{
+			"id":   AnyString(),
+			"name": "<AnyString()>",
+		})
+		value.chain.assertFailed(t)
+	})
+
+	t.Run("no placeholders", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+		chain := newChainWithConfig("test", Config{
+			AssertionHandler: handler,
+		}.withDefaults())
+
+		newObject(chain, actual).IsEqual(map[string]interface{}{})
+
+		require.NotNil(t, handler.failure)
+		assert.Equal(t, 1, len(handler.failure.Errors))
+	})
+}
+
+func TestPlaceholder_Unordered(t *testing.T) {
+	t.Run("placeholder before exact value", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		value := NewValue(reporter, []interface{}{"ab", "ac"})
+
+		value.IsEqualWith([]interface{}{Regexp("^a"), "ab"}, UnorderedAt("$"))
+		value.chain.assertNotFailed(t)
+	})
+
+	t.Run("no matching element", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		value := NewValue(reporter, []interface{}{"ab", "bc"})
+
+		value.IsEqualWith([]interface{}{Regexp("^a"), "ab"}, UnorderedAt("$"))
+		value.chain.assertFailed(t)
+	})
+}
+
+func TestPlaceholder_ContainsSubset(t *testing.T) {
+	actual := map[string]interface{}{
+		"id": "f81d4fae-7dec-11d0-a765-00a0c91e6bf6",
+		"user": map[string]interface{}{
+			"name":  "John",
+			"email": "john@example.com",
+		},
+		"tags": []interface{}{"a", "b"},
+	}
+
+	t.Run("match", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		value := NewObject(reporter, actual)
+
+		subset := map[string]interface{}{
+			"id": UUID(),
+			"user": map[string]interface{}{
+				"email": Regexp(`@example\.com$`),
+			},
+			"tags": []interface{}{"a", AnyString()},
+		}
+
+		value.ContainsSubset(subset)
+		value.chain.assertNotFailed(t)
+		value.chain.clearFailed()
+
+		value.NotContainsSubset(subset)
+		value.chain.assertFailed(t)
+		value.chain.clearFailed()
+	})
+
+	t.Run("mismatch", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+		chain := newChainWithConfig("test", Config{
+			AssertionHandler: handler,
+		}.withDefaults())
+
+		newObject(chain, actual).ContainsSubset(map[string]interface{}{
+			"user": map[string]interface{}{
+				"email": Regexp(`@example\.org$`),
+				"phone": Any(),
+			},
+		})
+
+		require.NotNil(t, handler.failure)
+		require.Equal(t, 3, len(handler.failure.Errors))
+		assert.Contains(t,
+			handler.failure.Errors[1].Error(), "at $.user.email: ")
+		assert.Contains(t,
+			handler.failure.Errors[2].Error(), "at $.user.phone: missing member")
+	})
+}
@@ -0,0 +1,362 @@
+package httpexpect
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Problem provides methods to inspect Problem Details object
+// (RFC 9457, formerly RFC 7807).
+type Problem struct {
+	noCopy noCopy
+	chain  *chain
+	value  map[string]interface{}
+}
+
+func newProblem(parent *chain, val map[string]interface{}) *Problem {
+	p := &Problem{chain: parent.clone(), value: nil}
+
+	opChain := p.chain.enter("")
+	defer opChain.leave()
+
+	if val == nil {
+		opChain.fail(AssertionFailure{
+			Type:   AssertNotNil,
+			Actual: &AssertionValue{val},
+			Errors: []error{
+				errors.New("expected: non-nil problem details"),
+			},
+		})
+	} else {
+		p.value = val
+	}
+
+	return p
+}
+
+// Raw returns underlying map with all problem members, including
+// extension members.
+//
+// Example:
+//
+//	problem := resp.Problem()
+//	assert.Equal(t, "Out of credit", problem.Raw()["title"])
+func (p *Problem) Raw() map[string]interface{} {
+	return p.value
+}
+
+// Alias is similar to Value.Alias.
+func (p *Problem) Alias(name string) *Problem {
+	opChain := p.chain.enter("Alias(%q)", name)
+	defer opChain.leave()
+
+	p.chain.setAlias(name)
+	return p
+}
+
+// Chain returns public handle to assertion chain of Problem, which may be
+// used to implement custom matchers. See Chain for details.
+func (p *Problem) Chain() *Chain {
+	return &Chain{chain: p.chain}
+}
+
+// AsWarning is similar to Value.AsWarning.
+func (p *Problem) AsWarning() *Problem {
+	opChain := p.chain.enter("AsWarning()")
+	defer opChain.leave()
+
+	warnChain := p.chain.clone()
+	warnChain.setWarning()
+
+	return &Problem{
+		chain: warnChain,
+		value: p.value,
+	}
+}
+
+// Type returns a new String instance with "type" member.
+//
+// If member is absent, returns "about:blank", as specified by RFC.
+// If member is not a string, failure is reported.
+//
+// Example:
+//
+//	problem := resp.Problem()
+//	problem.Type().IsEqual("https://example.com/probs/out-of-credit")
+func (p *Problem) Type() *String {
+	opChain := p.chain.enter("Type()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newString(opChain, "")
+	}
+
+	if _, ok := p.value["type"]; !ok {
+		return newString(opChain, "about:blank")
+	}
+
+	s, _ := p.getString(opChain, "type")
+
+	return newString(opChain, s)
+}
+
+// Title returns a new String instance with "title" member.
+//
+// If member is absent or is not a string, failure is reported.
+//
+// Example:
+//
+//	problem := resp.Problem()
+//	problem.Title().IsEqual("You do not have enough credit.")
+func (p *Problem) Title() *String {
+	opChain := p.chain.enter("Title()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newString(opChain, "")
+	}
+
+	s, _ := p.getString(opChain, "title")
+
+	return newString(opChain, s)
+}
+
+// Status returns a new Number instance with "status" member.
+//
+// If member is absent or is not a number, failure is reported.
+//
+// Example:
+//
+//	problem := resp.Problem()
+//	problem.Status().IsEqual(403)
+func (p *Problem) Status() *Number {
+	opChain := p.chain.enter("Status()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newNumber(opChain, 0)
+	}
+
+	if !p.hasMember(opChain, "status") {
+		return newNumber(opChain, 0)
+	}
+
+	status, ok := p.value["status"].(float64)
+	if !ok {
+		opChain.fail(AssertionFailure{
+			Type:   AssertType,
+			Actual: &AssertionValue{p.value["status"]},
+			Errors: []error{
+				errors.New(`expected: "status" member is a number`),
+			},
+		})
+		return newNumber(opChain, 0)
+	}
+
+	return newNumber(opChain, status)
+}
+
+// Detail returns a new String instance with "detail" member.
+//
+// If member is absent or is not a string, failure is reported.
+//
+// Example:
+//
+//	problem := resp.Problem()
+//	problem.Detail().Contains("balance is 30")
+func (p *Problem) Detail() *String {
+	opChain := p.chain.enter("Detail()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newString(opChain, "")
+	}
+
+	s, _ := p.getString(opChain, "detail")
+
+	return newString(opChain, s)
+}
+
+// Instance returns a new String instance with "instance" member.
+//
+// If member is absent or is not a string, failure is reported.
+//
+// Example:
+//
+//	problem := resp.Problem()
+//	problem.Instance().IsEqual("/account/12345/msgs/abc")
+func (p *Problem) Instance() *String {
+	opChain := p.chain.enter("Instance()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newString(opChain, "")
+	}
+
+	s, _ := p.getString(opChain, "instance")
+
+	return newString(opChain, s)
+}
+
+// Extension returns a new Value instance with given extension member.
+//
+// If member is absent, failure is reported.
+//
+// Example:
+//
+//	problem := resp.Problem()
+//	problem.Extension("balance").Number().IsEqual(30)
+func (p *Problem) Extension(name string) *Value {
+	opChain := p.chain.enter("Extension(%q)", name)
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newValue(opChain, nil)
+	}
+
+	if !p.hasMember(opChain, name) {
+		return newValue(opChain, nil)
+	}
+
+	return newValue(opChain, p.value[name])
+}
+
+func (p *Problem) hasMember(opChain *chain, name string) bool {
+	if _, ok := p.value[name]; !ok {
+		opChain.fail(AssertionFailure{
+			Type:     AssertContainsKey,
+			Actual:   &AssertionValue{p.value},
+			Expected: &AssertionValue{name},
+			Errors: []error{
+				fmt.Errorf("expected: problem details contain %q member", name),
+			},
+		})
+		return false
+	}
+
+	return true
+}
+
+func (p *Problem) getString(opChain *chain, name string) (string, bool) {
+	if !p.hasMember(opChain, name) {
+		return "", false
+	}
+
+	s, ok := p.value[name].(string)
+	if !ok {
+		opChain.fail(AssertionFailure{
+			Type:   AssertType,
+			Actual: &AssertionValue{p.value[name]},
+			Errors: []error{
+				fmt.Errorf("expected: %q member is a string", name),
+			},
+		})
+		return "", false
+	}
+
+	return s, true
+}
+
+// Decode "application/problem+xml" payload (RFC 9457, appendix B)
+// into a map of the same shape as "application/problem+json" payload.
+//
+// Leaf elements become strings, except "status" which becomes a number.
+// Elements with only "i" children become arrays, other elements with
+// children become objects.
+func parseProblemXML(content []byte) (map[string]interface{}, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(content))
+
+	for {
+		tok, err := decoder.Token()
+		if err != nil {
+			if err == io.EOF {
+				return nil, errors.New("missing root element")
+			}
+			return nil, err
+		}
+
+		if start, ok := tok.(xml.StartElement); ok {
+			if start.Name.Local != "problem" {
+				return nil, fmt.Errorf(
+					`unexpected root element %q, expected "problem"`, start.Name.Local)
+			}
+
+			value, err := decodeProblemXMLElement(decoder)
+			if err != nil {
+				return nil, err
+			}
+
+			obj, ok := value.(map[string]interface{})
+			if !ok {
+				obj = map[string]interface{}{}
+			}
+
+			if s, ok := obj["status"].(string); ok {
+				if status, err := strconv.Atoi(strings.TrimSpace(s)); err == nil {
+					obj["status"] = float64(status)
+				}
+			}
+
+			return obj, nil
+		}
+	}
+}
+
+// Decode contents of an element, after its start token was consumed.
+func decodeProblemXMLElement(decoder *xml.Decoder) (interface{}, error) {
+	var (
+		text   strings.Builder
+		names  []string
+		values []interface{}
+	)
+
+	for {
+		tok, err := decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+
+		switch t := tok.(type) {
+		case xml.CharData:
+			text.Write(t)
+
+		case xml.StartElement:
+			child, err := decodeProblemXMLElement(decoder)
+			if err != nil {
+				return nil, err
+			}
+
+			names = append(names, t.Name.Local)
+			values = append(values, child)
+
+		case xml.EndElement:
+			if len(names) == 0 {
+				return text.String(), nil
+			}
+
+			isArray := true
+			for _, name := range names {
+				if name != "i" {
+					isArray = false
+					break
+				}
+			}
+
+			if isArray {
+				return values, nil
+			}
+
+			object := map[string]interface{}{}
+			for n, name := range names {
+				object[name] = values[n]
+			}
+
+			return object, nil
+		}
+	}
+}
@@ -0,0 +1,179 @@
+package httpexpect
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProblem_FailedChain(t *testing.T) {
+	check := func(value *Problem) {
+		value.chain.assertFailed(t)
+
+		value.Alias("foo")
+
+		value.Type().chain.assertFailed(t)
+		value.Title().chain.assertFailed(t)
+		value.Status().chain.assertFailed(t)
+		value.Detail().chain.assertFailed(t)
+		value.Instance().chain.assertFailed(t)
+		value.Extension("foo").chain.assertFailed(t)
+	}
+
+	t.Run("failed chain", func(t *testing.T) {
+		chain := newMockChain(t)
+		chain.setFailed()
+
+		value := newProblem(chain, map[string]interface{}{})
+
+		check(value)
+	})
+
+	t.Run("nil value", func(t *testing.T) {
+		chain := newMockChain(t)
+
+		value := newProblem(chain, nil)
+
+		check(value)
+	})
+}
+
+func TestProblem_Alias(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	parent := newChainWithDefaults("Problem()", reporter)
+	value := newProblem(parent, map[string]interface{}{})
+	assert.Equal(t, []string{"Problem()"}, value.chain.context.Path)
+	assert.Equal(t, []string{"Problem()"}, value.chain.context.AliasedPath)
+
+	value.Alias("foo")
+	assert.Equal(t, []string{"Problem()"}, value.chain.context.Path)
+	assert.Equal(t, []string{"foo"}, value.chain.context.AliasedPath)
+
+	childValue := value.Type()
+	assert.Equal(t, []string{"Problem()", "Type()"},
+		childValue.chain.context.Path)
+	assert.Equal(t, []string{"foo", "Type()"},
+		childValue.chain.context.AliasedPath)
+}
+
+func TestProblem_Members(t *testing.T) {
+	t.Run("all members", func(t *testing.T) {
+		raw := map[string]interface{}{
+			"type":     "https://example.com/probs/out-of-credit",
+			"title":    "You do not have enough credit.",
+			"status":   403.0,
+			"detail":   "Your current balance is 30, but that costs 50.",
+			"instance": "/account/12345/msgs/abc",
+			"balance":  30.0,
+			"accounts": []interface{}{"/account/12345", "/account/67890"},
+		}
+
+		value := newProblem(newMockChain(t), raw)
+
+		assert.Equal(t, raw, value.Raw())
+
+		value.Type().IsEqual("https://example.com/probs/out-of-credit")
+		value.Title().IsEqual("You do not have enough credit.")
+		value.Status().IsEqual(403)
+		value.Detail().Contains("balance is 30")
+		value.Instance().IsEqual("/account/12345/msgs/abc")
+		value.Extension("balance").Number().IsEqual(30)
+		value.Extension("accounts").Array().Length().IsEqual(2)
+
+		value.chain.assertNotFailed(t)
+	})
+
+	t.Run("default type", func(t *testing.T) {
+		value := newProblem(newMockChain(t), map[string]interface{}{})
+
+		value.Type().IsEqual("about:blank")
+		value.chain.assertNotFailed(t)
+	})
+
+	t.Run("missing members", func(t *testing.T) {
+		value := newProblem(newMockChain(t), map[string]interface{}{})
+
+		value.Title().chain.assertFailed(t)
+		value.Status().chain.assertFailed(t)
+		value.Detail().chain.assertFailed(t)
+		value.Instance().chain.assertFailed(t)
+		value.Extension("balance").chain.assertFailed(t)
+	})
+
+	t.Run("invalid types", func(t *testing.T) {
+		value := newProblem(newMockChain(t), map[string]interface{}{
+			"type":     123.0,
+			"title":    false,
+			"status":   "403",
+			"detail":   nil,
+			"instance": []interface{}{},
+		})
+
+		value.Type().chain.assertFailed(t)
+		value.Title().chain.assertFailed(t)
+		value.Status().chain.assertFailed(t)
+		value.Detail().chain.assertFailed(t)
+		value.Instance().chain.assertFailed(t)
+	})
+}
+
+func TestProblem_ParseXML(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		value, err := parseProblemXML([]byte(`<?xml version="1.0" encoding="UTF-8"?>
+<problem xmlns="urn:ietf:rfc:7807">
+  <type>https://example.com/probs/out-of-credit</type>
+  <title>You do not have enough credit.</title>
+  <status>403</status>
+  <detail>Your current balance is 30, but that costs 50.</detail>
+  <instance>https://example.net/account/12345/msgs/abc</instance>
+  <balance>30</balance>
+  <accounts>
+    <i>https://example.net/account/12345</i>
+    <i>https://example.net/account/67890</i>
+  </accounts>
+  <limits>
+    <daily>100</daily>
+  </limits>
+  <empty/>
+</problem>`))
+
+		require.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{
+			"type":     "https://example.com/probs/out-of-credit",
+			"title":    "You do not have enough credit.",
+			"status":   403.0,
+			"detail":   "Your current balance is 30, but that costs 50.",
+			"instance": "https://example.net/account/12345/msgs/abc",
+			"balance":  "30",
+			"accounts": []interface{}{
+				"https://example.net/account/12345",
+				"https://example.net/account/67890",
+			},
+			"limits": map[string]interface{}{
+				"daily": "100",
+			},
+			"empty": "",
+		}, value)
+	})
+
+	t.Run("empty problem", func(t *testing.T) {
+		value, err := parseProblemXML([]byte(`<problem/>`))
+
+		require.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{}, value)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		for _, content := range []string{
+			``,
+			`not xml`,
+			`<error><title>foo</title></error>`,
+			`<problem><title>foo</problem>`,
+		} {
+			_, err := parseProblemXML([]byte(content))
+			assert.Error(t, err, content)
+		}
+	})
+}
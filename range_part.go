@@ -0,0 +1,337 @@
+package httpexpect
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	"net/http"
+)
+
+// RangePart provides methods to inspect a single part of partial content
+// response (status 206).
+//
+// Responses with single range have one part, with body and headers
+// of the response itself. Responses with multiple ranges have one part
+// per body part of "multipart/byteranges" payload.
+type RangePart struct {
+	noCopy noCopy
+	chain  *chain
+	header http.Header
+	body   []byte
+}
+
+func newRangePart(parent *chain, header http.Header, body []byte) *RangePart {
+	p := &RangePart{chain: parent.clone(), header: nil, body: nil}
+
+	opChain := p.chain.enter("")
+	defer opChain.leave()
+
+	if header == nil {
+		opChain.fail(AssertionFailure{
+			Type:   AssertNotNil,
+			Actual: &AssertionValue{header},
+			Errors: []error{
+				errors.New("expected: non-nil range part"),
+			},
+		})
+	} else {
+		p.header = header
+		p.body = body
+	}
+
+	return p
+}
+
+// Raw returns underlying body of the part.
+func (p *RangePart) Raw() []byte {
+	return p.body
+}
+
+// Alias is similar to Value.Alias.
+func (p *RangePart) Alias(name string) *RangePart {
+	opChain := p.chain.enter("Alias(%q)", name)
+	defer opChain.leave()
+
+	p.chain.setAlias(name)
+	return p
+}
+
+// Chain returns public handle to assertion chain of RangePart, which may be
+// used to implement custom matchers. See Chain for details.
+func (p *RangePart) Chain() *Chain {
+	return &Chain{chain: p.chain}
+}
+
+// AsWarning is similar to Value.AsWarning.
+func (p *RangePart) AsWarning() *RangePart {
+	opChain := p.chain.enter("AsWarning()")
+	defer opChain.leave()
+
+	warnChain := p.chain.clone()
+	warnChain.setWarning()
+
+	return &RangePart{
+		chain:  warnChain,
+		header: p.header,
+		body:   p.body,
+	}
+}
+
+// ContentType returns a new String instance with value of "Content-Type"
+// header of the part.
+//
+// Example:
+//
+//	part := resp.RangeParts().Part(0)
+//	part.ContentType().IsEqual("text/plain")
+func (p *RangePart) ContentType() *String {
+	opChain := p.chain.enter("ContentType()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newString(opChain, "")
+	}
+
+	return newString(opChain, p.header.Get("Content-Type"))
+}
+
+// ContentRange returns a new ContentRange instance with parsed
+// "Content-Range" header of the part.
+//
+// If header is missing or invalid, failure is reported.
+//
+// Example:
+//
+//	part := resp.RangeParts().Part(0)
+//	part.ContentRange().Start().IsEqual(0)
+func (p *RangePart) ContentRange() *ContentRange {
+	opChain := p.chain.enter("ContentRange()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newContentRange(opChain, nil)
+	}
+
+	return newContentRange(opChain, getContentRange(opChain, p.header))
+}
+
+// Body returns a new String instance with body of the part.
+//
+// Example:
+//
+//	part := resp.RangeParts().Part(0)
+//	part.Body().IsEqual("hello")
+func (p *RangePart) Body() *String {
+	opChain := p.chain.enter("Body()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newString(opChain, "")
+	}
+
+	return newString(opChain, string(p.body))
+}
+
+// RangeParts provides methods to inspect list of parts of partial content
+// response (status 206).
+type RangeParts struct {
+	noCopy noCopy
+	chain  *chain
+	parts  []rangePartData
+}
+
+func newRangeParts(parent *chain, parts []rangePartData) *RangeParts {
+	return &RangeParts{chain: parent.clone(), parts: parts}
+}
+
+// Alias is similar to Value.Alias.
+func (rp *RangeParts) Alias(name string) *RangeParts {
+	opChain := rp.chain.enter("Alias(%q)", name)
+	defer opChain.leave()
+
+	rp.chain.setAlias(name)
+	return rp
+}
+
+// Chain returns public handle to assertion chain of RangeParts, which may be
+// used to implement custom matchers. See Chain for details.
+func (rp *RangeParts) Chain() *Chain {
+	return &Chain{chain: rp.chain}
+}
+
+// AsWarning is similar to Value.AsWarning.
+func (rp *RangeParts) AsWarning() *RangeParts {
+	opChain := rp.chain.enter("AsWarning()")
+	defer opChain.leave()
+
+	warnChain := rp.chain.clone()
+	warnChain.setWarning()
+
+	return &RangeParts{
+		chain: warnChain,
+		parts: rp.parts,
+	}
+}
+
+// Length returns a new Number instance with number of parts.
+//
+// Example:
+//
+//	parts := resp.RangeParts()
+//	parts.Length().IsEqual(2)
+func (rp *RangeParts) Length() *Number {
+	opChain := rp.chain.enter("Length()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newNumber(opChain, 0)
+	}
+
+	return newNumber(opChain, float64(len(rp.parts)))
+}
+
+// Part returns a new RangePart instance for part with given index.
+//
+// If index is out of bounds, Part reports failure and returns empty
+// (but non-nil) instance.
+//
+// Example:
+//
+//	parts := resp.RangeParts()
+//	parts.Part(0).Body().IsEqual("hello")
+//	parts.Part(1).Body().IsEqual("world")
+func (rp *RangeParts) Part(index int) *RangePart {
+	opChain := rp.chain.enter("Part(%d)", index)
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newRangePart(opChain, http.Header{}, nil)
+	}
+
+	if index < 0 || index >= len(rp.parts) {
+		opChain.fail(AssertionFailure{
+			Type:   AssertInRange,
+			Actual: &AssertionValue{index},
+			Expected: &AssertionValue{AssertionRange{
+				Min: 0,
+				Max: len(rp.parts) - 1,
+			}},
+			Errors: []error{
+				errors.New("expected: valid part index"),
+			},
+		})
+		return newRangePart(opChain, http.Header{}, nil)
+	}
+
+	return newRangePart(opChain, rp.parts[index].header, rp.parts[index].body)
+}
+
+// Iter returns a new slice of RangePart instances, one per part.
+//
+// Example:
+//
+//	for _, part := range resp.RangeParts().Iter() {
+//	    part.ContentType().IsEqual("text/plain")
+//	}
+func (rp *RangeParts) Iter() []*RangePart {
+	opChain := rp.chain.enter("Iter()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return []*RangePart{}
+	}
+
+	ret := []*RangePart{}
+
+	for index, part := range rp.parts {
+		func() {
+			partChain := opChain.replace("Iter[%d]", index)
+			defer partChain.leave()
+
+			ret = append(ret, newRangePart(partChain, part.header, part.body))
+		}()
+	}
+
+	return ret
+}
+
+func getContentRange(opChain *chain, header http.Header) *contentRange {
+	value := header.Get("Content-Range")
+
+	if value == "" {
+		opChain.fail(AssertionFailure{
+			Type:     AssertContainsKey,
+			Actual:   &AssertionValue{header},
+			Expected: &AssertionValue{"Content-Range"},
+			Errors: []error{
+				errors.New(`expected: "Content-Range" header is present`),
+			},
+		})
+		return nil
+	}
+
+	cr, err := parseContentRange(value)
+	if err != nil {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{value},
+			Errors: []error{
+				errors.New(`invalid "Content-Range" header`),
+				err,
+			},
+		})
+		return nil
+	}
+
+	return cr
+}
+
+type rangePartData struct {
+	header http.Header
+	body   []byte
+}
+
+// Split "multipart/byteranges" payload into parts.
+func parseByteRanges(boundary string, content []byte) ([]rangePartData, error) {
+	reader := multipart.NewReader(bytes.NewReader(content), boundary)
+
+	parts := []rangePartData{}
+
+	for {
+		part, err := reader.NextPart()
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, err
+		}
+
+		body, err := ioutil.ReadAll(part)
+		if err != nil {
+			return nil, err
+		}
+
+		parts = append(parts, rangePartData{
+			header: http.Header(part.Header),
+			body:   body,
+		})
+	}
+
+	if len(parts) == 0 {
+		return nil, errors.New("multipart payload has no parts")
+	}
+
+	return parts, nil
+}
+
+func isByteRanges(contentType string) (string, bool) {
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil || mediaType != "multipart/byteranges" {
+		return "", false
+	}
+
+	return params["boundary"], true
+}
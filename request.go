@@ -34,6 +34,9 @@ type Request struct {
 	config Config
 	chain  *chain
 
+	// Expect instance that created request, if any
+	owner *Expect
+
 	redirectPolicy RedirectPolicy
 	maxRedirects   int
 
@@ -53,10 +56,13 @@ type Request struct {
 	formbuf   *bytes.Buffer
 	multipart *multipart.Writer
 
-	bodySetter   string
-	typeSetter   string
-	forceType    bool
-	expectCalled bool
+	bodySetter    string
+	typeSetter    string
+	forceType     bool
+	expectCalled  bool
+	warningCalled bool
+
+	compression string
 
 	wsUpgrade bool
 
@@ -207,6 +213,78 @@ func (r *Request) Alias(name string) *Request {
 	return r
 }
 
+// Chain returns public handle to assertion chain of Request, which may be
+// used to implement custom matchers. See Chain for details.
+func (r *Request) Chain() *Chain {
+	return &Chain{chain: r.chain}
+}
+
+// AsWarning is similar to Value.AsWarning.
+//
+// Unlike other matchers, request state (URL, headers, body, form, etc.) is
+// moved to the returned copy instead of being copied, and the original
+// Request must not be used after this call. Any subsequent call to WithXXX
+// methods or Expect on the original Request reports failure.
+//
+// Example:
+//
+//	req := e.GET("/users").AsWarning()
+//	req.WithQuery("limit", 10)
+//	req.Expect().Status(http.StatusOK)
+func (r *Request) AsWarning() *Request {
+	opChain := r.chain.enter("AsWarning()")
+	defer opChain.leave()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	warnChain := r.chain.clone()
+	warnChain.setWarning()
+
+	req := &Request{
+		config: r.config,
+		chain:  warnChain,
+		owner:  r.owner,
+
+		redirectPolicy: r.redirectPolicy,
+		maxRedirects:   r.maxRedirects,
+
+		retryPolicy:   r.retryPolicy,
+		maxRetries:    r.maxRetries,
+		minRetryDelay: r.minRetryDelay,
+		maxRetryDelay: r.maxRetryDelay,
+		sleepFn:       r.sleepFn,
+
+		timeout: r.timeout,
+
+		httpReq: r.httpReq,
+		path:    r.path,
+		query:   r.query,
+
+		form:      r.form,
+		formbuf:   r.formbuf,
+		multipart: r.multipart,
+
+		bodySetter:   r.bodySetter,
+		typeSetter:   r.typeSetter,
+		forceType:    r.forceType,
+		expectCalled: r.expectCalled,
+
+		compression: r.compression,
+
+		wsUpgrade: r.wsUpgrade,
+
+		transformers: r.transformers,
+		matchers:     r.matchers,
+	}
+
+	if !opChain.failed() && r.checkOrder(opChain, "AsWarning()") {
+		r.warningCalled = true
+	}
+
+	return req
+}
+
 // WithName sets convenient request name.
 // This name will be included in assertion reports for this request.
 // It does not affect assertion chain path, inlike Alias.
@@ -1291,6 +1369,148 @@ func (r *Request) withHeader(k, v string) {
 	}
 }
 
+// ByteRange defines a single byte range for WithRanges.
+//
+// Start and End are positions of first and last bytes, inclusive.
+// If End is negative, range is open-ended and includes all bytes from
+// Start to the end of the representation ("Start-"). If Start is negative,
+// range is a suffix range and includes last -Start bytes ("-N"), and
+// End is ignored.
+type ByteRange struct {
+	Start int64
+	End   int64
+}
+
+// String returns range in the format used in "Range" header, e.g. "0-99".
+func (br ByteRange) String() string {
+	switch {
+	case br.Start < 0:
+		return fmt.Sprintf("%d", br.Start)
+	case br.End < 0:
+		return fmt.Sprintf("%d-", br.Start)
+	default:
+		return fmt.Sprintf("%d-%d", br.Start, br.End)
+	}
+}
+
+// WithRange sets "Range" header to request a single byte range.
+//
+// Start and optional end are positions of first and last bytes, inclusive.
+// If end is omitted, range includes all bytes starting from start.
+// If start is negative, range includes last -start bytes.
+//
+// Example:
+//
+//	req := NewRequestC(config, "GET", "http://example.com/video")
+//	req.WithRange(0, 99)  // Range: bytes=0-99
+//	req.WithRange(100)    // Range: bytes=100-
+//	req.WithRange(-500)   // Range: bytes=-500
+func (r *Request) WithRange(start int64, end ...int64) *Request {
+	opChain := r.chain.enter("WithRange()")
+	defer opChain.leave()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if opChain.failed() {
+		return r
+	}
+
+	if !r.checkOrder(opChain, "WithRange()") {
+		return r
+	}
+
+	if len(end) > 1 {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected multiple end arguments"),
+			},
+		})
+		return r
+	}
+
+	br := ByteRange{Start: start, End: -1}
+	if len(end) != 0 {
+		if start < 0 {
+			opChain.fail(AssertionFailure{
+				Type: AssertUsage,
+				Errors: []error{
+					errors.New("unexpected end argument for suffix range"),
+				},
+			})
+			return r
+		}
+		br.End = end[0]
+	}
+
+	r.withRanges(opChain, []ByteRange{br})
+
+	return r
+}
+
+// WithRanges sets "Range" header to request multiple byte ranges.
+//
+// Server is expected to respond with "multipart/byteranges" payload,
+// which can be inspected using Response.RangeParts.
+//
+// Example:
+//
+//	req := NewRequestC(config, "GET", "http://example.com/video")
+//	req.WithRanges(
+//		httpexpect.ByteRange{Start: 0, End: 99},
+//		httpexpect.ByteRange{Start: 200, End: -1},
+//	) // Range: bytes=0-99,200-
+func (r *Request) WithRanges(ranges ...ByteRange) *Request {
+	opChain := r.chain.enter("WithRanges()")
+	defer opChain.leave()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if opChain.failed() {
+		return r
+	}
+
+	if !r.checkOrder(opChain, "WithRanges()") {
+		return r
+	}
+
+	if len(ranges) == 0 {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				errors.New("unexpected empty ranges list"),
+			},
+		})
+		return r
+	}
+
+	r.withRanges(opChain, ranges)
+
+	return r
+}
+
+func (r *Request) withRanges(opChain *chain, ranges []ByteRange) {
+	specs := make([]string, 0, len(ranges))
+
+	for _, br := range ranges {
+		if br.Start >= 0 && br.End >= 0 && br.End < br.Start {
+			opChain.fail(AssertionFailure{
+				Type: AssertUsage,
+				Errors: []error{
+					fmt.Errorf("invalid byte range %d-%d: end is less than start",
+						br.Start, br.End),
+				},
+			})
+			return
+		}
+		specs = append(specs, br.String())
+	}
+
+	r.httpReq.Header.Set("Range", "bytes="+strings.Join(specs, ","))
+}
+
 // WithCookies adds given cookies to request.
 //
 // Example:
@@ -1500,6 +1720,50 @@ func (r *Request) WithChunked(reader io.Reader) *Request {
 	return r
 }
 
+// WithCompression enables compression of request body using given
+// content coding, and sets "Content-Encoding" header accordingly.
+//
+// Supported codings are "gzip", "deflate", "br" and "zstd".
+// Body is compressed in Expect(), so it doesn't matter if WithCompression
+// is called before or after the body is set. If request has no body,
+// WithCompression has no effect.
+//
+// Example:
+//
+//	req := NewRequestC(config, "PUT", "http://example.com/path")
+//	req.WithCompression("gzip")
+//	req.WithJSON(map[string]interface{}{"foo": 123})
+func (r *Request) WithCompression(encoding string) *Request {
+	opChain := r.chain.enter("WithCompression()")
+	defer opChain.leave()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if opChain.failed() {
+		return r
+	}
+
+	if !r.checkOrder(opChain, "WithCompression()") {
+		return r
+	}
+
+	if !isSupportedEncoding(encoding) {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				fmt.Errorf("unsupported content encoding %q, expected one of: %s",
+					encoding, strings.Join(supportedEncodings, ", ")),
+			},
+		})
+		return r
+	}
+
+	r.compression = encoding
+
+	return r
+}
+
 // WithBytes sets request body to given slice of bytes.
 //
 // Example:
@@ -1608,6 +1872,118 @@ func (r *Request) WithJSON(object interface{}) *Request {
 	return r
 }
 
+// WithJSONPatch sets Content-Type header to "application/json-patch+json",
+// marshals given JSON Patch (RFC 6902) operations into an array, and adds
+// it to request body.
+//
+// Each operation may be a map, a struct with "json" struct tags, or anything
+// else that json.Marshal can encode into an object with "op", "path", and,
+// depending on the operation, "value" or "from" members.
+//
+// If operations are not valid JSON Patch, failure is reported.
+//
+// Example:
+//
+//	req := NewRequestC(config, "PATCH", "http://example.com/users/1")
+//	req.WithJSONPatch(
+//		map[string]interface{}{"op": "replace", "path": "/name", "value": "Jane"},
+//		map[string]interface{}{"op": "remove", "path": "/nickname"},
+//	)
+func (r *Request) WithJSONPatch(ops ...interface{}) *Request {
+	opChain := r.chain.enter("WithJSONPatch()")
+	defer opChain.leave()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if opChain.failed() {
+		return r
+	}
+
+	if !r.checkOrder(opChain, "WithJSONPatch()") {
+		return r
+	}
+
+	if ops == nil {
+		ops = []interface{}{}
+	}
+
+	b, err := json.Marshal(ops)
+
+	if err == nil {
+		var patch interface{}
+		if err = json.Unmarshal(b, &patch); err == nil {
+			err = validateJSONPatch(patch)
+		}
+	}
+
+	if err != nil {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{ops},
+			Errors: []error{
+				errors.New("invalid json patch"),
+				err,
+			},
+		})
+		return r
+	}
+
+	r.setType(opChain, "WithJSONPatch()", "application/json-patch+json", false)
+	r.setBody(opChain, "WithJSONPatch()", bytes.NewReader(b), len(b), false)
+
+	return r
+}
+
+// WithMergePatch sets Content-Type header to "application/merge-patch+json",
+// marshals given object into JSON Merge Patch (RFC 7396), and adds it to
+// request body.
+//
+// In merge patch, members with null values are removed from target,
+// and other members are added or replaced.
+//
+// Example:
+//
+//	req := NewRequestC(config, "PATCH", "http://example.com/users/1")
+//	req.WithMergePatch(map[string]interface{}{
+//		"name":     "Jane",
+//		"nickname": nil,
+//	})
+func (r *Request) WithMergePatch(object interface{}) *Request {
+	opChain := r.chain.enter("WithMergePatch()")
+	defer opChain.leave()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if opChain.failed() {
+		return r
+	}
+
+	if !r.checkOrder(opChain, "WithMergePatch()") {
+		return r
+	}
+
+	b, err := json.Marshal(object)
+
+	if err != nil {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{object},
+			Errors: []error{
+				errors.New("invalid json object"),
+				err,
+			},
+		})
+		return r
+	}
+
+	r.setType(opChain, "WithMergePatch()", "application/merge-patch+json", false)
+	r.setBody(opChain, "WithMergePatch()", bytes.NewReader(b), len(b), false)
+
+	return r
+}
+
 // WithForm sets Content-Type header to "application/x-www-form-urlencoded"
 // or (if WithMultipart() was called) "multipart/form-data", converts given
 // object to url.Values using github.com/ajg/form, and adds it to request body.
@@ -1960,6 +2336,84 @@ func (r *Request) Expect() *Response {
 	return resp
 }
 
+// ExpectNotModified is like Expect, but after receiving response, it also
+// replays request conditionally and checks that server responds with
+// 304 Not Modified.
+//
+// Conditional request has same method, URL, headers and body, and also
+// has "If-None-Match" header set from "ETag" header of the first response,
+// and "If-Modified-Since" header set from "Last-Modified" header.
+// If the first response has neither of them, failure is reported.
+//
+// Returned Response is the first (unconditional) response.
+//
+// Example:
+//
+//	req := NewRequestC(config, "GET", "http://example.com/path")
+//	resp := req.ExpectNotModified()
+//	resp.Status(http.StatusOK)
+//	resp.ETag().NotEmpty()
+func (r *Request) ExpectNotModified() *Response {
+	opChain := r.chain.enter("ExpectNotModified()")
+	defer opChain.leave()
+
+	resp := r.expect(opChain)
+
+	if resp == nil {
+		return newResponse(responseOpts{
+			config: r.config,
+			chain:  opChain,
+		})
+	}
+
+	etag := resp.httpResp.Header.Get("ETag")
+	lastModified := resp.httpResp.Header.Get("Last-Modified")
+
+	if etag == "" && lastModified == "" {
+		opChain.fail(AssertionFailure{
+			Type:   AssertContainsKey,
+			Actual: &AssertionValue{resp.httpResp.Header},
+			Expected: &AssertionValue{
+				AssertionList{"ETag", "Last-Modified"},
+			},
+			Errors: []error{
+				errors.New(
+					`expected: response contains "ETag" or "Last-Modified" header`),
+			},
+		})
+		return resp
+	}
+
+	replay := r.replay(opChain)
+
+	if etag != "" {
+		replay.httpReq.Header.Set("If-None-Match", etag)
+	}
+	if lastModified != "" {
+		replay.httpReq.Header.Set("If-Modified-Since", lastModified)
+	}
+
+	replayResp := replay.expect(opChain)
+
+	if replayResp == nil {
+		return resp
+	}
+
+	if replayResp.httpResp.StatusCode != http.StatusNotModified {
+		opChain.fail(AssertionFailure{
+			Type:     AssertEqual,
+			Actual:   &AssertionValue{statusCodeText(replayResp.httpResp.StatusCode)},
+			Expected: &AssertionValue{statusCodeText(http.StatusNotModified)},
+			Errors: []error{
+				errors.New(
+					"expected: conditional request responds with 304 Not Modified"),
+			},
+		})
+	}
+
+	return resp
+}
+
 func (r *Request) expect(opChain *chain) *Response {
 	if !r.prepare(opChain) {
 		return nil
@@ -2031,12 +2485,112 @@ func (r *Request) execute(opChain *chain) *Response {
 	return newResponse(responseOpts{
 		config:    r.config,
 		chain:     opChain,
+		request:   r,
 		httpResp:  httpResp,
 		websocket: websock,
 		rtt:       []time.Duration{elapsed},
 	})
 }
 
+// Create new request with same method, URL, headers, body and settings.
+// Should be called only after request was sent.
+func (r *Request) replay(opChain *chain) *Request {
+	// new request will store itself in context
+	parent := opChain.clone()
+	parent.context.Request = nil
+
+	req := newRequest(parent, r.config, r.httpReq.Method, "")
+
+	u := *r.httpReq.URL
+	req.httpReq.URL = &u
+
+	for k, v := range r.httpReq.Header {
+		req.httpReq.Header[k] = append([]string(nil), v...)
+	}
+
+	if bw, ok := r.httpReq.Body.(*bodyWrapper); ok {
+		if body, err := bw.GetBody(); err == nil {
+			req.setBody(opChain, "Expect()", body, int(r.httpReq.ContentLength), true)
+		}
+	}
+
+	req.redirectPolicy = r.redirectPolicy
+	req.maxRedirects = r.maxRedirects
+	req.retryPolicy = r.retryPolicy
+	req.maxRetries = r.maxRetries
+	req.minRetryDelay = r.minRetryDelay
+	req.maxRetryDelay = r.maxRetryDelay
+	req.timeout = r.timeout
+	req.transformers = r.transformers
+	req.matchers = r.matchers
+
+	return req
+}
+
+// Creates requests for hypermedia links found in response.
+type linkFollower struct {
+	config Config
+	origin *Request // may be nil
+	base   *url.URL // may be nil
+}
+
+// Create new GET request for given link, resolved relative to base URL.
+// If origin request was created by Expect, new request is created by the
+// same Expect, with same builders and matchers.
+func (f *linkFollower) follow(opChain *chain, href string) *Request {
+	// new request will store itself in context
+	parent := opChain.clone()
+	parent.context.Request = nil
+	parent.context.Response = nil
+
+	var req *Request
+	if f.origin != nil && f.origin.owner != nil {
+		req = f.origin.owner.newRequest(parent, http.MethodGet, "")
+	} else {
+		req = newRequest(parent, f.config, http.MethodGet, "")
+	}
+
+	if opChain.failed() || req.httpReq == nil {
+		return req
+	}
+
+	ref, err := url.Parse(href)
+	if err != nil {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{href},
+			Errors: []error{
+				errors.New("invalid link url"),
+				err,
+			},
+		})
+		return req
+	}
+
+	base := f.base
+	if base == nil {
+		base = req.httpReq.URL
+	}
+
+	req.httpReq.URL = base.ResolveReference(ref)
+
+	// move link query to request query, so that query parameters added
+	// by builders, before or after following the link, are appended to it
+	// instead of overwriting it in encodeRequest
+	if req.httpReq.URL.RawQuery != "" {
+		query := req.httpReq.URL.Query()
+		for key, values := range req.query {
+			for _, value := range values {
+				query.Add(key, value)
+			}
+		}
+		req.query = query
+		req.httpReq.URL.RawQuery = ""
+	}
+
+	return req
+}
+
 func (r *Request) encodeRequest(opChain *chain) bool {
 	if opChain.failed() {
 		return false
@@ -2072,6 +2626,12 @@ func (r *Request) encodeRequest(opChain *chain) bool {
 		r.httpReq.Body = http.NoBody
 	}
 
+	if r.compression != "" && r.httpReq.Body != http.NoBody {
+		if !r.encodeCompression(opChain) {
+			return false
+		}
+	}
+
 	if r.config.Context != nil {
 		r.httpReq = r.httpReq.WithContext(r.config.Context)
 	}
@@ -2081,6 +2641,40 @@ func (r *Request) encodeRequest(opChain *chain) bool {
 	return true
 }
 
+func (r *Request) encodeCompression(opChain *chain) bool {
+	content, err := ioutil.ReadAll(r.httpReq.Body)
+
+	if closeErr := r.httpReq.Body.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err == nil {
+		content, err = compressContent(r.compression, content)
+	}
+
+	if err != nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertOperation,
+			Errors: []error{
+				errors.New("failed to compress request body"),
+				err,
+			},
+		})
+		return false
+	}
+
+	r.httpReq.Body = ioutil.NopCloser(bytes.NewReader(content))
+
+	// keep chunked encoding if it was enabled
+	if r.httpReq.ContentLength >= 0 {
+		r.httpReq.ContentLength = int64(len(content))
+	}
+
+	r.httpReq.Header.Set("Content-Encoding", r.compression)
+
+	return true
+}
+
 var websocketErr = `webocket request can not have body:
   body was set by %s
   webocket was enabled by WithWebsocketUpgrade()`
@@ -2439,6 +3033,16 @@ func (r *Request) checkOrder(opChain *chain, funcCall string) bool {
 		})
 		return false
 	}
+	if r.warningCalled {
+		opChain.fail(AssertionFailure{
+			Type: AssertUsage,
+			Errors: []error{
+				fmt.Errorf("unexpected call to %s: AsWarning() has already been called,"+
+					" use returned request instead", funcCall),
+			},
+		})
+		return false
+	}
 	return true
 }
 
@@ -56,6 +56,8 @@ func TestRequest_FailedChain(t *testing.T) {
 	req.WithURL("http://example.com")
 	req.WithHeaders(map[string]string{"foo": "bar"})
 	req.WithHeader("foo", "bar")
+	req.WithRange(0, 99)
+	req.WithRanges(ByteRange{Start: 0, End: 99})
 	req.WithCookies(map[string]string{"foo": "bar"})
 	req.WithCookie("foo", "bar")
 	req.WithBasicAuth("foo", "bar")
@@ -65,14 +67,20 @@ func TestRequest_FailedChain(t *testing.T) {
 	req.WithBytes([]byte("foo"))
 	req.WithText("foo")
 	req.WithJSON(map[string]string{"foo": "bar"})
+	req.WithJSONPatch(map[string]string{"op": "remove", "path": "/foo"})
+	req.WithMergePatch(map[string]string{"foo": "bar"})
 	req.WithForm(map[string]string{"foo": "bar"})
 	req.WithFormField("foo", "bar")
 	req.WithFile("foo", "bar", strings.NewReader("baz"))
 	req.WithFileBytes("foo", "bar", []byte("baz"))
 	req.WithMultipart()
+	req.WithCompression("gzip")
 
 	resp := req.Expect()
 	resp.chain.assertFailed(t)
+
+	resp = req.ExpectNotModified()
+	resp.chain.assertFailed(t)
 }
 
 func TestRequest_Constructors(t *testing.T) {
@@ -200,6 +208,90 @@ func TestRequest_Alias(t *testing.T) {
 	assert.Equal(t, []string{"foo"}, value.chain.context.AliasedPath)
 }
 
+func TestRequest_AsWarning(t *testing.T) {
+	t.Run("body", func(t *testing.T) {
+		client := &mockClient{}
+
+		config := Config{
+			Client:   client,
+			Reporter: newMockReporter(t),
+		}
+
+		req := NewRequestC(config, "POST", "/path").
+			WithText("hello")
+
+		warnReq := req.AsWarning()
+		assert.NotSame(t, req, warnReq)
+
+		warnReq.WithQuery("limit", 10)
+		warnReq.Expect().Body().IsEqual("hello")
+
+		req.chain.assertNotFailed(t)
+		warnReq.chain.assertNotFailed(t)
+
+		assert.Equal(t, "limit=10", client.req.URL.RawQuery)
+	})
+
+	t.Run("form", func(t *testing.T) {
+		client := &mockClient{}
+
+		config := Config{
+			Client:   client,
+			Reporter: newMockReporter(t),
+		}
+
+		req := NewRequestC(config, "POST", "/path").
+			WithFormField("a", 1)
+
+		warnReq := req.AsWarning().
+			WithFormField("b", 2)
+
+		warnReq.Expect().Body().IsEqual("a=1&b=2")
+		warnReq.chain.assertNotFailed(t)
+	})
+
+	t.Run("original not usable", func(t *testing.T) {
+		client := &mockClient{}
+		reporter := newMockReporter(t)
+
+		config := Config{
+			Client:   client,
+			Reporter: reporter,
+		}
+
+		req := NewRequestC(config, "POST", "/path").
+			WithText("hello")
+
+		req.AsWarning()
+		req.chain.assertNotFailed(t)
+
+		req.WithHeader("foo", "bar")
+		req.chain.assertFailed(t)
+		assert.True(t, reporter.reported)
+
+		req.chain.clearFailed()
+
+		req.Expect()
+		req.chain.assertFailed(t)
+		assert.Nil(t, client.req)
+	})
+
+	t.Run("warning", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		config := Config{
+			Client:   &mockClient{},
+			Reporter: reporter,
+		}
+
+		req := NewRequestC(config, "GET", "/path").AsWarning()
+
+		req.WithQuery("foo", nil)
+		req.chain.assertFailed(t)
+		assert.False(t, reporter.reported)
+	})
+}
+
 func TestRequest_Basic(t *testing.T) {
 	t.Run("get", func(t *testing.T) {
 		client := &mockClient{}
@@ -743,6 +835,92 @@ func TestRequest_Headers(t *testing.T) {
 	assert.Same(t, &client.resp, resp.Raw())
 }
 
+func TestRequest_Ranges(t *testing.T) {
+	cases := []struct {
+		name   string
+		fn     func(req *Request)
+		header string
+		fail   bool
+	}{
+		{
+			name:   "closed",
+			fn:     func(req *Request) { req.WithRange(0, 99) },
+			header: "bytes=0-99",
+		},
+		{
+			name:   "open-ended",
+			fn:     func(req *Request) { req.WithRange(100) },
+			header: "bytes=100-",
+		},
+		{
+			name:   "suffix",
+			fn:     func(req *Request) { req.WithRange(-500) },
+			header: "bytes=-500",
+		},
+		{
+			name: "multiple",
+			fn: func(req *Request) {
+				req.WithRanges(
+					ByteRange{Start: 0, End: 0},
+					ByteRange{Start: 10, End: -1},
+					ByteRange{Start: -5},
+				)
+			},
+			header: "bytes=0-0,10-,-5",
+		},
+		{
+			name: "overwrite",
+			fn: func(req *Request) {
+				req.WithRange(0, 99)
+				req.WithRange(100, 199)
+			},
+			header: "bytes=100-199",
+		},
+		{
+			name: "multiple end arguments",
+			fn:   func(req *Request) { req.WithRange(0, 1, 2) },
+			fail: true,
+		},
+		{
+			name: "end for suffix range",
+			fn:   func(req *Request) { req.WithRange(-10, 20) },
+			fail: true,
+		},
+		{
+			name: "end less than start",
+			fn:   func(req *Request) { req.WithRange(10, 5) },
+			fail: true,
+		},
+		{
+			name: "empty ranges",
+			fn:   func(req *Request) { req.WithRanges() },
+			fail: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := &mockClient{}
+
+			config := Config{
+				Client:   client,
+				Reporter: newMockReporter(t),
+			}
+
+			req := NewRequestC(config, "GET", "url")
+			tc.fn(req)
+
+			if tc.fail {
+				req.chain.assertFailed(t)
+				return
+			}
+
+			req.Expect().chain.assertNotFailed(t)
+			assert.Equal(t, tc.header, client.req.Header.Get("Range"))
+		})
+	}
+}
+
 func TestRequest_Cookies(t *testing.T) {
 	client := &mockClient{}
 
@@ -949,6 +1127,101 @@ func TestRequest_BodyBytes(t *testing.T) {
 	})
 }
 
+func TestRequest_BodyCompression(t *testing.T) {
+	for _, encoding := range []string{"gzip", "deflate", "br", "zstd"} {
+		t.Run(encoding, func(t *testing.T) {
+			client := &mockClient{}
+
+			reporter := newMockReporter(t)
+
+			req := NewRequestC(Config{
+				Client:   client,
+				Reporter: reporter,
+			}, "METHOD", "url")
+
+			req.WithCompression(encoding)
+			req.WithText("hello, world!")
+
+			resp := req.Expect()
+			resp.chain.assertNotFailed(t)
+
+			assert.Equal(t, encoding, client.req.Header.Get("Content-Encoding"))
+			assert.Equal(t, "text/plain; charset=utf-8", client.req.Header.Get("Content-Type"))
+
+			// mockClient echoes request body, which is decoded automatically
+			resp.Body().IsEqual("hello, world!")
+			resp.chain.assertNotFailed(t)
+		})
+	}
+
+	t.Run("chunked", func(t *testing.T) {
+		client := &mockClient{}
+
+		req := NewRequestC(Config{
+			Client:   client,
+			Reporter: newMockReporter(t),
+		}, "METHOD", "url")
+
+		req.WithChunked(bytes.NewBufferString("body"))
+		req.WithCompression("gzip")
+
+		resp := req.Expect()
+		resp.chain.assertNotFailed(t)
+
+		assert.Equal(t, int64(-1), client.req.ContentLength)
+		resp.Body().IsEqual("body")
+	})
+
+	t.Run("no body", func(t *testing.T) {
+		client := &mockClient{}
+
+		req := NewRequestC(Config{
+			Client:   client,
+			Reporter: newMockReporter(t),
+		}, "METHOD", "url")
+
+		req.WithCompression("gzip")
+
+		resp := req.Expect()
+		resp.chain.assertNotFailed(t)
+
+		assert.Equal(t, "", client.req.Header.Get("Content-Encoding"))
+		assert.Equal(t, http.NoBody, client.req.Body)
+	})
+
+	t.Run("disabled decompression", func(t *testing.T) {
+		client := &mockClient{}
+
+		req := NewRequestC(Config{
+			Client:               client,
+			Reporter:             newMockReporter(t),
+			DisableDecompression: true,
+		}, "METHOD", "url")
+
+		req.WithCompression("gzip")
+		req.WithBytes([]byte("body"))
+
+		resp := req.Expect()
+		resp.chain.assertNotFailed(t)
+
+		compressed, err := compressContent("gzip", []byte("body"))
+		assert.NoError(t, err)
+
+		resp.Body().IsEqual(string(compressed))
+		resp.chain.assertNotFailed(t)
+	})
+
+	t.Run("unsupported", func(t *testing.T) {
+		req := NewRequestC(Config{
+			Client:   &mockClient{},
+			Reporter: newMockReporter(t),
+		}, "METHOD", "url")
+
+		req.WithCompression("compress")
+		req.chain.assertFailed(t)
+	})
+}
+
 func TestRequest_BodyText(t *testing.T) {
 	client := &mockClient{}
 
@@ -1239,6 +1512,99 @@ func TestRequest_BodyJSON(t *testing.T) {
 	assert.Same(t, &client.resp, resp.Raw())
 }
 
+func TestRequest_BodyPatch(t *testing.T) {
+	t.Run("json patch", func(t *testing.T) {
+		client := &mockClient{}
+
+		config := Config{
+			Client:   client,
+			Reporter: newMockReporter(t),
+		}
+
+		type patchOp struct {
+			Op    string      `json:"op"`
+			Path  string      `json:"path"`
+			Value interface{} `json:"value"`
+		}
+
+		req := NewRequestC(config, "PATCH", "url")
+
+		req.WithJSONPatch(
+			map[string]interface{}{"op": "remove", "path": "/nickname"},
+			patchOp{Op: "replace", Path: "/name", Value: "Jane"},
+		)
+
+		resp := req.Expect()
+		resp.chain.assertNotFailed(t)
+
+		assert.Equal(t, "application/json-patch+json",
+			client.req.Header.Get("Content-Type"))
+		assert.JSONEq(t,
+			`[{"op":"remove","path":"/nickname"},
+			  {"op":"replace","path":"/name","value":"Jane"}]`,
+			resp.Body().Raw())
+	})
+
+	t.Run("empty json patch", func(t *testing.T) {
+		client := &mockClient{}
+
+		config := Config{
+			Client:   client,
+			Reporter: newMockReporter(t),
+		}
+
+		req := NewRequestC(config, "PATCH", "url")
+		req.WithJSONPatch()
+
+		resp := req.Expect()
+		resp.chain.assertNotFailed(t)
+
+		assert.Equal(t, "[]", resp.Body().Raw())
+	})
+
+	t.Run("invalid json patch", func(t *testing.T) {
+		for _, op := range []interface{}{
+			"foo",
+			map[string]interface{}{"op": "add", "path": "/foo"},
+			map[string]interface{}{"op": "jump", "path": "/foo"},
+			func() {},
+		} {
+			req := NewRequestC(newMockConfig(newMockReporter(t)), "PATCH", "url")
+			req.WithJSONPatch(op)
+			req.chain.assertFailed(t)
+		}
+	})
+
+	t.Run("merge patch", func(t *testing.T) {
+		client := &mockClient{}
+
+		config := Config{
+			Client:   client,
+			Reporter: newMockReporter(t),
+		}
+
+		req := NewRequestC(config, "PATCH", "url")
+
+		req.WithMergePatch(map[string]interface{}{
+			"name":     "Jane",
+			"nickname": nil,
+		})
+
+		resp := req.Expect()
+		resp.chain.assertNotFailed(t)
+
+		assert.Equal(t, "application/merge-patch+json",
+			client.req.Header.Get("Content-Type"))
+		assert.JSONEq(t, `{"name":"Jane","nickname":null}`, resp.Body().Raw())
+	})
+
+	t.Run("invalid merge patch", func(t *testing.T) {
+		req := NewRequestC(newMockConfig(newMockReporter(t)), "PATCH", "url")
+		req.WithMergePatch(func() {})
+		req.chain.assertFailed(t)
+	})
+}
+
 func TestRequest_ContentLength(t *testing.T) {
 	client := &mockClient{}
 
@@ -8,6 +8,7 @@ import (
 	"io/ioutil"
 	"mime"
 	"net/http"
+	"net/url"
 	"reflect"
 	"regexp"
 	"strconv"
@@ -24,6 +25,7 @@ type Response struct {
 	config Config
 	chain  *chain
 
+	request   *Request
 	httpResp  *http.Response
 	websocket *websocket.Conn
 	rtt       *time.Duration
@@ -86,6 +88,7 @@ func NewResponseC(
 type responseOpts struct {
 	config    Config
 	chain     *chain
+	request   *Request
 	httpResp  *http.Response
 	websocket *websocket.Conn
 	rtt       []time.Duration
@@ -97,6 +100,7 @@ func newResponse(opts responseOpts) *Response {
 	r := &Response{
 		config:       opts.config,
 		chain:        opts.chain.clone(),
+		request:      opts.request,
 		contentState: contentPending,
 	}
 
@@ -191,12 +195,56 @@ func (r *Response) getContent(opChain *chain) ([]byte, bool) {
 		return nil, false
 	}
 
+	// range of encoded representation can't be decoded alone
+	isPartial := resp.StatusCode == http.StatusPartialContent ||
+		resp.Header.Get("Content-Range") != ""
+
+	if !r.config.DisableDecompression && !isPartial && len(content) != 0 {
+		content, err = r.decodeContent(content)
+
+		if err != nil {
+			opChain.fail(AssertionFailure{
+				Type: AssertOperation,
+				Errors: []error{
+					errors.New("failed to decode response body"),
+					err,
+				},
+			})
+
+			r.content = nil
+			r.contentState = contentFailed
+
+			return nil, false
+		}
+	}
+
 	r.content = content
 	r.contentState = contentRetreived
 
 	return r.content, true
 }
 
+// Undo codings from "Content-Encoding" header, in reverse order.
+// Decoding stops at the first unsupported coding (e.g. "compress"), and
+// content is returned with remaining codings still applied.
+func (r *Response) decodeContent(content []byte) ([]byte, error) {
+	encodings := parseContentEncoding(r.httpResp.Header.Values("Content-Encoding"))
+
+	for n := len(encodings) - 1; n >= 0; n-- {
+		if !isSupportedEncoding(encodings[n]) {
+			break
+		}
+
+		var err error
+		content, err = decompressContent(encodings[n], content)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return content, nil
+}
+
 // Raw returns underlying http.Response object.
 // This is the value originally passed to NewResponse.
 func (r *Response) Raw() *http.Response {
@@ -212,6 +260,33 @@ func (r *Response) Alias(name string) *Response {
 	return r
 }
 
+// Chain returns public handle to assertion chain of Response, which may be
+// used to implement custom matchers. See Chain for details.
+func (r *Response) Chain() *Chain {
+	return &Chain{chain: r.chain}
+}
+
+// AsWarning is similar to Value.AsWarning.
+func (r *Response) AsWarning() *Response {
+	opChain := r.chain.enter("AsWarning()")
+	defer opChain.leave()
+
+	warnChain := r.chain.clone()
+	warnChain.setWarning()
+
+	return &Response{
+		config:       r.config,
+		chain:        warnChain,
+		request:      r.request,
+		httpResp:     r.httpResp,
+		websocket:    r.websocket,
+		rtt:          r.rtt,
+		content:      r.content,
+		contentState: r.contentState,
+		cookies:      r.cookies,
+	}
+}
+
 // RoundTripTime returns a new Duration instance with response round-trip time.
 //
 // The returned duration is the time interval starting just before request is
@@ -451,6 +526,430 @@ func (r *Response) Header(header string) *String {
 	return newString(opChain, value)
 }
 
+// Location returns a new URL instance with "Location" header.
+//
+// Relative reference is resolved against request URL, if it is known.
+// If header is missing or can't be parsed, failure is reported.
+//
+// Example:
+//
+//	resp := NewResponse(t, response)
+//	resp.Location().Path().IsEqual("/users/123")
+func (r *Response) Location() *URL {
+	opChain := r.chain.enter("Location()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newURL(opChain, "")
+	}
+
+	value, ok := r.getHeader(opChain, "Location")
+	if !ok {
+		return newURL(opChain, "")
+	}
+
+	location, err := r.httpResp.Location()
+	if err != nil {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{value},
+			Errors: []error{
+				errors.New(`invalid "Location" response header`),
+				err,
+			},
+		})
+		return newURL(opChain, "")
+	}
+
+	return newURLFromValue(opChain, location)
+}
+
+// Links returns a new Links instance with links parsed from all "Link"
+// headers (RFC 8288), keyed by relation type.
+//
+// Relative targets are resolved against request URL, if it is known.
+// If there are no "Link" headers, returned instance is empty.
+// If header can't be parsed, failure is reported.
+//
+// Example:
+//
+//	resp := NewResponse(t, response)
+//	resp.Links().HasRel("next")
+//	resp.Links().Rel("next").Query().Value("page").Array().ConsistsOf("2")
+func (r *Response) Links() *Links {
+	opChain := r.chain.enter("Links()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newLinks(opChain, nil)
+	}
+
+	links, ok := r.getLinks(opChain)
+	if !ok {
+		return newLinks(opChain, nil)
+	}
+
+	return newLinks(opChain, links)
+}
+
+func (r *Response) getLinks(opChain *chain) ([]webLink, bool) {
+	var base *url.URL
+	if r.httpResp.Request != nil {
+		base = r.httpResp.Request.URL
+	}
+
+	links, err := parseWebLinks(r.httpResp.Header.Values("Link"), base)
+	if err != nil {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{r.httpResp.Header.Values("Link")},
+			Errors: []error{
+				errors.New(`invalid "Link" response header`),
+				err,
+			},
+		})
+		return nil, false
+	}
+
+	return links, true
+}
+
+// CacheControl returns a new CacheControl instance with directives parsed
+// from all "Cache-Control" headers.
+//
+// If there are no "Cache-Control" headers, returned instance is empty.
+// If header can't be parsed, failure is reported.
+//
+// Example:
+//
+//	resp := NewResponse(t, response)
+//	resp.CacheControl().HasDirective("public").MaxAge().IsEqual(time.Minute)
+func (r *Response) CacheControl() *CacheControl {
+	opChain := r.chain.enter("CacheControl()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newCacheControl(opChain, nil)
+	}
+
+	values := r.httpResp.Header.Values("Cache-Control")
+
+	directives, err := parseCacheControl(values)
+	if err != nil {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{values},
+			Errors: []error{
+				errors.New(`invalid "Cache-Control" response header`),
+				err,
+			},
+		})
+		return newCacheControl(opChain, nil)
+	}
+
+	return newCacheControl(opChain, directives)
+}
+
+// ETag returns a new String instance with value of "ETag" header,
+// including quotes and weak validator prefix, if any.
+//
+// If header is missing or is not a valid entity tag, failure is reported.
+//
+// Example:
+//
+//	resp := NewResponse(t, response)
+//	resp.ETag().IsEqual(`W/"v1"`)
+func (r *Response) ETag() *String {
+	opChain := r.chain.enter("ETag()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newString(opChain, "")
+	}
+
+	etag, ok := r.getHeader(opChain, "ETag")
+	if !ok {
+		return newString(opChain, "")
+	}
+
+	if !isValidETag(etag) {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{etag},
+			Errors: []error{
+				errors.New(`invalid "ETag" response header`),
+			},
+		})
+		return newString(opChain, "")
+	}
+
+	return newString(opChain, etag)
+}
+
+// LastModified returns a new DateTime instance with value of "Last-Modified"
+// header.
+//
+// If header is missing or is not a valid HTTP date, failure is reported.
+//
+// Example:
+//
+//	resp := NewResponse(t, response)
+//	resp.LastModified().Lt(time.Now())
+func (r *Response) LastModified() *DateTime {
+	opChain := r.chain.enter("LastModified()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newDateTime(opChain, time.Unix(0, 0))
+	}
+
+	value, ok := r.getHeader(opChain, "Last-Modified")
+	if !ok {
+		return newDateTime(opChain, time.Unix(0, 0))
+	}
+
+	lastModified, err := http.ParseTime(value)
+	if err != nil {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{value},
+			Errors: []error{
+				errors.New(`invalid "Last-Modified" response header`),
+				err,
+			},
+		})
+		return newDateTime(opChain, time.Unix(0, 0))
+	}
+
+	return newDateTime(opChain, lastModified)
+}
+
+// Vary returns a new Array instance with header names listed in all
+// "Vary" headers, in canonical form.
+//
+// If there are no "Vary" headers, returned Array is empty.
+//
+// Example:
+//
+//	resp := NewResponse(t, response)
+//	resp.Vary().ContainsOnly("Accept-Encoding", "Authorization")
+func (r *Response) Vary() *Array {
+	opChain := r.chain.enter("Vary()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newArray(opChain, nil)
+	}
+
+	names := []interface{}{}
+
+	for _, value := range r.httpResp.Header.Values("Vary") {
+		for _, name := range strings.Split(value, ",") {
+			name = strings.TrimSpace(name)
+			if name != "" {
+				names = append(names, http.CanonicalHeaderKey(name))
+			}
+		}
+	}
+
+	return newArray(opChain, names)
+}
+
+// Age returns a new Duration instance with value of "Age" header, which
+// is set by caches to the time since response was generated by origin.
+//
+// If header is missing or is not a non-negative integer number of seconds,
+// failure is reported.
+//
+// Example:
+//
+//	resp := NewResponse(t, response)
+//	resp.Age().Le(time.Minute)
+func (r *Response) Age() *Duration {
+	opChain := r.chain.enter("Age()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newDuration(opChain, nil)
+	}
+
+	value, ok := r.getHeader(opChain, "Age")
+	if !ok {
+		return newDuration(opChain, nil)
+	}
+
+	age, err := parseDeltaSeconds(strings.TrimSpace(value))
+	if err != nil {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{value},
+			Errors: []error{
+				errors.New(`invalid "Age" response header`),
+				err,
+			},
+		})
+		return newDuration(opChain, nil)
+	}
+
+	return newDuration(opChain, &age)
+}
+
+// CORS returns a new CORS instance to inspect CORS headers of response.
+//
+// It's useful for actual cross-origin responses; for preflight requests,
+// see Expect.Preflight.
+//
+// Example:
+//
+//	resp := NewResponse(t, response)
+//	resp.CORS().AllowsOrigin("https://example.com").ExposesHeader("X-Total-Count")
+func (r *Response) CORS() *CORS {
+	opChain := r.chain.enter("CORS()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newCORS(opChain, nil)
+	}
+
+	return newCORS(opChain, r.httpResp.Header)
+}
+
+// SecurityHeaders returns a new SecurityHeaders instance to inspect
+// security-related headers of response.
+//
+// Example:
+//
+//	resp := NewResponse(t, response)
+//	resp.SecurityHeaders().Baseline().HSTSIncludesSubDomains()
+func (r *Response) SecurityHeaders() *SecurityHeaders {
+	opChain := r.chain.enter("SecurityHeaders()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newSecurityHeaders(opChain, nil)
+	}
+
+	return newSecurityHeaders(opChain, r.httpResp.Header)
+}
+
+// ContentRange returns a new ContentRange instance with parsed
+// "Content-Range" header, which is sent with partial content
+// (status 206) and range not satisfiable (status 416) responses.
+//
+// If header is missing or invalid, failure is reported.
+//
+// Example:
+//
+//	resp := NewResponse(t, response)
+//	cr := resp.ContentRange()
+//	cr.Unit().IsEqual("bytes")
+//	cr.Start().IsEqual(0)
+//	cr.End().IsEqual(99)
+//	cr.Total().IsEqual(1000)
+func (r *Response) ContentRange() *ContentRange {
+	opChain := r.chain.enter("ContentRange()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newContentRange(opChain, nil)
+	}
+
+	return newContentRange(opChain, getContentRange(opChain, r.httpResp.Header))
+}
+
+// RangeParts returns a new RangeParts instance with parts of partial
+// content response.
+//
+// If Content-Type is "multipart/byteranges", body is split into parts,
+// each with its own headers and body. Otherwise, if "Content-Range"
+// header is present, a single part is returned with response headers
+// and body.
+//
+// If response has neither multipart/byteranges payload nor
+// "Content-Range" header, or if payload can't be parsed, failure is
+// reported and empty (but non-nil) instance is returned.
+//
+// Example:
+//
+//	resp := NewResponse(t, response)
+//	parts := resp.RangeParts()
+//	parts.Length().IsEqual(2)
+//	parts.Part(0).ContentRange().Start().IsEqual(0)
+//	parts.Part(0).Body().IsEqual("hello")
+func (r *Response) RangeParts() *RangeParts {
+	opChain := r.chain.enter("RangeParts()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newRangeParts(opChain, nil)
+	}
+
+	content, ok := r.getContent(opChain)
+	if !ok {
+		return newRangeParts(opChain, nil)
+	}
+
+	var parts []rangePartData
+
+	contentType := r.httpResp.Header.Get("Content-Type")
+
+	if boundary, ok := isByteRanges(contentType); ok {
+		var err error
+		parts, err = parseByteRanges(boundary, content)
+		if err != nil {
+			opChain.fail(AssertionFailure{
+				Type:   AssertValid,
+				Actual: &AssertionValue{string(content)},
+				Errors: []error{
+					errors.New("invalid multipart/byteranges response body"),
+					err,
+				},
+			})
+			return newRangeParts(opChain, nil)
+		}
+	} else {
+		if _, ok := r.getHeader(opChain, "Content-Range"); !ok {
+			return newRangeParts(opChain, nil)
+		}
+		parts = []rangePartData{
+			{header: r.httpResp.Header, body: content},
+		}
+	}
+
+	return newRangeParts(opChain, parts)
+}
+
+func (r *Response) linkFollower() *linkFollower {
+	f := &linkFollower{
+		config: r.config,
+		origin: r.request,
+	}
+
+	if r.httpResp != nil && r.httpResp.Request != nil {
+		f.base = r.httpResp.Request.URL
+	}
+
+	return f
+}
+
+func (r *Response) getHeader(opChain *chain, name string) (string, bool) {
+	value := r.httpResp.Header.Get(name)
+
+	if value == "" {
+		opChain.fail(AssertionFailure{
+			Type:     AssertContainsKey,
+			Actual:   &AssertionValue{r.httpResp.Header},
+			Expected: &AssertionValue{name},
+			Errors: []error{
+				fmt.Errorf("expected: response contains %q header", name),
+			},
+		})
+		return "", false
+	}
+
+	return value, true
+}
+
 // Cookies returns a new Array instance with all cookie names set by this response.
 // Returned Array contains a String value for every cookie name.
 //
@@ -637,6 +1136,11 @@ func (r *Response) ContentType(mediaType string, charset ...string) *Response {
 
 // ContentEncoding succeeds if response has exactly given Content-Encoding list.
 // Common values are empty, "gzip", "compress", "deflate", "identity" and "br".
+//
+// Note that response body is decoded automatically before inspection,
+// unless Config.DisableDecompression is set or response is partial (see
+// Config.DisableDecompression). Codings other than "gzip", "deflate", "br",
+// and "zstd" are not decoded, and body is inspected as is.
 func (r *Response) ContentEncoding(encoding ...string) *Response {
 	opChain := r.chain.enter("ContentEncoding()")
 	defer opChain.leave()
@@ -675,6 +1179,10 @@ type ContentOpts struct {
 	MediaType string
 	// The character set Content-Type part, e.g. "utf-8"
 	Charset string
+	// Decode JSON numbers without loss of precision, e.g. int64 values
+	// above 2^53 or decimals with many significant digits. Numbers that
+	// can't be represented exactly by float64 are kept as json.Number.
+	UseNumber bool
 }
 
 // Text returns a new String instance with response body.
@@ -800,6 +1308,13 @@ func (r *Response) getForm(
 //	resp.JSON(ContentOpts{
 //	  MediaType: "application/json",
 //	}).Array.ConsistsOf("foo", "bar")
+//
+// By default, numbers are decoded into float64. Set UseNumber option to
+// decode large integers and precise decimals without loss of precision:
+//
+//	resp.JSON(ContentOpts{
+//	  UseNumber: true,
+//	}).Object().Value("id").Number().IsEqualExact("12345678901234567890")
 func (r *Response) JSON(options ...ContentOpts) *Value {
 	opChain := r.chain.enter("JSON()")
 	defer opChain.leave()
@@ -833,9 +1348,8 @@ func (r *Response) getJSON(opChain *chain, options ...ContentOpts) interface{} {
 		return nil
 	}
 
-	var value interface{}
-
-	if err := json.Unmarshal(content, &value); err != nil {
+	value, err := decodeJSON(content, options...)
+	if err != nil {
 		opChain.fail(AssertionFailure{
 			Type: AssertValid,
 			Actual: &AssertionValue{
@@ -852,6 +1366,23 @@ func (r *Response) getJSON(opChain *chain, options ...ContentOpts) interface{} {
 	return value
 }
 
+func decodeJSON(content []byte, options ...ContentOpts) (interface{}, error) {
+	var value interface{}
+
+	if len(options) != 0 && options[0].UseNumber {
+		if err := unmarshalUseNumber(content, &value); err != nil {
+			return nil, err
+		}
+		return canonJSONNumbers(value), nil
+	}
+
+	if err := json.Unmarshal(content, &value); err != nil {
+		return nil, err
+	}
+
+	return value, nil
+}
+
 // JSONP returns a new Value instance with JSONP decoded from response body.
 //
 // JSONP succeeds if response contains "application/javascript" Content-Type
@@ -927,9 +1458,8 @@ func (r *Response) getJSONP(
 		return nil
 	}
 
-	var value interface{}
-
-	if err := json.Unmarshal(m[2], &value); err != nil {
+	value, err := decodeJSON(m[2], options...)
+	if err != nil {
 		opChain.fail(AssertionFailure{
 			Type: AssertValid,
 			Actual: &AssertionValue{
@@ -946,6 +1476,209 @@ func (r *Response) getJSONP(
 	return value
 }
 
+// Problem returns a new Problem instance with Problem Details object
+// (RFC 9457, formerly RFC 7807) decoded from response body.
+//
+// Problem succeeds if response contains "application/problem+json" or
+// "application/problem+xml" Content-Type header with empty or "utf-8"
+// charset, and response body can be decoded. If "status" member is present,
+// it should be equal to response status code.
+//
+// Example:
+//
+//	resp := NewResponse(t, response)
+//	problem := resp.Problem()
+//	problem.Type().IsEqual("https://example.com/probs/out-of-credit")
+//	problem.Title().IsEqual("You do not have enough credit.")
+//	problem.Extension("balance").Number().IsEqual(30)
+func (r *Response) Problem() *Problem {
+	opChain := r.chain.enter("Problem()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newProblem(opChain, nil)
+	}
+
+	value := r.getProblem(opChain)
+	if value == nil {
+		return newProblem(opChain, nil)
+	}
+
+	if status, ok := value["status"]; ok && status != float64(r.httpResp.StatusCode) {
+		opChain.fail(AssertionFailure{
+			Type:     AssertEqual,
+			Actual:   &AssertionValue{status},
+			Expected: &AssertionValue{r.httpResp.StatusCode},
+			Errors: []error{
+				errors.New(
+					`expected: problem "status" member matches response status code`),
+			},
+		})
+		return newProblem(opChain, nil)
+	}
+
+	return newProblem(opChain, value)
+}
+
+func (r *Response) getProblem(opChain *chain) map[string]interface{} {
+	const (
+		jsonType = "application/problem+json"
+		xmlType  = "application/problem+xml"
+	)
+
+	contentType := r.httpResp.Header.Get("Content-Type")
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+
+	if mediaType != jsonType && mediaType != xmlType {
+		opChain.fail(AssertionFailure{
+			Type:     AssertBelongs,
+			Actual:   &AssertionValue{contentType},
+			Expected: &AssertionValue{AssertionList{jsonType, xmlType}},
+			Errors: []error{
+				errors.New(`unexpected media type in "Content-Type" response header`),
+			},
+		})
+		return nil
+	}
+
+	if !r.checkContentType(opChain, mediaType) {
+		return nil
+	}
+
+	content, ok := r.getContent(opChain)
+	if !ok {
+		return nil
+	}
+
+	var (
+		value map[string]interface{}
+		err   error
+	)
+
+	if mediaType == jsonType {
+		err = json.Unmarshal(content, &value)
+		if err == nil && value == nil {
+			err = errors.New("expected json object")
+		}
+	} else {
+		value, err = parseProblemXML(content)
+	}
+
+	if err != nil {
+		opChain.fail(AssertionFailure{
+			Type: AssertValid,
+			Actual: &AssertionValue{
+				string(content),
+			},
+			Errors: []error{
+				errors.New("failed to decode problem details"),
+				err,
+			},
+		})
+		return nil
+	}
+
+	return value
+}
+
+// JSONAPI returns a new JSONAPI instance with JSON:API document decoded
+// from response body.
+//
+// JSONAPI succeeds if response contains "application/vnd.api+json"
+// Content-Type header with empty or "utf-8" charset, and response body
+// is a valid JSON:API document.
+//
+// Example:
+//
+//	resp := NewResponse(t, response)
+//	doc := resp.JSONAPI()
+//	doc.Data().Object().HasValue("type", "articles")
+//	doc.Included().Length().IsEqual(2)
+func (r *Response) JSONAPI() *JSONAPI {
+	opChain := r.chain.enter("JSONAPI()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newJSONAPI(opChain, r.linkFollower(), nil)
+	}
+
+	doc := r.getHypermedia(opChain, "application/vnd.api+json", "JSON:API",
+		validateJSONAPI)
+
+	return newJSONAPI(opChain, r.linkFollower(), doc)
+}
+
+// HAL returns a new HAL instance with HAL resource decoded from
+// response body.
+//
+// HAL succeeds if response contains "application/hal+json" or
+// "application/json" Content-Type header with empty or "utf-8" charset,
+// and response body is a valid HAL resource.
+//
+// Example:
+//
+//	resp := NewResponse(t, response)
+//	hal := resp.HAL()
+//	hal.Embedded("orders").Array().Length().IsEqual(2)
+//	hal.Follow("next").Expect().Status(http.StatusOK)
+func (r *Response) HAL() *HAL {
+	opChain := r.chain.enter("HAL()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newHAL(opChain, r.linkFollower(), nil)
+	}
+
+	mediaType := "application/hal+json"
+
+	contentType := r.httpResp.Header.Get("Content-Type")
+	if t, _, _ := mime.ParseMediaType(contentType); t == "application/json" {
+		mediaType = t
+	}
+
+	resource := r.getHypermedia(opChain, mediaType, "HAL", validateHAL)
+
+	return newHAL(opChain, r.linkFollower(), resource)
+}
+
+func (r *Response) getHypermedia(
+	opChain *chain,
+	mediaType string,
+	format string,
+	validate func(map[string]interface{}) error,
+) map[string]interface{} {
+	value := r.getJSON(opChain, ContentOpts{MediaType: mediaType})
+	if opChain.failed() {
+		return nil
+	}
+
+	obj, ok := value.(map[string]interface{})
+	if !ok {
+		opChain.fail(AssertionFailure{
+			Type:   AssertType,
+			Actual: &AssertionValue{value},
+			Errors: []error{
+				fmt.Errorf("expected: %s document is an object", format),
+			},
+		})
+		return nil
+	}
+
+	if err := validate(obj); err != nil {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{obj},
+			Errors: []error{
+				fmt.Errorf("invalid %s document", format),
+				err,
+			},
+		})
+		return nil
+	}
+
+	return obj
+}
+
 func (r *Response) checkContentOptions(
 	opChain *chain, options []ContentOpts, expectedType string, expectedCharset ...string,
 ) bool {
@@ -2,6 +2,7 @@ package httpexpect
 
 import (
 	"bytes"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io/ioutil"
@@ -11,6 +12,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestResponse_FailedChain(t *testing.T) {
@@ -23,6 +25,20 @@ func TestResponse_FailedChain(t *testing.T) {
 		resp.Duration().chain.assertFailed(t)
 		resp.Headers().chain.assertFailed(t)
 		resp.Header("foo").chain.assertFailed(t)
+		resp.Location().chain.assertFailed(t)
+		resp.Links().chain.assertFailed(t)
+		resp.CacheControl().chain.assertFailed(t)
+		resp.ETag().chain.assertFailed(t)
+		resp.LastModified().chain.assertFailed(t)
+		resp.Vary().chain.assertFailed(t)
+		resp.Age().chain.assertFailed(t)
+		resp.CORS().chain.assertFailed(t)
+		resp.SecurityHeaders().chain.assertFailed(t)
+		resp.ContentRange().chain.assertFailed(t)
+		resp.RangeParts().chain.assertFailed(t)
+		resp.Problem().chain.assertFailed(t)
+		resp.JSONAPI().chain.assertFailed(t)
+		resp.HAL().chain.assertFailed(t)
 		resp.Cookies().chain.assertFailed(t)
 		resp.Cookie("foo").chain.assertFailed(t)
 		resp.Body().chain.assertFailed(t)
@@ -293,6 +309,602 @@ func TestResponse_Headers(t *testing.T) {
 	resp.Header("Bad-Header").IsEmpty().chain.assertNotFailed(t)
 }
 
+func TestResponse_Location(t *testing.T) {
+	t.Run("absolute", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		resp := NewResponse(reporter, &http.Response{
+			StatusCode: http.StatusFound,
+			Header: http.Header{
+				"Location": {"https://example.com/users/123?tab=info"},
+			},
+		})
+
+		loc := resp.Location()
+		loc.Scheme().IsEqual("https")
+		loc.Host().IsEqual("example.com")
+		loc.Path().IsEqual("/users/123")
+		loc.Query().Value("tab").Array().ConsistsOf("info")
+
+		resp.chain.assertNotFailed(t)
+	})
+
+	t.Run("relative", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		httpReq, _ := http.NewRequest("POST", "http://example.com/users/", nil)
+
+		resp := NewResponse(reporter, &http.Response{
+			StatusCode: http.StatusCreated,
+			Header: http.Header{
+				"Location": {"123"},
+			},
+			Request: httpReq,
+		})
+
+		loc := resp.Location()
+		loc.Host().IsEqual("example.com")
+		loc.Path().IsEqual("/users/123")
+
+		resp.chain.assertNotFailed(t)
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		resp := NewResponse(reporter, &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{},
+		})
+
+		resp.Location().chain.assertFailed(t)
+		resp.chain.assertFailed(t)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		resp := NewResponse(reporter, &http.Response{
+			StatusCode: http.StatusFound,
+			Header: http.Header{
+				"Location": {"http://[::1"},
+			},
+		})
+
+		resp.Location().chain.assertFailed(t)
+		resp.chain.assertFailed(t)
+	})
+}
+
+func TestResponse_Links(t *testing.T) {
+	t.Run("links", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		httpReq, _ := http.NewRequest("GET", "http://example.com/items?page=2", nil)
+
+		resp := NewResponse(reporter, &http.Response{
+			StatusCode: http.StatusOK,
+			Header: http.Header{
+				"Link": {
+					`</items?page=3>; rel="next", </items?page=1>; rel="prev"`,
+					`<http://example.com/items?page=9>; rel=last`,
+				},
+			},
+			Request: httpReq,
+		})
+
+		links := resp.Links()
+		links.Rels().IsEqual([]interface{}{"last", "next", "prev"})
+		links.Rel("next").Query().Value("page").Array().ConsistsOf("3")
+		links.Rel("prev").Host().IsEqual("example.com")
+		links.NotHasRel("first")
+
+		resp.chain.assertNotFailed(t)
+	})
+
+	t.Run("no links", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		resp := NewResponse(reporter, &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{},
+		})
+
+		resp.Links().Rels().IsEmpty()
+
+		resp.chain.assertNotFailed(t)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		resp := NewResponse(reporter, &http.Response{
+			StatusCode: http.StatusOK,
+			Header: http.Header{
+				"Link": {`/items?page=3; rel="next"`},
+			},
+		})
+
+		resp.Links().chain.assertFailed(t)
+		resp.chain.assertFailed(t)
+	})
+}
+
+func TestResponse_Caching(t *testing.T) {
+	t.Run("headers", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		resp := NewResponse(reporter, &http.Response{
+			StatusCode: http.StatusOK,
+			Header: http.Header{
+				"Cache-Control": {"public, max-age=60", "s-maxage=600"},
+				"Etag":          {`W/"v1"`},
+				"Last-Modified": {"Wed, 21 Oct 2015 07:28:00 GMT"},
+				"Vary":          {"accept-encoding, Authorization", "Origin"},
+				"Age":           {"15"},
+			},
+		})
+
+		cc := resp.CacheControl()
+		cc.HasDirective("public")
+		cc.NotHasDirective("no-store")
+		cc.MaxAge().IsEqual(time.Minute)
+		cc.SMaxAge().IsEqual(10 * time.Minute)
+
+		resp.ETag().IsEqual(`W/"v1"`)
+		resp.LastModified().IsEqual(time.Date(2015, 10, 21, 7, 28, 0, 0, time.UTC))
+		resp.Vary().IsEqual([]interface{}{"Accept-Encoding", "Authorization", "Origin"})
+		resp.Age().IsEqual(15 * time.Second)
+
+		resp.chain.assertNotFailed(t)
+	})
+
+	t.Run("no headers", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		resp := NewResponse(reporter, &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{},
+		})
+
+		resp.CacheControl().Directives().IsEmpty()
+		resp.Vary().IsEmpty()
+
+		resp.chain.assertNotFailed(t)
+
+		resp.ETag().chain.assertFailed(t)
+		resp.LastModified().chain.assertFailed(t)
+		resp.Age().chain.assertFailed(t)
+	})
+
+	t.Run("invalid headers", func(t *testing.T) {
+		cases := []struct {
+			header string
+			value  string
+			fn     func(resp *Response)
+		}{
+			{
+				header: "Cache-Control",
+				value:  "max-age=10 public",
+				fn:     func(resp *Response) { resp.CacheControl() },
+			},
+			{
+				header: "Etag",
+				value:  "v1",
+				fn:     func(resp *Response) { resp.ETag() },
+			},
+			{
+				header: "Etag",
+				value:  `"v"1"`,
+				fn:     func(resp *Response) { resp.ETag() },
+			},
+			{
+				header: "Last-Modified",
+				value:  "yesterday",
+				fn:     func(resp *Response) { resp.LastModified() },
+			},
+			{
+				header: "Age",
+				value:  "-5",
+				fn:     func(resp *Response) { resp.Age() },
+			},
+		}
+
+		for _, tc := range cases {
+			t.Run(tc.header+": "+tc.value, func(t *testing.T) {
+				reporter := newMockReporter(t)
+
+				resp := NewResponse(reporter, &http.Response{
+					StatusCode: http.StatusOK,
+					Header: http.Header{
+						tc.header: {tc.value},
+					},
+				})
+
+				tc.fn(resp)
+				resp.chain.assertFailed(t)
+			})
+		}
+	})
+}
+
+func TestResponse_Ranges(t *testing.T) {
+	t.Run("single range", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		resp := NewResponse(reporter, &http.Response{
+			StatusCode: http.StatusPartialContent,
+			Header: http.Header{
+				"Content-Type":  {"text/plain"},
+				"Content-Range": {"bytes 6-10/11"},
+			},
+			Body: newMockBody("world"),
+		})
+
+		cr := resp.ContentRange()
+		cr.Unit().IsEqual("bytes")
+		cr.Start().IsEqual(6)
+		cr.End().IsEqual(10)
+		cr.Total().IsEqual(11)
+
+		parts := resp.RangeParts()
+		parts.Length().IsEqual(1)
+
+		parts.Part(0).ContentType().IsEqual("text/plain")
+		parts.Part(0).ContentRange().Start().IsEqual(6)
+		parts.Part(0).Body().IsEqual("world")
+		assert.Equal(t, []byte("world"), parts.Part(0).Raw())
+
+		resp.chain.assertNotFailed(t)
+	})
+
+	t.Run("multiple ranges", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		body := "--SEP\r\n" +
+			"Content-Type: text/plain\r\n" +
+			"Content-Range: bytes 0-4/11\r\n" +
+			"\r\n" +
+			"hello\r\n" +
+			"--SEP\r\n" +
+			"Content-Type: text/plain\r\n" +
+			"Content-Range: bytes 6-10/11\r\n" +
+			"\r\n" +
+			"world\r\n" +
+			"--SEP--\r\n"
+
+		resp := NewResponse(reporter, &http.Response{
+			StatusCode: http.StatusPartialContent,
+			Header: http.Header{
+				"Content-Type": {"multipart/byteranges; boundary=SEP"},
+			},
+			Body: newMockBody(body),
+		})
+
+		parts := resp.RangeParts()
+		parts.Length().IsEqual(2)
+
+		parts.Part(0).ContentRange().Start().IsEqual(0)
+		parts.Part(0).ContentRange().End().IsEqual(4)
+		parts.Part(0).Body().IsEqual("hello")
+
+		parts.Part(1).ContentRange().Start().IsEqual(6)
+		parts.Part(1).ContentRange().End().IsEqual(10)
+		parts.Part(1).Body().IsEqual("world")
+
+		assert.Equal(t, []string{"RangeParts()", "Part(1)", "Body()"},
+			parts.Part(1).Body().chain.context.Path[1:])
+
+		iter := parts.Iter()
+		require.Equal(t, 2, len(iter))
+		iter[0].Body().IsEqual("hello")
+		iter[1].Body().IsEqual("world")
+
+		assert.Equal(t, []string{"RangeParts()", "Iter[1]", "Body()"},
+			iter[1].Body().chain.context.Path[1:])
+
+		resp.chain.assertNotFailed(t)
+
+		resp.ContentRange().chain.assertFailed(t)
+		resp.chain.assertFailed(t)
+	})
+
+	t.Run("invalid multipart", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		resp := NewResponse(reporter, &http.Response{
+			StatusCode: http.StatusPartialContent,
+			Header: http.Header{
+				"Content-Type": {"multipart/byteranges; boundary=SEP"},
+			},
+			Body: newMockBody("garbage"),
+		})
+
+		parts := resp.RangeParts()
+		parts.chain.assertFailed(t)
+		parts.Part(0).chain.assertFailed(t)
+		assert.Empty(t, parts.Iter())
+		resp.chain.assertFailed(t)
+	})
+
+	t.Run("not partial", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		resp := NewResponse(reporter, &http.Response{
+			StatusCode: http.StatusOK,
+			Header: http.Header{
+				"Content-Type": {"text/plain"},
+			},
+			Body: newMockBody("hello world"),
+		})
+
+		parts := resp.RangeParts()
+		parts.chain.assertFailed(t)
+		parts.Part(0).chain.assertFailed(t)
+		assert.Empty(t, parts.Iter())
+		resp.chain.assertFailed(t)
+	})
+
+	t.Run("missing part header", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		body := "--SEP\r\n" +
+			"Content-Type: text/plain\r\n" +
+			"\r\n" +
+			"hello\r\n" +
+			"--SEP--\r\n"
+
+		resp := NewResponse(reporter, &http.Response{
+			StatusCode: http.StatusPartialContent,
+			Header: http.Header{
+				"Content-Type": {"multipart/byteranges; boundary=SEP"},
+			},
+			Body: newMockBody(body),
+		})
+
+		parts := resp.RangeParts()
+		parts.Length().IsEqual(1)
+		resp.chain.assertNotFailed(t)
+
+		part := parts.Part(0)
+		part.ContentRange().chain.assertFailed(t)
+		part.chain.assertFailed(t)
+	})
+
+	t.Run("part out of range", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		resp := NewResponse(reporter, &http.Response{
+			StatusCode: http.StatusPartialContent,
+			Header: http.Header{
+				"Content-Range": {"bytes 0-4/11"},
+			},
+			Body: newMockBody("hello"),
+		})
+
+		parts := resp.RangeParts()
+		parts.chain.assertNotFailed(t)
+
+		part := parts.Part(1)
+		part.chain.assertFailed(t)
+		part.Body().chain.assertFailed(t)
+		parts.chain.assertFailed(t)
+
+		part = parts.Part(-1)
+		part.chain.assertFailed(t)
+	})
+
+	t.Run("gzip single range", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		data, err := compressContent("gzip", []byte("hello, world"))
+		require.NoError(t, err)
+
+		// range of gzip-encoded representation is a slice of compressed bytes
+		resp := NewResponse(reporter, &http.Response{
+			StatusCode: http.StatusPartialContent,
+			Header: http.Header{
+				"Content-Type":     {"text/plain"},
+				"Content-Encoding": {"gzip"},
+				"Content-Range":    {fmt.Sprintf("bytes 0-9/%d", len(data))},
+			},
+			Body: newMockBody(string(data[:10])),
+		})
+
+		parts := resp.RangeParts()
+		parts.Length().IsEqual(1)
+
+		parts.Part(0).ContentRange().Start().IsEqual(0)
+		parts.Part(0).ContentRange().End().IsEqual(9)
+		parts.Part(0).Body().IsEqual(string(data[:10]))
+
+		resp.Body().IsEqual(string(data[:10]))
+
+		resp.chain.assertNotFailed(t)
+	})
+}
+
+func TestResponse_Hypermedia(t *testing.T) {
+	newResp := func(t *testing.T, contentType, body string) *Response {
+		return NewResponse(newMockReporter(t), &http.Response{
+			StatusCode: http.StatusOK,
+			Header: http.Header{
+				"Content-Type": {contentType},
+			},
+			Body: newMockBody(body),
+		})
+	}
+
+	t.Run("jsonapi", func(t *testing.T) {
+		resp := newResp(t, "application/vnd.api+json",
+			`{"data": {"type": "articles", "id": "1"}}`)
+		resp.JSONAPI().Data().Object().HasValue("id", "1")
+		resp.chain.assertNotFailed(t)
+
+		resp = newResp(t, "application/vnd.api+json; ext=bulk",
+			`{"meta": {}}`)
+		resp.JSONAPI()
+		resp.chain.assertNotFailed(t)
+
+		resp = newResp(t, "application/json",
+			`{"data": {"type": "articles", "id": "1"}}`)
+		resp.JSONAPI().chain.assertFailed(t)
+		resp.chain.assertFailed(t)
+
+		resp = newResp(t, "application/vnd.api+json", `[]`)
+		resp.JSONAPI().chain.assertFailed(t)
+		resp.chain.assertFailed(t)
+
+		resp = newResp(t, "application/vnd.api+json", `{"data": "foo"}`)
+		resp.JSONAPI().chain.assertFailed(t)
+		resp.chain.assertFailed(t)
+	})
+
+	t.Run("hal", func(t *testing.T) {
+		resp := newResp(t, "application/hal+json",
+			`{"_links": {"self": {"href": "/"}}}`)
+		resp.HAL().Links().ContainsKey("self")
+		resp.chain.assertNotFailed(t)
+
+		resp = newResp(t, "application/json",
+			`{"_links": {"self": {"href": "/"}}}`)
+		resp.HAL().Links().ContainsKey("self")
+		resp.chain.assertNotFailed(t)
+
+		resp = newResp(t, "text/plain", `{}`)
+		resp.HAL().chain.assertFailed(t)
+		resp.chain.assertFailed(t)
+
+		resp = newResp(t, "application/hal+json", `"foo"`)
+		resp.HAL().chain.assertFailed(t)
+		resp.chain.assertFailed(t)
+
+		resp = newResp(t, "application/hal+json", `{"_links": "foo"}`)
+		resp.HAL().chain.assertFailed(t)
+		resp.chain.assertFailed(t)
+	})
+}
+
+func TestResponse_Problem(t *testing.T) {
+	cases := []struct {
+		name        string
+		status      int
+		contentType string
+		body        string
+		fail        bool
+	}{
+		{
+			name:        "json",
+			status:      http.StatusForbidden,
+			contentType: "application/problem+json",
+			body: `{"type": "https://example.com/probs/out-of-credit",
+				"title": "Out of credit", "status": 403, "balance": 30}`,
+		},
+		{
+			name:        "json with charset",
+			status:      http.StatusForbidden,
+			contentType: "application/problem+json; charset=utf-8",
+			body: `{"type": "https://example.com/probs/out-of-credit",
+				"title": "Out of credit", "balance": 30}`,
+		},
+		{
+			name:        "xml",
+			status:      http.StatusForbidden,
+			contentType: "application/problem+xml",
+			body: `<problem xmlns="urn:ietf:rfc:7807">
+				<type>https://example.com/probs/out-of-credit</type>
+				<title>Out of credit</title>
+				<status>403</status>
+				<balance>30</balance>
+				</problem>`,
+		},
+		{
+			name:        "status mismatch",
+			status:      http.StatusBadRequest,
+			contentType: "application/problem+json",
+			body:        `{"title": "Out of credit", "status": 403}`,
+			fail:        true,
+		},
+		{
+			name:        "xml status mismatch",
+			status:      http.StatusBadRequest,
+			contentType: "application/problem+xml",
+			body:        `<problem><status>403</status></problem>`,
+			fail:        true,
+		},
+		{
+			name:        "plain json",
+			status:      http.StatusForbidden,
+			contentType: "application/json",
+			body:        `{"title": "Out of credit"}`,
+			fail:        true,
+		},
+		{
+			name:        "bad charset",
+			status:      http.StatusForbidden,
+			contentType: "application/problem+json; charset=latin1",
+			body:        `{"title": "Out of credit"}`,
+			fail:        true,
+		},
+		{
+			name:        "invalid json",
+			status:      http.StatusForbidden,
+			contentType: "application/problem+json",
+			body:        `{"title":`,
+			fail:        true,
+		},
+		{
+			name:        "json array",
+			status:      http.StatusForbidden,
+			contentType: "application/problem+json",
+			body:        `["Out of credit"]`,
+			fail:        true,
+		},
+		{
+			name:        "json null",
+			status:      http.StatusForbidden,
+			contentType: "application/problem+json",
+			body:        `null`,
+			fail:        true,
+		},
+		{
+			name:        "invalid xml",
+			status:      http.StatusForbidden,
+			contentType: "application/problem+xml",
+			body:        `<error/>`,
+			fail:        true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			reporter := newMockReporter(t)
+
+			resp := NewResponse(reporter, &http.Response{
+				StatusCode: tc.status,
+				Header: http.Header{
+					"Content-Type": {tc.contentType},
+				},
+				Body: newMockBody(tc.body),
+			})
+
+			problem := resp.Problem()
+
+			if tc.fail {
+				problem.chain.assertFailed(t)
+				resp.chain.assertFailed(t)
+				return
+			}
+
+			problem.Type().IsEqual("https://example.com/probs/out-of-credit")
+			problem.Title().IsEqual("Out of credit")
+			problem.Extension("balance").NotNull()
+
+			resp.chain.assertNotFailed(t)
+		})
+	}
+}
+
 func TestResponse_Cookies(t *testing.T) {
 	reporter := newMockReporter(t)
 
@@ -439,6 +1051,155 @@ func TestResponse_BodyOperations(t *testing.T) {
 	})
 }
 
+func TestResponse_BodyDecompression(t *testing.T) {
+	compress := func(encodings ...string) []byte {
+		data := []byte(`{"foo":123}`)
+		for _, enc := range encodings {
+			var err error
+			data, err = compressContent(enc, data)
+			require.NoError(t, err)
+		}
+		return data
+	}
+
+	t.Run("single coding", func(t *testing.T) {
+		for _, encoding := range supportedEncodings {
+			t.Run(encoding, func(t *testing.T) {
+				reporter := newMockReporter(t)
+
+				resp := NewResponse(reporter, &http.Response{
+					StatusCode: http.StatusOK,
+					Header: http.Header{
+						"Content-Type":     {"application/json"},
+						"Content-Encoding": {encoding},
+					},
+					Body: ioutil.NopCloser(bytes.NewReader(compress(encoding))),
+				})
+
+				resp.ContentEncoding(encoding)
+				resp.JSON().Object().Value("foo").IsEqual(123)
+
+				resp.chain.assertNotFailed(t)
+			})
+		}
+	})
+
+	t.Run("multiple codings", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		resp := NewResponse(reporter, &http.Response{
+			StatusCode: http.StatusOK,
+			Header: http.Header{
+				"Content-Encoding": {"gzip, identity", "br"},
+			},
+			Body: ioutil.NopCloser(bytes.NewReader(compress("gzip", "br"))),
+		})
+
+		resp.Body().IsEqual(`{"foo":123}`)
+		resp.chain.assertNotFailed(t)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		compressed := compress("gzip")
+
+		resp := NewResponseC(Config{
+			Reporter:             reporter,
+			DisableDecompression: true,
+		}, &http.Response{
+			StatusCode: http.StatusOK,
+			Header: http.Header{
+				"Content-Encoding": {"gzip"},
+			},
+			Body: ioutil.NopCloser(bytes.NewReader(compressed)),
+		})
+
+		resp.Body().IsEqual(string(compressed))
+		resp.chain.assertNotFailed(t)
+	})
+
+	t.Run("empty body", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		resp := NewResponse(reporter, &http.Response{
+			StatusCode: http.StatusOK,
+			Header: http.Header{
+				"Content-Encoding": {"gzip"},
+			},
+			Body: ioutil.NopCloser(bytes.NewReader(nil)),
+		})
+
+		resp.Body().IsEmpty()
+		resp.chain.assertNotFailed(t)
+	})
+
+	t.Run("invalid data", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		resp := NewResponse(reporter, &http.Response{
+			StatusCode: http.StatusOK,
+			Header: http.Header{
+				"Content-Encoding": {"gzip"},
+			},
+			Body: ioutil.NopCloser(bytes.NewBufferString("not gzip")),
+		})
+
+		resp.Body().chain.assertFailed(t)
+		resp.chain.assertFailed(t)
+	})
+
+	t.Run("unsupported coding", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		resp := NewResponse(reporter, &http.Response{
+			StatusCode: http.StatusOK,
+			Header: http.Header{
+				"Content-Encoding": {"compress"},
+			},
+			Body: ioutil.NopCloser(bytes.NewBufferString("data")),
+		})
+
+		resp.Body().IsEqual("data")
+		resp.chain.assertNotFailed(t)
+	})
+
+	t.Run("unsupported outer coding", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		compressed := compress("gzip")
+
+		resp := NewResponse(reporter, &http.Response{
+			StatusCode: http.StatusOK,
+			Header: http.Header{
+				"Content-Encoding": {"gzip, compress"},
+			},
+			Body: ioutil.NopCloser(bytes.NewReader(compressed)),
+		})
+
+		resp.Body().IsEqual(string(compressed))
+		resp.chain.assertNotFailed(t)
+	})
+
+	t.Run("unsupported inner coding", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		data, err := compressContent("gzip", []byte("data"))
+		require.NoError(t, err)
+
+		resp := NewResponse(reporter, &http.Response{
+			StatusCode: http.StatusOK,
+			Header: http.Header{
+				"Content-Encoding": {"compress", "gzip"},
+			},
+			Body: ioutil.NopCloser(bytes.NewReader(data)),
+		})
+
+		resp.Body().IsEqual("data")
+		resp.chain.assertNotFailed(t)
+	})
+}
+
 func TestResponse_BodyDeferred(t *testing.T) {
 	t.Run("constructor does not read content", func(t *testing.T) {
 		reporter := newMockReporter(t)
@@ -1146,6 +1907,140 @@ func TestResponse_JSON(t *testing.T) {
 			map[string]interface{}{"key": "value"}, resp.JSON().Object().Raw())
 	})
 
+	t.Run("use number", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		headers := map[string][]string{
+			"Content-Type": {"application/json; charset=utf-8"},
+		}
+
+		body := `{"id": 12345678901234567890, "price": 19.99,` +
+			` "amount": 0.10000000000000000001, "count": 3}`
+
+		httpResp := &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header(headers),
+			Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+		}
+
+		resp := NewResponse(reporter, httpResp)
+
+		lossy := resp.JSON().Object()
+		resp.chain.assertNotFailed(t)
+
+		assert.Equal(t, 12345678901234567890.0, lossy.Raw()["id"])
+		assert.Equal(t, 0.1, lossy.Raw()["amount"])
+
+		exact := resp.JSON(ContentOpts{UseNumber: true}).Object()
+		resp.chain.assertNotFailed(t)
+
+		assert.Equal(t, map[string]interface{}{
+			"id":     json.Number("12345678901234567890"),
+			"price":  19.99,
+			"amount": json.Number("0.10000000000000000001"),
+			"count":  3.0,
+		}, exact.Raw())
+
+		exact.Value("id").Number().IsEqualExact("12345678901234567890")
+		exact.chain.assertNotFailed(t)
+
+		exact.Value("id").Number().NotEqualExact("12345678901234567891")
+		exact.chain.assertNotFailed(t)
+
+		exact.Value("amount").Number().IsEqualExact("0.10000000000000000001")
+		exact.chain.assertNotFailed(t)
+
+		exact.ContainsSubset(map[string]interface{}{
+			"id":    uint64(12345678901234567890),
+			"price": 19.99,
+			"count": 3,
+		})
+		exact.chain.assertNotFailed(t)
+
+		exact.ContainsSubset(map[string]interface{}{
+			"id": uint64(12345678901234567891),
+		})
+		exact.chain.assertFailed(t)
+		exact.chain.clearFailed()
+	})
+
+	t.Run("large integer without use number", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		httpResp := &http.Response{
+			StatusCode: http.StatusOK,
+			Header: http.Header{
+				"Content-Type": {"application/json"},
+			},
+			Body: ioutil.NopCloser(bytes.NewBufferString(`{"id": 9007199254740993}`)),
+		}
+
+		resp := NewResponse(reporter, httpResp)
+
+		lossy := resp.JSON().Object()
+
+		lossy.Value("id").IsEqual(int64(9007199254740993))
+		lossy.IsEqual(map[string]interface{}{"id": int64(9007199254740993)})
+		lossy.ContainsSubset(map[string]interface{}{"id": int64(9007199254740993)})
+		lossy.IsEqualWith(map[string]interface{}{"id": int64(9007199254740993)})
+		resp.chain.assertNotFailed(t)
+
+		exact := resp.JSON(ContentOpts{UseNumber: true}).Object()
+
+		id := exact.Value("id")
+
+		id.IsEqual(int64(9007199254740993))
+		id.chain.assertNotFailed(t)
+
+		id.IsEqual(int64(9007199254740992))
+		id.chain.assertFailed(t)
+	})
+
+	t.Run("large integer number comparison", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		httpResp := &http.Response{
+			StatusCode: http.StatusOK,
+			Header: http.Header{
+				"Content-Type": {"application/json"},
+			},
+			Body: ioutil.NopCloser(
+				bytes.NewBufferString(`{"id": 12345678901234567891}`)),
+		}
+
+		resp := NewResponse(reporter, httpResp)
+
+		id := resp.JSON(ContentOpts{UseNumber: true}).Object().Value("id").Number()
+
+		id.IsEqual(uint64(12345678901234567890))
+		id.chain.assertFailed(t)
+		id.chain.clearFailed()
+
+		id.Gt(uint64(12345678901234567890))
+		id.Lt(json.Number("12345678901234567892"))
+		id.InRange(uint64(12345678901234567891), uint64(12345678901234567891))
+		id.chain.assertNotFailed(t)
+	})
+
+	t.Run("use number, bad body", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		headers := map[string][]string{
+			"Content-Type": {"application/json; charset=utf-8"},
+		}
+
+		httpResp := &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header(headers),
+			Body:       ioutil.NopCloser(bytes.NewBufferString(`{} {}`)),
+		}
+
+		resp := NewResponse(reporter, httpResp)
+
+		resp.JSON(ContentOpts{UseNumber: true})
+		resp.chain.assertFailed(t)
+	})
+
 	t.Run("bad body", func(t *testing.T) {
 		reporter := newMockReporter(t)
 
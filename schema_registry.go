@@ -0,0 +1,280 @@
+package httpexpect
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// SchemaRegistry holds a set of JSON Schema documents, which may
+// reference each other using "$ref", without network access.
+//
+// Registered schemas may use drafts 2019-09 and 2020-12, including
+// "$defs", "$anchor", "$dynamicRef", "unevaluatedProperties", and
+// "unevaluatedItems" keywords. Schemas without "$schema" keyword are
+// treated as draft 2020-12.
+//
+// Each schema is registered under its URI and, if it has "$id", under
+// that URI too. Schemas loaded from a directory or file system get
+// URIs of the form "file:///<path>", where path is relative to the
+// directory or file system root, so relative references between files
+// are resolved as expected.
+//
+// SchemaRegistry is safe for concurrent use.
+//
+// Example:
+//
+//	registry := httpexpect.NewSchemaRegistry()
+//	if err := registry.AddDir("testdata/schemas"); err != nil {
+//		t.Fatal(err)
+//	}
+//
+//	resp.JSON().Schema(registry.Ref("user.json"))
+type SchemaRegistry struct {
+	mu    sync.Mutex
+	index *jsonSchemaIndex
+}
+
+// NewSchemaRegistry returns a new empty SchemaRegistry.
+func NewSchemaRegistry() *SchemaRegistry {
+	return &SchemaRegistry{index: newJSONSchemaIndex()}
+}
+
+// Add registers schema under given URI.
+//
+// schema should be one of the following:
+//   - go value that can be json.Marshal-ed to a valid schema
+//   - string or []byte containing valid schema
+//
+// Relative URI is resolved against "file:///".
+func (r *SchemaRegistry) Add(uri string, schema interface{}) error {
+	var (
+		data interface{}
+		err  error
+	)
+
+	switch s := schema.(type) {
+	case string:
+		data, err = loadSchema([]byte(s))
+	case []byte:
+		data, err = loadSchema(s)
+	default:
+		var b []byte
+		if b, err = json.Marshal(schema); err == nil {
+			data, err = loadSchema(b)
+		}
+	}
+
+	if err != nil {
+		return fmt.Errorf("invalid schema %q: %s", uri, err)
+	}
+
+	absURI, err := resolveJSONSchemaURI("file:///", uri)
+	if err != nil {
+		return err
+	}
+
+	return r.add(map[string]interface{}{
+		stripJSONSchemaFragment(absURI): data,
+	})
+}
+
+// AddDir registers all "*.json" files from given directory and its
+// subdirectories. Each file is registered under "file:///<path>" URI,
+// where path is relative to the directory.
+func (r *SchemaRegistry) AddDir(dir string) error {
+	docs := map[string]interface{}{}
+
+	err := filepath.Walk(dir, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || !strings.HasSuffix(info.Name(), ".json") {
+			return nil
+		}
+
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+
+		b, err := ioutil.ReadFile(p)
+		if err != nil {
+			return err
+		}
+
+		data, err := loadSchema(b)
+		if err != nil {
+			return fmt.Errorf("invalid schema %q: %s", p, err)
+		}
+
+		docs["file:///"+filepath.ToSlash(rel)] = data
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return r.add(docs)
+}
+
+// AddFS registers all "*.json" files from given file system.
+// Each file is registered under "file:///<path>" URI, where path is
+// relative to the file system root.
+//
+// To load schemas from embed.FS, wrap it using http.FS:
+//
+//	//go:embed schemas
+//	var schemas embed.FS
+//
+//	sub, _ := fs.Sub(schemas, "schemas")
+//	registry.AddFS(http.FS(sub))
+func (r *SchemaRegistry) AddFS(fsys http.FileSystem) error {
+	docs := map[string]interface{}{}
+
+	if err := loadSchemaFS(fsys, "/", docs); err != nil {
+		return err
+	}
+
+	return r.add(docs)
+}
+
+// Ref returns a reference to registered schema with given URI, which
+// may be passed to Schema methods.
+//
+// Relative URI is resolved against "file:///", so schemas loaded from
+// directory may be referenced by their relative path. URI may contain
+// fragment, e.g. "defs.json#/$defs/user".
+//
+// Example:
+//
+//	resp.JSON().Schema(registry.Ref("user.json"))
+//	resp.JSON().Schema(registry.Ref("https://example.com/schemas/user"))
+func (r *SchemaRegistry) Ref(uri string) *SchemaRef {
+	return &SchemaRef{registry: r, uri: uri}
+}
+
+func (r *SchemaRegistry) add(docs map[string]interface{}) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// index is copied, so that concurrent validations may use previous
+	// index without locking
+	index := r.index.clone()
+
+	uris := make([]string, 0, len(docs))
+	for uri := range docs {
+		uris = append(uris, uri)
+	}
+	sort.Strings(uris)
+
+	for _, uri := range uris {
+		if err := index.add(uri, docs[uri]); err != nil {
+			return fmt.Errorf("invalid schema %q: %s", uri, err)
+		}
+	}
+
+	r.index = index
+	return nil
+}
+
+func (r *SchemaRegistry) getIndex() *jsonSchemaIndex {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.index
+}
+
+// SchemaRef is a reference to schema in SchemaRegistry.
+// It is created by SchemaRegistry.Ref and may be passed to Schema methods.
+type SchemaRef struct {
+	registry *SchemaRegistry
+	uri      string
+}
+
+// String returns referenced URI.
+func (s *SchemaRef) String() string {
+	return s.uri
+}
+
+func (s *SchemaRef) locate() (*jsonSchemaIndex, jsonSchemaLoc, error) {
+	if s.registry == nil {
+		return nil, jsonSchemaLoc{}, errors.New("schema reference without registry")
+	}
+
+	index := s.registry.getIndex()
+
+	loc, err := index.resolve("file:///", s.uri)
+	if err != nil {
+		return nil, jsonSchemaLoc{}, err
+	}
+
+	return index, loc, nil
+}
+
+func loadSchema(b []byte) (interface{}, error) {
+	var data interface{}
+
+	if err := unmarshalUseNumber(b, &data); err != nil {
+		return nil, err
+	}
+
+	return canonJSONNumbers(data), nil
+}
+
+func loadSchemaFS(fsys http.FileSystem, name string, docs map[string]interface{}) error {
+	f, err := fsys.Open(name)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+
+	if info.IsDir() {
+		entries, err := f.Readdir(-1)
+		if err != nil {
+			return err
+		}
+
+		sort.Slice(entries, func(i, j int) bool {
+			return entries[i].Name() < entries[j].Name()
+		})
+
+		for _, entry := range entries {
+			if err := loadSchemaFS(fsys, path.Join(name, entry.Name()), docs); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	if !strings.HasSuffix(name, ".json") {
+		return nil
+	}
+
+	b, err := ioutil.ReadAll(f)
+	if err != nil {
+		return err
+	}
+
+	data, err := loadSchema(b)
+	if err != nil {
+		return fmt.Errorf("invalid schema %q: %s", name, err)
+	}
+
+	docs["file://"+name] = data
+	return nil
+}
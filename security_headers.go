@@ -0,0 +1,677 @@
+package httpexpect
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// SecurityHeaders provides methods to inspect security-related headers
+// of a response, like "Strict-Transport-Security" (HSTS) and
+// "Content-Security-Policy" (CSP).
+type SecurityHeaders struct {
+	noCopy noCopy
+	chain  *chain
+	value  http.Header
+}
+
+func newSecurityHeaders(parent *chain, val http.Header) *SecurityHeaders {
+	s := &SecurityHeaders{chain: parent.clone(), value: nil}
+
+	opChain := s.chain.enter("")
+	defer opChain.leave()
+
+	if val == nil {
+		opChain.fail(AssertionFailure{
+			Type:   AssertNotNil,
+			Actual: &AssertionValue{val},
+			Errors: []error{
+				errors.New("expected: non-nil response header"),
+			},
+		})
+	} else {
+		s.value = val
+	}
+
+	return s
+}
+
+// Raw returns underlying http.Header of response.
+//
+// Example:
+//
+//	sh := resp.SecurityHeaders()
+//	assert.Equal(t, "DENY", sh.Raw().Get("X-Frame-Options"))
+func (s *SecurityHeaders) Raw() http.Header {
+	return s.value
+}
+
+// Alias is similar to Value.Alias.
+func (s *SecurityHeaders) Alias(name string) *SecurityHeaders {
+	opChain := s.chain.enter("Alias(%q)", name)
+	defer opChain.leave()
+
+	s.chain.setAlias(name)
+	return s
+}
+
+// Chain returns public handle to assertion chain of SecurityHeaders, which may be
+// used to implement custom matchers. See Chain for details.
+func (s *SecurityHeaders) Chain() *Chain {
+	return &Chain{chain: s.chain}
+}
+
+// AsWarning is similar to Value.AsWarning.
+func (s *SecurityHeaders) AsWarning() *SecurityHeaders {
+	opChain := s.chain.enter("AsWarning()")
+	defer opChain.leave()
+
+	warnChain := s.chain.clone()
+	warnChain.setWarning()
+
+	return &SecurityHeaders{
+		chain: warnChain,
+		value: s.value,
+	}
+}
+
+// Baseline succeeds if response has all recommended security headers
+// with valid values:
+//
+//   - "Strict-Transport-Security" with positive max-age
+//   - "Content-Security-Policy" with at least one directive
+//   - "X-Content-Type-Options" equal to "nosniff"
+//   - "X-Frame-Options" equal to "DENY" or "SAMEORIGIN", or
+//     "frame-ancestors" directive in "Content-Security-Policy"
+//   - "Referrer-Policy" with known policy
+//   - "Permissions-Policy" with at least one feature
+//
+// If some headers are missing or invalid, a single failure listing all
+// of them is reported.
+//
+// Example:
+//
+//	resp := e.GET("/").Expect()
+//	resp.SecurityHeaders().Baseline()
+func (s *SecurityHeaders) Baseline() *SecurityHeaders {
+	opChain := s.chain.enter("Baseline()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return s
+	}
+
+	var (
+		missing []interface{}
+		errs    []error
+	)
+
+	check := func(name string, err error) {
+		if err != nil {
+			missing = append(missing, name)
+			errs = append(errs, fmt.Errorf("%s: %s", name, err.Error()))
+		}
+	}
+
+	hsts, err := s.hsts()
+	if err == nil && hsts.maxAge <= 0 {
+		err = errors.New("expected positive max-age")
+	}
+	check("Strict-Transport-Security", err)
+
+	csp, err := s.csp()
+	if err == nil && len(csp) == 0 {
+		err = errors.New("expected at least one directive")
+	}
+	check("Content-Security-Policy", err)
+
+	value, err := s.header("X-Content-Type-Options")
+	if err == nil && !strings.EqualFold(value, "nosniff") {
+		err = fmt.Errorf("expected \"nosniff\", got %q", value)
+	}
+	check("X-Content-Type-Options", err)
+
+	if _, ok := csp["frame-ancestors"]; !ok {
+		value, err = s.header("X-Frame-Options")
+		if err == nil &&
+			!strings.EqualFold(value, "DENY") && !strings.EqualFold(value, "SAMEORIGIN") {
+			err = fmt.Errorf("expected \"DENY\" or \"SAMEORIGIN\", got %q", value)
+		}
+		check("X-Frame-Options", err)
+	}
+
+	value, err = s.referrerPolicy()
+	if err == nil && !isKnownReferrerPolicy(value) {
+		err = fmt.Errorf("unknown policy %q", value)
+	}
+	check("Referrer-Policy", err)
+
+	pp, err := s.permissionsPolicy()
+	if err == nil && len(pp) == 0 {
+		err = errors.New("expected at least one feature")
+	}
+	check("Permissions-Policy", err)
+
+	if len(missing) != 0 {
+		opChain.fail(AssertionFailure{
+			Type:     AssertContainsKey,
+			Actual:   &AssertionValue{s.value},
+			Expected: &AssertionValue{AssertionList(missing)},
+			Errors: append([]error{
+				errors.New("expected: response has all recommended security headers"),
+			}, errs...),
+		})
+	}
+
+	return s
+}
+
+// HSTSMaxAge returns a new Duration instance with value of "max-age"
+// directive of "Strict-Transport-Security" header.
+//
+// If header is missing or invalid, failure is reported.
+//
+// Example:
+//
+//	sh := resp.SecurityHeaders()
+//	sh.HSTSMaxAge().Ge(365 * 24 * time.Hour)
+func (s *SecurityHeaders) HSTSMaxAge() *Duration {
+	opChain := s.chain.enter("HSTSMaxAge()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newDuration(opChain, nil)
+	}
+
+	hsts, ok := s.getHSTS(opChain)
+	if !ok {
+		return newDuration(opChain, nil)
+	}
+
+	return newDuration(opChain, &hsts.maxAge)
+}
+
+// HSTSIncludesSubDomains succeeds if "Strict-Transport-Security" header
+// has "includeSubDomains" directive.
+//
+// If header is missing or invalid, failure is reported.
+//
+// Example:
+//
+//	sh := resp.SecurityHeaders()
+//	sh.HSTSIncludesSubDomains()
+func (s *SecurityHeaders) HSTSIncludesSubDomains() *SecurityHeaders {
+	opChain := s.chain.enter("HSTSIncludesSubDomains()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return s
+	}
+
+	hsts, ok := s.getHSTS(opChain)
+	if !ok {
+		return s
+	}
+
+	if !hsts.includeSubDomains {
+		opChain.fail(AssertionFailure{
+			Type:     AssertContainsElement,
+			Actual:   &AssertionValue{s.value.Get("Strict-Transport-Security")},
+			Expected: &AssertionValue{"includeSubDomains"},
+			Errors: []error{
+				errors.New(
+					`expected: "Strict-Transport-Security" has "includeSubDomains"`),
+			},
+		})
+	}
+
+	return s
+}
+
+// HSTSPreload succeeds if "Strict-Transport-Security" header has "preload"
+// directive.
+//
+// If header is missing or invalid, failure is reported.
+//
+// Example:
+//
+//	sh := resp.SecurityHeaders()
+//	sh.HSTSPreload()
+func (s *SecurityHeaders) HSTSPreload() *SecurityHeaders {
+	opChain := s.chain.enter("HSTSPreload()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return s
+	}
+
+	hsts, ok := s.getHSTS(opChain)
+	if !ok {
+		return s
+	}
+
+	if !hsts.preload {
+		opChain.fail(AssertionFailure{
+			Type:     AssertContainsElement,
+			Actual:   &AssertionValue{s.value.Get("Strict-Transport-Security")},
+			Expected: &AssertionValue{"preload"},
+			Errors: []error{
+				errors.New(`expected: "Strict-Transport-Security" has "preload"`),
+			},
+		})
+	}
+
+	return s
+}
+
+// ContentSecurityPolicy returns a new Object instance with directives of
+// "Content-Security-Policy" header.
+//
+// Keys are lower-cased directive names, and values are arrays of strings
+// with directive values. If directive is repeated, first occurrence wins.
+//
+// If header is missing, failure is reported.
+//
+// Example:
+//
+//	sh := resp.SecurityHeaders()
+//	csp := sh.ContentSecurityPolicy()
+//	csp.Value("default-src").Array().ConsistsOf("'self'")
+//	csp.Value("frame-ancestors").Array().ConsistsOf("'none'")
+func (s *SecurityHeaders) ContentSecurityPolicy() *Object {
+	opChain := s.chain.enter("ContentSecurityPolicy()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newObject(opChain, nil)
+	}
+
+	csp, err := s.csp()
+	if err != nil {
+		s.fail(opChain, "Content-Security-Policy", err)
+		return newObject(opChain, nil)
+	}
+
+	return newObject(opChain, csp)
+}
+
+// ContentTypeOptions returns a new String instance with value of
+// "X-Content-Type-Options" header.
+//
+// If header is missing, failure is reported.
+//
+// Example:
+//
+//	sh := resp.SecurityHeaders()
+//	sh.ContentTypeOptions().IsEqual("nosniff")
+func (s *SecurityHeaders) ContentTypeOptions() *String {
+	opChain := s.chain.enter("ContentTypeOptions()")
+	defer opChain.leave()
+
+	return s.headerString(opChain, "X-Content-Type-Options")
+}
+
+// FrameOptions returns a new String instance with value of
+// "X-Frame-Options" header.
+//
+// If header is missing, failure is reported.
+//
+// Example:
+//
+//	sh := resp.SecurityHeaders()
+//	sh.FrameOptions().IsEqualFold("deny")
+func (s *SecurityHeaders) FrameOptions() *String {
+	opChain := s.chain.enter("FrameOptions()")
+	defer opChain.leave()
+
+	return s.headerString(opChain, "X-Frame-Options")
+}
+
+// ReferrerPolicy returns a new String instance with effective policy
+// from "Referrer-Policy" header.
+//
+// Header may contain a list of fallback policies; browsers use the last
+// one they recognize, and this method returns the last known policy, or
+// the last policy if none is known. Returned policy is lower-cased.
+//
+// If header is missing, failure is reported.
+//
+// Example:
+//
+//	sh := resp.SecurityHeaders()
+//	sh.ReferrerPolicy().IsEqual("strict-origin-when-cross-origin")
+func (s *SecurityHeaders) ReferrerPolicy() *String {
+	opChain := s.chain.enter("ReferrerPolicy()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newString(opChain, "")
+	}
+
+	policy, err := s.referrerPolicy()
+	if err != nil {
+		s.fail(opChain, "Referrer-Policy", err)
+		return newString(opChain, "")
+	}
+
+	return newString(opChain, policy)
+}
+
+// PermissionsPolicy returns a new Object instance with features of
+// "Permissions-Policy" header.
+//
+// Keys are feature names, and values are arrays of strings with allowlist
+// of the feature, e.g. empty array for "()", or ["self", "https://a.com"]
+// for `(self "https://a.com")`.
+//
+// If header is missing or invalid, failure is reported.
+//
+// Example:
+//
+//	sh := resp.SecurityHeaders()
+//	pp := sh.PermissionsPolicy()
+//	pp.Value("camera").Array().IsEmpty()
+//	pp.Value("fullscreen").Array().ConsistsOf("self")
+func (s *SecurityHeaders) PermissionsPolicy() *Object {
+	opChain := s.chain.enter("PermissionsPolicy()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newObject(opChain, nil)
+	}
+
+	pp, err := s.permissionsPolicy()
+	if err != nil {
+		s.fail(opChain, "Permissions-Policy", err)
+		return newObject(opChain, nil)
+	}
+
+	return newObject(opChain, pp)
+}
+
+func (s *SecurityHeaders) headerString(opChain *chain, name string) *String {
+	if opChain.failed() {
+		return newString(opChain, "")
+	}
+
+	value, err := s.header(name)
+	if err != nil {
+		s.fail(opChain, name, err)
+		return newString(opChain, "")
+	}
+
+	return newString(opChain, value)
+}
+
+func (s *SecurityHeaders) getHSTS(opChain *chain) (hstsPolicy, bool) {
+	hsts, err := s.hsts()
+	if err != nil {
+		s.fail(opChain, "Strict-Transport-Security", err)
+		return hstsPolicy{}, false
+	}
+
+	return hsts, true
+}
+
+func (s *SecurityHeaders) fail(opChain *chain, name string, err error) {
+	if errors.Is(err, errSecurityHeaderMissing) {
+		opChain.fail(AssertionFailure{
+			Type:     AssertContainsKey,
+			Actual:   &AssertionValue{s.value},
+			Expected: &AssertionValue{name},
+			Errors: []error{
+				fmt.Errorf("expected: response contains %q header", name),
+			},
+		})
+	} else {
+		opChain.fail(AssertionFailure{
+			Type:   AssertValid,
+			Actual: &AssertionValue{s.value.Values(name)},
+			Errors: []error{
+				fmt.Errorf("invalid %q response header", name),
+				err,
+			},
+		})
+	}
+}
+
+var errSecurityHeaderMissing = errors.New("header is missing")
+
+func (s *SecurityHeaders) header(name string) (string, error) {
+	value := strings.TrimSpace(s.value.Get(name))
+	if value == "" {
+		return "", errSecurityHeaderMissing
+	}
+
+	return value, nil
+}
+
+type hstsPolicy struct {
+	maxAge            time.Duration
+	includeSubDomains bool
+	preload           bool
+}
+
+// Parse "Strict-Transport-Security" header, see RFC 6797, section 6.1.
+func (s *SecurityHeaders) hsts() (hstsPolicy, error) {
+	var hsts hstsPolicy
+
+	value, err := s.header("Strict-Transport-Security")
+	if err != nil {
+		return hsts, err
+	}
+
+	seen := map[string]bool{}
+
+	for _, directive := range strings.Split(value, ";") {
+		directive = strings.TrimSpace(directive)
+		if directive == "" {
+			continue
+		}
+
+		name, arg := directive, ""
+		if n := strings.IndexByte(directive, '='); n >= 0 {
+			name = strings.TrimSpace(directive[:n])
+			arg = strings.Trim(strings.TrimSpace(directive[n+1:]), `"`)
+		}
+		name = strings.ToLower(name)
+
+		if seen[name] {
+			return hsts, fmt.Errorf("duplicate directive %q", name)
+		}
+		seen[name] = true
+
+		switch name {
+		case "max-age":
+			hsts.maxAge, err = parseDeltaSeconds(arg)
+			if err != nil {
+				return hsts, err
+			}
+		case "includesubdomains":
+			hsts.includeSubDomains = true
+		case "preload":
+			hsts.preload = true
+		}
+	}
+
+	if !seen["max-age"] {
+		return hsts, errors.New(`missing required "max-age" directive`)
+	}
+
+	return hsts, nil
+}
+
+// Parse "Content-Security-Policy" header into map of directives.
+func (s *SecurityHeaders) csp() (map[string]interface{}, error) {
+	if _, err := s.header("Content-Security-Policy"); err != nil {
+		return nil, err
+	}
+
+	directives := map[string]interface{}{}
+
+	for _, value := range s.value.Values("Content-Security-Policy") {
+		for _, policy := range strings.Split(value, ",") {
+			for _, directive := range strings.Split(policy, ";") {
+				fields := strings.Fields(directive)
+				if len(fields) == 0 {
+					continue
+				}
+
+				name := strings.ToLower(fields[0])
+				if _, ok := directives[name]; ok {
+					continue
+				}
+
+				values := []interface{}{}
+				for _, f := range fields[1:] {
+					values = append(values, f)
+				}
+				directives[name] = values
+			}
+		}
+	}
+
+	return directives, nil
+}
+
+var referrerPolicies = []string{
+	"no-referrer",
+	"no-referrer-when-downgrade",
+	"origin",
+	"origin-when-cross-origin",
+	"same-origin",
+	"strict-origin",
+	"strict-origin-when-cross-origin",
+	"unsafe-url",
+}
+
+func isKnownReferrerPolicy(policy string) bool {
+	for _, p := range referrerPolicies {
+		if p == policy {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *SecurityHeaders) referrerPolicy() (string, error) {
+	if _, err := s.header("Referrer-Policy"); err != nil {
+		return "", err
+	}
+
+	var last, lastKnown string
+
+	for _, value := range s.value.Values("Referrer-Policy") {
+		for _, policy := range strings.Split(value, ",") {
+			policy = strings.ToLower(strings.TrimSpace(policy))
+			if policy == "" {
+				continue
+			}
+			last = policy
+			if isKnownReferrerPolicy(policy) {
+				lastKnown = policy
+			}
+		}
+	}
+
+	if lastKnown != "" {
+		return lastKnown, nil
+	}
+
+	return last, nil
+}
+
+// Parse "Permissions-Policy" header, which is a structured field dictionary
+// (RFC 8941) mapping features to allowlists.
+func (s *SecurityHeaders) permissionsPolicy() (map[string]interface{}, error) {
+	if _, err := s.header("Permissions-Policy"); err != nil {
+		return nil, err
+	}
+
+	features := map[string]interface{}{}
+
+	for _, value := range s.value.Values("Permissions-Policy") {
+		p := headerParser{input: value}
+
+		for {
+			p.skipSpaceAnd(',')
+			if p.done() {
+				break
+			}
+
+			name := p.parseToken()
+			if name == "" || p.peek() != '=' {
+				return nil, fmt.Errorf("expected feature name and '=' at position %d in %q",
+					p.pos, p.input)
+			}
+			p.pos++
+
+			allowlist, err := p.parseAllowlist()
+			if err != nil {
+				return nil, err
+			}
+
+			// skip parameters
+			for !p.done() && p.peek() != ',' {
+				if p.peek() == '"' {
+					if _, err := p.parseQuoted(); err != nil {
+						return nil, err
+					}
+				} else {
+					p.pos++
+				}
+			}
+
+			features[name] = allowlist
+		}
+	}
+
+	return features, nil
+}
+
+func (p *headerParser) parseAllowlist() ([]interface{}, error) {
+	allowlist := []interface{}{}
+
+	if p.peek() != '(' {
+		item, err := p.parseItem()
+		if err != nil {
+			return nil, err
+		}
+		return append(allowlist, item), nil
+	}
+	p.pos++
+
+	for {
+		p.skipSpaceAnd(0)
+
+		if p.done() {
+			return nil, fmt.Errorf("unterminated inner list in %q", p.input)
+		}
+
+		if p.peek() == ')' {
+			p.pos++
+			return allowlist, nil
+		}
+
+		item, err := p.parseItem()
+		if err != nil {
+			return nil, err
+		}
+		allowlist = append(allowlist, item)
+	}
+}
+
+func (p *headerParser) parseItem() (string, error) {
+	if p.peek() == '"' {
+		return p.parseQuoted()
+	}
+
+	item := p.parseToken()
+	if item == "" {
+		return "", fmt.Errorf("expected item at position %d in %q", p.pos, p.input)
+	}
+
+	return item, nil
+}
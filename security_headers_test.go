@@ -0,0 +1,326 @@
+package httpexpect
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSecurityHeaders_FailedChain(t *testing.T) {
+	check := func(value *SecurityHeaders) {
+		value.chain.assertFailed(t)
+
+		value.Alias("foo")
+
+		value.Baseline()
+		value.HSTSMaxAge().chain.assertFailed(t)
+		value.HSTSIncludesSubDomains()
+		value.HSTSPreload()
+		value.ContentSecurityPolicy().chain.assertFailed(t)
+		value.ContentTypeOptions().chain.assertFailed(t)
+		value.FrameOptions().chain.assertFailed(t)
+		value.ReferrerPolicy().chain.assertFailed(t)
+		value.PermissionsPolicy().chain.assertFailed(t)
+	}
+
+	t.Run("failed chain", func(t *testing.T) {
+		chain := newMockChain(t)
+		chain.setFailed()
+
+		value := newSecurityHeaders(chain, http.Header{})
+
+		check(value)
+	})
+
+	t.Run("nil value", func(t *testing.T) {
+		chain := newMockChain(t)
+
+		value := newSecurityHeaders(chain, nil)
+
+		check(value)
+	})
+}
+
+func TestSecurityHeaders_Alias(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	parent := newChainWithDefaults("SecurityHeaders()", reporter)
+	value := newSecurityHeaders(parent, http.Header{})
+	assert.Equal(t, []string{"SecurityHeaders()"}, value.chain.context.Path)
+	assert.Equal(t, []string{"SecurityHeaders()"}, value.chain.context.AliasedPath)
+
+	value.Alias("foo")
+	assert.Equal(t, []string{"SecurityHeaders()"}, value.chain.context.Path)
+	assert.Equal(t, []string{"foo"}, value.chain.context.AliasedPath)
+
+	childValue := value.FrameOptions()
+	assert.Equal(t, []string{"SecurityHeaders()", "FrameOptions()"},
+		childValue.chain.context.Path)
+	assert.Equal(t, []string{"foo", "FrameOptions()"},
+		childValue.chain.context.AliasedPath)
+}
+
+func secureHeaders() http.Header {
+	return http.Header{
+		"Strict-Transport-Security": {"max-age=31536000; includeSubDomains; preload"},
+		"Content-Security-Policy": {
+			"default-src 'self'; img-src 'self' https://cdn.example.com; " +
+				"frame-ancestors 'none'",
+		},
+		"X-Content-Type-Options": {"nosniff"},
+		"X-Frame-Options":        {"DENY"},
+		"Referrer-Policy":        {"no-referrer, strict-origin-when-cross-origin"},
+		"Permissions-Policy": {
+			`camera=(), geolocation=(self "https://maps.example.com"), fullscreen=*`,
+		},
+	}
+}
+
+func TestSecurityHeaders_Getters(t *testing.T) {
+	value := newSecurityHeaders(newMockChain(t), secureHeaders())
+
+	value.HSTSMaxAge().IsEqual(365 * 24 * time.Hour)
+	value.HSTSIncludesSubDomains()
+	value.HSTSPreload()
+
+	value.ContentSecurityPolicy().IsEqual(map[string]interface{}{
+		"default-src":     []interface{}{"'self'"},
+		"img-src":         []interface{}{"'self'", "https://cdn.example.com"},
+		"frame-ancestors": []interface{}{"'none'"},
+	})
+
+	value.ContentTypeOptions().IsEqual("nosniff")
+	value.FrameOptions().IsEqual("DENY")
+	value.ReferrerPolicy().IsEqual("strict-origin-when-cross-origin")
+
+	value.PermissionsPolicy().IsEqual(map[string]interface{}{
+		"camera":      []interface{}{},
+		"geolocation": []interface{}{"self", "https://maps.example.com"},
+		"fullscreen":  []interface{}{"*"},
+	})
+
+	value.chain.assertNotFailed(t)
+}
+
+func TestSecurityHeaders_HSTS(t *testing.T) {
+	cases := []struct {
+		name       string
+		header     string
+		maxAge     time.Duration
+		subDomains bool
+		preload    bool
+		invalid    bool
+	}{
+		{
+			name:   "max-age only",
+			header: "max-age=600",
+			maxAge: 10 * time.Minute,
+		},
+		{
+			name:       "case and quotes",
+			header:     `Max-Age="600" ; INCLUDESUBDOMAINS`,
+			maxAge:     10 * time.Minute,
+			subDomains: true,
+		},
+		{
+			name:    "missing max-age",
+			header:  "includeSubDomains",
+			invalid: true,
+		},
+		{
+			name:    "invalid max-age",
+			header:  "max-age=-1",
+			invalid: true,
+		},
+		{
+			name:    "duplicate directive",
+			header:  "max-age=1; max-age=2",
+			invalid: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			header := http.Header{
+				"Strict-Transport-Security": {tc.header},
+			}
+
+			value := newSecurityHeaders(newMockChain(t), header)
+			maxAge := value.HSTSMaxAge()
+			if tc.invalid {
+				value.chain.assertFailed(t)
+				return
+			}
+			value.chain.assertNotFailed(t)
+			assert.Equal(t, tc.maxAge, maxAge.Raw())
+
+			value = newSecurityHeaders(newMockChain(t), header)
+			value.HSTSIncludesSubDomains()
+			if tc.subDomains {
+				value.chain.assertNotFailed(t)
+			} else {
+				value.chain.assertFailed(t)
+			}
+
+			value = newSecurityHeaders(newMockChain(t), header)
+			value.HSTSPreload()
+			if tc.preload {
+				value.chain.assertNotFailed(t)
+			} else {
+				value.chain.assertFailed(t)
+			}
+		})
+	}
+}
+
+func TestSecurityHeaders_Missing(t *testing.T) {
+	cases := []struct {
+		name string
+		fn   func(*SecurityHeaders)
+	}{
+		{"HSTSMaxAge", func(s *SecurityHeaders) { s.HSTSMaxAge() }},
+		{"HSTSIncludesSubDomains", func(s *SecurityHeaders) { s.HSTSIncludesSubDomains() }},
+		{"HSTSPreload", func(s *SecurityHeaders) { s.HSTSPreload() }},
+		{"ContentSecurityPolicy", func(s *SecurityHeaders) { s.ContentSecurityPolicy() }},
+		{"ContentTypeOptions", func(s *SecurityHeaders) { s.ContentTypeOptions() }},
+		{"FrameOptions", func(s *SecurityHeaders) { s.FrameOptions() }},
+		{"ReferrerPolicy", func(s *SecurityHeaders) { s.ReferrerPolicy() }},
+		{"PermissionsPolicy", func(s *SecurityHeaders) { s.PermissionsPolicy() }},
+		{"Baseline", func(s *SecurityHeaders) { s.Baseline() }},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			value := newSecurityHeaders(newMockChain(t), http.Header{})
+			tc.fn(value)
+			value.chain.assertFailed(t)
+		})
+	}
+}
+
+func TestSecurityHeaders_PermissionsPolicy(t *testing.T) {
+	t.Run("params and multiple headers", func(t *testing.T) {
+		value := newSecurityHeaders(newMockChain(t), http.Header{
+			"Permissions-Policy": {
+				`camera=();report-to="main, backup"`,
+				`usb=self`,
+			},
+		})
+
+		value.PermissionsPolicy().IsEqual(map[string]interface{}{
+			"camera": []interface{}{},
+			"usb":    []interface{}{"self"},
+		})
+		value.chain.assertNotFailed(t)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		for _, header := range []string{
+			`camera`,
+			`camera=(self`,
+			`camera=("unterminated)`,
+			`=()`,
+		} {
+			value := newSecurityHeaders(newMockChain(t), http.Header{
+				"Permissions-Policy": {header},
+			})
+
+			value.PermissionsPolicy()
+			value.chain.assertFailed(t)
+		}
+	})
+}
+
+func TestSecurityHeaders_Baseline(t *testing.T) {
+	t.Run("secure", func(t *testing.T) {
+		value := newSecurityHeaders(newMockChain(t), secureHeaders())
+		value.Baseline()
+		value.chain.assertNotFailed(t)
+	})
+
+	t.Run("frame-ancestors instead of X-Frame-Options", func(t *testing.T) {
+		header := secureHeaders()
+		header.Del("X-Frame-Options")
+
+		value := newSecurityHeaders(newMockChain(t), header)
+		value.Baseline()
+		value.chain.assertNotFailed(t)
+	})
+
+	cases := []struct {
+		name   string
+		modify func(http.Header)
+	}{
+		{
+			name: "no HSTS",
+			modify: func(h http.Header) {
+				h.Del("Strict-Transport-Security")
+			},
+		},
+		{
+			name: "zero HSTS max-age",
+			modify: func(h http.Header) {
+				h.Set("Strict-Transport-Security", "max-age=0")
+			},
+		},
+		{
+			name: "no CSP",
+			modify: func(h http.Header) {
+				h.Del("Content-Security-Policy")
+			},
+		},
+		{
+			name: "sniffing allowed",
+			modify: func(h http.Header) {
+				h.Set("X-Content-Type-Options", "sniff")
+			},
+		},
+		{
+			name: "framing allowed",
+			modify: func(h http.Header) {
+				h.Set("Content-Security-Policy", "default-src 'self'")
+				h.Set("X-Frame-Options", "ALLOW-FROM https://example.com")
+			},
+		},
+		{
+			name: "unknown referrer policy",
+			modify: func(h http.Header) {
+				h.Set("Referrer-Policy", "whatever")
+			},
+		},
+		{
+			name: "no permissions policy",
+			modify: func(h http.Header) {
+				h.Del("Permissions-Policy")
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			header := secureHeaders()
+			tc.modify(header)
+
+			reporter := newMockReporter(t)
+			value := newSecurityHeaders(newChainWithDefaults("test", reporter), header)
+			value.Baseline()
+			value.chain.assertFailed(t)
+			assert.True(t, reporter.reported)
+		})
+	}
+
+	t.Run("all missing reported at once", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+		chain := newChainWithConfig("test", Config{
+			AssertionHandler: handler,
+		}.withDefaults())
+
+		newSecurityHeaders(chain, http.Header{}).Baseline()
+
+		if assert.NotNil(t, handler.failure) {
+			assert.Equal(t, 7, len(handler.failure.Errors))
+		}
+	})
+}
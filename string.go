@@ -91,6 +91,26 @@ func (s *String) Alias(name string) *String {
 	return s
 }
 
+// Chain returns public handle to assertion chain of String, which may be
+// used to implement custom matchers. See Chain for details.
+func (s *String) Chain() *Chain {
+	return &Chain{chain: s.chain}
+}
+
+// AsWarning is similar to Value.AsWarning.
+func (s *String) AsWarning() *String {
+	opChain := s.chain.enter("AsWarning()")
+	defer opChain.leave()
+
+	warnChain := s.chain.clone()
+	warnChain.setWarning()
+
+	return &String{
+		chain: warnChain,
+		value: s.value,
+	}
+}
+
 // Path is similar to Value.Path.
 func (s *String) Path(path string) *Value {
 	opChain := s.chain.enter("Path(%q)", path)
@@ -1320,6 +1340,50 @@ func (s *String) AsDateTime(format ...string) *DateTime {
 	return newDateTime(opChain, tm)
 }
 
+// AsJWT decodes JSON Web Token from string and returns a new JWT instance
+// with result.
+//
+// If the string is not a well-formed JWT in compact form, AsJWT reports
+// failure and returns empty (but non-nil) instance.
+//
+// Example:
+//
+//	str := NewString(t, token)
+//	str.AsJWT().Claims().Value("sub").IsEqual("john")
+//	str.AsJWT().ExpiresAt().Gt(time.Now())
+//	str.AsJWT().Verify([]byte("secret"))
+func (s *String) AsJWT() *JWT {
+	opChain := s.chain.enter("AsJWT()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newJWT(opChain, "")
+	}
+
+	return newJWT(opChain, s.value)
+}
+
+// AsURL parses URL from string and returns a new URL instance with result.
+//
+// If the string can't be parsed as URL, AsURL reports failure and returns
+// empty (but non-nil) instance.
+//
+// Example:
+//
+//	str := NewString(t, "https://example.com/users?page=2")
+//	str.AsURL().Host().IsEqual("example.com")
+//	str.AsURL().Path().IsEqual("/users")
+func (s *String) AsURL() *URL {
+	opChain := s.chain.enter("AsURL()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newURL(opChain, "")
+	}
+
+	return newURL(opChain, s.value)
+}
+
 type datetimeFormat struct {
 	layout string
 	name   string
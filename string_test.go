@@ -57,6 +57,8 @@ func TestString_FailedChain(t *testing.T) {
 	value.AsBoolean().chain.assertFailed(t)
 	value.AsNumber().chain.assertFailed(t)
 	value.AsDateTime().chain.assertFailed(t)
+	value.AsJWT().chain.assertFailed(t)
+	value.AsURL().chain.assertFailed(t)
 }
 
 func TestString_Constructors(t *testing.T) {
@@ -895,3 +897,46 @@ func TestString_AsDateTime(t *testing.T) {
 		}
 	}
 }
+
+func TestString_AsJWT(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		value := NewString(reporter,
+			"eyJhbGciOiJIUzI1NiJ9.eyJzdWIiOiJqb2huIn0.c2ln")
+
+		jwt := value.AsJWT()
+		jwt.chain.assertNotFailed(t)
+
+		assert.Equal(t, []string{"String()", "AsJWT()"}, jwt.chain.context.Path)
+		assert.Equal(t, map[string]interface{}{"sub": "john"}, jwt.claims)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		value := NewString(reporter, "not a token")
+
+		jwt := value.AsJWT()
+		jwt.chain.assertFailed(t)
+	})
+}
+
+func TestString_AsURL(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		value := NewString(reporter, "http://example.com/path")
+
+		u := value.AsURL()
+		u.chain.assertNotFailed(t)
+
+		assert.Equal(t, []string{"String()", "AsURL()"}, u.chain.context.Path)
+		assert.Equal(t, "/path", u.Raw().Path)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		reporter := newMockReporter(t)
+		value := NewString(reporter, "http://[::1")
+
+		u := value.AsURL()
+		u.chain.assertFailed(t)
+	})
+}
@@ -1,8 +1,8 @@
 package httpexpect
 
 import (
+	"encoding/json"
 	"errors"
-	"reflect"
 )
 
 // Value provides methods to inspect attached interface{} object
@@ -110,6 +110,43 @@ func (v *Value) Decode(target interface{}) *Value {
 	return v
 }
 
+// DecodeStrict is similar to Decode, but additionally fails if value
+// contains fields not present in target struct, or if fields marked as
+// required in target struct are absent. Each offending field is reported
+// with its path.
+//
+// Field is marked as required using "required" option of json tag or
+// "required" rule of validate tag, in the same way as for MatchesType.
+// Nested structs, slices, arrays, and maps are checked recursively.
+// Types implementing json.Unmarshaler are not inspected.
+//
+// Example:
+//
+//	type User struct {
+//		ID   int    `json:"id,required"`
+//		Name string `json:"name"`
+//	}
+//
+//	value := NewValue(t, map[string]interface{}{
+//		"id":    123,
+//		"name":  "John",
+//		"email": "john@example.com",
+//	})
+//
+//	var target User
+//	value.DecodeStrict(&target) // fails, "email" is unknown
+func (v *Value) DecodeStrict(target interface{}) *Value {
+	opChain := v.chain.enter("DecodeStrict()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return v
+	}
+
+	canonDecodeStrict(opChain, v.value, target)
+	return v
+}
+
 // Alias returns a new Value object with alias.
 // When a test of Value object with alias is failed,
 // an assertion is displayed as a chain starting from the alias.
@@ -139,6 +176,43 @@ func (v *Value) Alias(name string) *Value {
 	return v
 }
 
+// Chain returns public handle to assertion chain of Value, which may be
+// used to implement custom matchers. See Chain for details.
+func (v *Value) Chain() *Chain {
+	return &Chain{chain: v.chain}
+}
+
+// AsWarning returns a copy of Value in warning mode.
+// The original Value is not affected.
+//
+// In warning mode, failures of assertions on the copy and its children
+// are reported with SeverityLog instead of SeverityError, so they are
+// logged (if Logger is configured) but don't fail the test, and don't
+// mark parent objects as failed. Such failures are also included in
+// Expect.WarningSummary.
+//
+// Example:
+//
+//	value := NewValue(t, map[string]interface{}{"version": 1})
+//
+//	// failure is logged, but test doesn't fail
+//	value.AsWarning().Object().Value("version").IsEqual(2)
+//
+//	// failure is reported as usual
+//	value.Object().Value("version").IsEqual(2)
+func (v *Value) AsWarning() *Value {
+	opChain := v.chain.enter("AsWarning()")
+	defer opChain.leave()
+
+	warnChain := v.chain.clone()
+	warnChain.setWarning()
+
+	return &Value{
+		chain: warnChain,
+		value: v.value,
+	}
+}
+
 // Path returns a new Value object for child object(s) matching given
 // JSONPath expression.
 //
@@ -149,6 +223,8 @@ func (v *Value) Alias(name string) *Value {
 // only a subset of JSONPath, yet useful for simple queries. It doesn't
 // support filters and requires double quotes for strings.
 //
+// For standard RFC 9535 queries, including filters, use PathAll.
+//
 // Example 1:
 //
 //	json := `{"users": [{"name": "john"}, {"name": "bob"}]}`
@@ -172,17 +248,86 @@ func (v *Value) Path(path string) *Value {
 	return jsonPath(opChain, v.value, path)
 }
 
+// PathAll returns a new Array object with all nodes matching given
+// JSONPath expression, as defined by RFC 9535.
+//
+// Unlike Path, PathAll always returns a list of nodes, which is empty
+// if nothing matched. Query supports filter selectors with comparisons,
+// logical operators, and length(), count(), match(), search(), and
+// value() functions. Members of objects are visited in sorted order.
+//
+// Assertions on returned elements report normalized path of the element
+// (e.g. $['users'][1]['name']) instead of its index in the list.
+//
+// Example:
+//
+//	value := NewValue(t, map[string]interface{}{
+//	    "users": []interface{}{
+//	        map[string]interface{}{"name": "john", "age": 30},
+//	        map[string]interface{}{"name": "bob", "age": 17},
+//	    },
+//	})
+//
+//	value.PathAll("$.users[?@.age >= 18].name").IsEqual([]string{"john"})
+//	value.PathAll("$..name").Length().IsEqual(2)
+//	value.PathAll(`$.users[?match(@.name, "[a-z]+")]`).Every(
+//	    func(_ int, user *Value) {
+//	        user.Object().Value("age").Number().Gt(0)
+//	    })
+func (v *Value) PathAll(path string) *Array {
+	opChain := v.chain.enter("PathAll(%q)", path)
+	defer opChain.leave()
+
+	return jsonPathAll(opChain, v.value, path)
+}
+
+// Query returns a new Value object with result of given JMESPath
+// expression.
+//
+// JMESPath is a query language for JSON, which supports projections,
+// filters, pipes, multi-select, and functions. See https://jmespath.org/.
+// We use https://github.com/jmespath/go-jmespath implementation.
+//
+// If expression doesn't match anything, returned Value contains null.
+//
+// Example:
+//
+//	value := NewValue(t, map[string]interface{}{
+//	    "items": []interface{}{
+//	        map[string]interface{}{"id": 3, "status": "active"},
+//	        map[string]interface{}{"id": 1, "status": "active"},
+//	        map[string]interface{}{"id": 2, "status": "deleted"},
+//	    },
+//	})
+//
+//	value.Query("items[?status=='active'].id | sort(@)").
+//	    IsEqual([]int{1, 3})
+//	value.Query("length(items)").Number().IsEqual(3)
+//	value.Query("items[0].{key: id, value: status}").Object().
+//	    IsEqual(map[string]interface{}{"key": 3, "value": "active"})
+func (v *Value) Query(expr string) *Value {
+	opChain := v.chain.enter("Query(%q)", expr)
+	defer opChain.leave()
+
+	return jsonQuery(opChain, v.value, expr)
+}
+
 // Schema succeeds if value matches given JSON Schema.
 //
 // JSON Schema specifies a JSON-based format to define the structure of
 // JSON data. See http://json-schema.org/.
-// We use https://github.com/xeipuuv/gojsonschema implementation.
+//
+// Schemas with "$schema" set to draft 2019-09 or 2020-12 are validated
+// by built-in validator, which reports every failing keyword with its
+// location. Older drafts are handled by
+// https://github.com/xeipuuv/gojsonschema implementation.
 //
 // schema should be one of the following:
 //   - go value that can be json.Marshal-ed to a valid schema
 //   - type convertible to string containing valid schema
 //   - type convertible to string containing valid http:// or file:// URI,
 //     pointing to reachable and valid schema
+//   - *SchemaRef returned by SchemaRegistry.Ref
 //
 // Example 1:
 //
@@ -218,6 +363,49 @@ func (v *Value) Schema(schema interface{}) *Value {
 	return v
 }
 
+// MatchesType succeeds if value matches JSON Schema generated from
+// the type of given example value.
+//
+// Schema is generated in the same way as encoding/json would encode
+// the type, so it's enough to pass the struct used by the handler that
+// produced the response. Every mismatch is reported with its location.
+//
+// Generated schema follows these rules:
+//   - fields are named according to "json" tags, embedded structs are
+//     flattened, and unknown fields are not allowed
+//   - fields without "omitempty" option are required, as well as fields
+//     with "required" option in "json" tag or "required" rule in
+//     "validate" tag
+//   - pointers, slices, and maps are nullable, unless field has
+//     "required" rule in "validate" tag, which also disallows empty
+//     strings
+//   - sized integers are checked for range, time.Time is checked to
+//     be RFC 3339 string, and types with custom MarshalJSON match any value
+//
+// Example:
+//
+//	type User struct {
+//	    ID    int64    `json:"id"`
+//	    Name  string   `json:"name" validate:"required"`
+//	    Email *string  `json:"email,omitempty"`
+//	    Tags  []string `json:"tags"`
+//	}
+//
+//	value := NewValue(t, map[string]interface{}{
+//	    "id":   123,
+//	    "name": "John",
+//	    "tags": []string{"admin"},
+//	})
+//
+//	value.MatchesType(User{})
+func (v *Value) MatchesType(example interface{}) *Value {
+	opChain := v.chain.enter("MatchesType()")
+	defer opChain.leave()
+
+	jsonMatchesType(opChain, v.value, example)
+	return v
+}
+
 // Object returns a new Object attached to underlying value.
 //
 // If underlying value is not an object (map[string]interface{}), failure is reported
@@ -334,20 +522,22 @@ func (v *Value) Number() *Number {
 		return newNumber(opChain, 0)
 	}
 
-	data, ok := v.value.(float64)
+	switch data := v.value.(type) {
+	case float64:
+		return newNumber(opChain, data)
 
-	if !ok {
-		opChain.fail(AssertionFailure{
-			Type:   AssertValid,
-			Actual: &AssertionValue{v.value},
-			Errors: []error{
-				errors.New("expected: value is number"),
-			},
-		})
-		return newNumber(opChain, 0)
+	case json.Number:
+		return newNumberExact(opChain, data)
 	}
 
-	return newNumber(opChain, data)
+	opChain.fail(AssertionFailure{
+		Type:   AssertValid,
+		Actual: &AssertionValue{v.value},
+		Errors: []error{
+			errors.New("expected: value is number"),
+		},
+	})
+	return newNumber(opChain, 0)
 }
 
 // Boolean returns a new Boolean attached to underlying value.
@@ -647,7 +837,7 @@ func (v *Value) IsNumber() *Value {
 		return v
 	}
 
-	if _, ok := v.value.(float64); !ok {
+	if !isNumber(v.value) {
 		opChain.fail(AssertionFailure{
 			Type:   AssertValid,
 			Actual: &AssertionValue{v.value},
@@ -677,7 +867,7 @@ func (v *Value) NotNumber() *Value {
 		return v
 	}
 
-	if _, ok := v.value.(float64); ok {
+	if isNumber(v.value) {
 		opChain.fail(AssertionFailure{
 			Type:   AssertValid,
 			Actual: &AssertionValue{v.value},
@@ -748,6 +938,56 @@ func (v *Value) NotBoolean() *Value {
 	return v
 }
 
+// PatchTo returns a new Array instance with JSON Patch (RFC 6902) that
+// transforms this value into another value.
+//
+// Another value may be a *Value instance or any value that can be converted
+// to canonical form, like map, slice, string, etc. If another value is
+// a *Value instance which has already failed, failure is reported.
+//
+// Each element of returned array is an object with "op", "path" and,
+// for "add" and "replace" operations, "value" members. If values are
+// equal, returned array is empty.
+//
+// Example:
+//
+//	before := e.GET("/users/1").Expect().JSON()
+//	e.PATCH("/users/1").WithMergePatch(map[string]interface{}{"name": "Jane"}).
+//		Expect().Status(http.StatusOK)
+//	after := e.GET("/users/1").Expect().JSON()
+//
+//	before.PatchTo(after).IsEqual([]interface{}{
+//		map[string]interface{}{"op": "replace", "path": "/name", "value": "Jane"},
+//	})
+func (v *Value) PatchTo(value interface{}) *Array {
+	opChain := v.chain.enter("PatchTo()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return newArray(opChain, nil)
+	}
+
+	if other, ok := value.(*Value); ok {
+		if other.chain.failed() {
+			opChain.fail(AssertionFailure{
+				Type: AssertUsage,
+				Errors: []error{
+					errors.New("unexpected *Value argument with failed chain"),
+				},
+			})
+			return newArray(opChain, nil)
+		}
+		value = other.Raw()
+	}
+
+	target, ok := canonValue(opChain, value)
+	if !ok {
+		return newArray(opChain, nil)
+	}
+
+	return newArray(opChain, diffJSONPatch(v.value, target))
+}
+
 // IsEqual succeeds if value is equal to another value (e.g. map, slice, string, etc).
 // Before comparison, both values are converted to canonical form.
 //
@@ -768,13 +1008,98 @@ func (v *Value) IsEqual(value interface{}) *Value {
 		return v
 	}
 
-	if !reflect.DeepEqual(expected, v.value) {
+	if equal, diffs := equalValues(v.value, expected); !equal {
 		opChain.fail(AssertionFailure{
 			Type:     AssertEqual,
 			Actual:   &AssertionValue{v.value},
 			Expected: &AssertionValue{expected},
-			Errors: []error{
+			Errors: append([]error{
 				errors.New("expected: values are equal"),
+			}, diffs...),
+		})
+	}
+
+	return v
+}
+
+// IsEqualWith succeeds if value is equal to given value, taking into
+// account given options. Before comparison, both values are converted to
+// canonical form.
+//
+// See EqualOption for supported options and path syntax.
+//
+// Example:
+//
+//	value := NewValue(t, map[string]interface{}{"id": 1, "price": 9.999})
+//	value.IsEqualWith(map[string]interface{}{"id": 2, "price": 10},
+//		IgnorePaths("$..id"), Tolerance("$..price", 0.01))
+func (v *Value) IsEqualWith(value interface{}, options ...EqualOption) *Value {
+	opChain := v.chain.enter("IsEqualWith()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return v
+	}
+
+	opts, ok := buildEqualOptions(opChain, options)
+	if !ok {
+		return v
+	}
+
+	expected, ok := canonValue(opChain, value)
+	if !ok {
+		return v
+	}
+
+	if diffs := compareWithOptions(opts, v.value, expected); len(diffs) != 0 {
+		opChain.fail(AssertionFailure{
+			Type:     AssertEqual,
+			Actual:   &AssertionValue{v.value},
+			Expected: &AssertionValue{expected},
+			Errors: append([]error{
+				errors.New("expected: values are equal with given options"),
+			}, diffs...),
+		})
+	}
+
+	return v
+}
+
+// NotEqualWith succeeds if value is not equal to given value, taking into
+// account given options. Before comparison, both values are converted to
+// canonical form.
+//
+// See EqualOption for supported options and path syntax.
+//
+// Example:
+//
+//	value := NewValue(t, map[string]interface{}{"id": 1, "price": 9.999})
+//	value.NotEqualWith(map[string]interface{}{"id": 2, "price": 10}, IgnorePaths("$..id"))
+func (v *Value) NotEqualWith(value interface{}, options ...EqualOption) *Value {
+	opChain := v.chain.enter("NotEqualWith()")
+	defer opChain.leave()
+
+	if opChain.failed() {
+		return v
+	}
+
+	opts, ok := buildEqualOptions(opChain, options)
+	if !ok {
+		return v
+	}
+
+	expected, ok := canonValue(opChain, value)
+	if !ok {
+		return v
+	}
+
+	if diffs := compareWithOptions(opts, v.value, expected); len(diffs) == 0 {
+		opChain.fail(AssertionFailure{
+			Type:     AssertNotEqual,
+			Actual:   &AssertionValue{v.value},
+			Expected: &AssertionValue{expected},
+			Errors: []error{
+				errors.New("expected: values are non-equal with given options"),
 			},
 		})
 	}
@@ -802,7 +1127,7 @@ func (v *Value) NotEqual(value interface{}) *Value {
 		return v
 	}
 
-	if reflect.DeepEqual(expected, v.value) {
+	if equal, _ := equalValues(v.value, expected); equal {
 		opChain.fail(AssertionFailure{
 			Type:     AssertNotEqual,
 			Actual:   &AssertionValue{v.value},
@@ -856,7 +1181,7 @@ func (v *Value) InList(values ...interface{}) *Value {
 			return v
 		}
 
-		if reflect.DeepEqual(expected, v.value) {
+		if canonEqual(v.value, expected) {
 			isListed = true
 			// continue loop to check that all values are correct
 		}
@@ -910,7 +1235,7 @@ func (v *Value) NotInList(values ...interface{}) *Value {
 			return v
 		}
 
-		if reflect.DeepEqual(expected, v.value) {
+		if canonEqual(v.value, expected) {
 			opChain.fail(AssertionFailure{
 				Type:     AssertNotBelongs,
 				Actual:   &AssertionValue{v.value},
@@ -18,11 +18,16 @@ func TestValue_FailedChain(t *testing.T) {
 	value.chain.assertFailed(t)
 
 	value.Path("$").chain.assertFailed(t)
+	value.PathAll("$").chain.assertFailed(t)
+	value.Query("@").chain.assertFailed(t)
 	value.Schema("")
+	value.MatchesType(struct{}{})
+	value.PatchTo(nil).chain.assertFailed(t)
 	value.Alias("foo")
 
 	var target interface{}
 	value.Decode(target)
+	value.DecodeStrict(target)
 
 	value.Object().chain.assertFailed(t)
 	value.Array().chain.assertFailed(t)
@@ -44,6 +49,8 @@ func TestValue_FailedChain(t *testing.T) {
 	value.NotBoolean()
 	value.IsEqual(nil)
 	value.NotEqual(nil)
+	value.IsEqualWith(nil)
+	value.NotEqualWith(nil)
 	value.InList(nil)
 	value.NotInList(nil)
 }
@@ -139,6 +146,107 @@ func TestValue_Decode(t *testing.T) {
 	})
 }
 
+func TestValue_DecodeStrict(t *testing.T) {
+	type S struct {
+		Foo int    `json:"foo,required"`
+		Bar string `json:"bar"`
+	}
+
+	t.Run("exact fields", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		value := NewValue(reporter, map[string]interface{}{"foo": 123, "bar": "x"})
+
+		var target S
+		value.DecodeStrict(&target)
+
+		value.chain.assertNotFailed(t)
+		assert.Equal(t, S{123, "x"}, target)
+	})
+
+	t.Run("optional field absent", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		value := NewValue(reporter, map[string]interface{}{"foo": 123})
+
+		var target S
+		value.DecodeStrict(&target)
+
+		value.chain.assertNotFailed(t)
+		assert.Equal(t, S{Foo: 123}, target)
+	})
+
+	t.Run("unknown field", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		value := NewValue(reporter,
+			map[string]interface{}{"foo": 123, "bar": "x", "baz": true})
+
+		var target S
+		value.DecodeStrict(&target)
+
+		value.chain.assertFailed(t)
+	})
+
+	t.Run("required field absent", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		value := NewValue(reporter, map[string]interface{}{"bar": "x"})
+
+		var target S
+		value.DecodeStrict(&target)
+
+		value.chain.assertFailed(t)
+	})
+
+	t.Run("target is nil", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		value := NewValue(reporter, 123)
+
+		value.DecodeStrict(nil)
+
+		value.chain.assertFailed(t)
+	})
+}
+
+func TestValue_ExactNumber(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	value := NewValue(reporter, json.Number("12345678901234567890"))
+
+	assert.Equal(t, json.Number("12345678901234567890"), value.Raw())
+
+	value.IsNumber()
+	value.chain.assertNotFailed(t)
+
+	value.NotNumber()
+	value.chain.assertFailed(t)
+	value.chain.clearFailed()
+
+	value.IsEqual(uint64(12345678901234567890))
+	value.chain.assertNotFailed(t)
+
+	value.IsEqual(uint64(12345678901234567891))
+	value.chain.assertFailed(t)
+	value.chain.clearFailed()
+
+	value.IsEqual(12345678901234567890.0)
+	value.chain.assertFailed(t)
+	value.chain.clearFailed()
+
+	value.IsEqualWith(12345678901234567000.0, Tolerance("$", 1000))
+	value.chain.assertNotFailed(t)
+
+	number := value.Number()
+	number.chain.assertNotFailed(t)
+
+	assert.Equal(t, 12345678901234567890.0, number.Raw())
+
+	number.IsEqualExact("12345678901234567890")
+	number.chain.assertNotFailed(t)
+}
+
 func TestValue_Alias(t *testing.T) {
 	reporter := newMockReporter(t)
 
@@ -155,6 +263,56 @@ func TestValue_Alias(t *testing.T) {
 	assert.Equal(t, []string{"foo", "Number()"}, childValue.chain.context.AliasedPath)
 }
 
+func TestValue_AsWarning(t *testing.T) {
+	t.Run("warning", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+
+		parent := newChainWithConfig("test", Config{
+			AssertionHandler: handler,
+		}.withDefaults())
+
+		value := newValue(parent, 123)
+		warnValue := value.AsWarning()
+		assert.NotSame(t, value, warnValue)
+
+		childValue := warnValue.Number()
+		childValue.IsEqual(456)
+
+		require.NotNil(t, handler.failure)
+		assert.Equal(t, SeverityLog, handler.failure.Severity)
+		assert.False(t, handler.failure.IsFatal)
+
+		childValue.chain.assertFailed(t)
+		warnValue.chain.assertNotFailed(t)
+		value.chain.assertNotFailed(t)
+		parent.assertNotFailed(t)
+		assert.False(t, parent.treeFailed())
+
+		assert.Equal(t, 1, len(parent.warningMessages()))
+	})
+
+	t.Run("original", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+
+		parent := newChainWithConfig("test", Config{
+			AssertionHandler: handler,
+		}.withDefaults())
+
+		value := newValue(parent, 123)
+		value.AsWarning()
+
+		value.Number().IsEqual(456)
+
+		require.NotNil(t, handler.failure)
+		assert.Equal(t, SeverityError, handler.failure.Severity)
+
+		assert.True(t, value.chain.treeFailed())
+		assert.True(t, parent.treeFailed())
+
+		assert.Equal(t, 0, len(parent.warningMessages()))
+	})
+}
+
 func TestValue_Getters(t *testing.T) {
 	reporter := newMockReporter(t)
 
@@ -584,6 +742,110 @@ func TestValue_IsBoolean(t *testing.T) {
 	}
 }
 
+func TestValue_PatchTo(t *testing.T) {
+	t.Run("objects", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		value := NewValue(reporter, map[string]interface{}{
+			"name":     "John",
+			"nickname": "johnny",
+			"age":      30,
+		})
+
+		value.PatchTo(map[string]interface{}{
+			"name": "Jane",
+			"age":  30,
+			"tags": []string{"admin"},
+		}).IsEqual([]interface{}{
+			map[string]interface{}{"op": "remove", "path": "/nickname"},
+			map[string]interface{}{"op": "replace", "path": "/name", "value": "Jane"},
+			map[string]interface{}{"op": "add", "path": "/tags",
+				"value": []interface{}{"admin"}},
+		})
+
+		value.chain.assertNotFailed(t)
+	})
+
+	t.Run("value argument", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		before := NewValue(reporter, []interface{}{1, 2})
+		after := NewValue(reporter, []interface{}{1, 2, 3})
+
+		before.PatchTo(after).IsEqual([]interface{}{
+			map[string]interface{}{"op": "add", "path": "/2", "value": 3},
+		})
+
+		before.PatchTo(before).IsEmpty()
+
+		before.chain.assertNotFailed(t)
+	})
+
+	t.Run("invalid argument", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		value := NewValue(reporter, map[string]interface{}{})
+
+		value.PatchTo(func() {}).chain.assertFailed(t)
+		value.chain.assertFailed(t)
+	})
+	t.Run("failed value argument", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		value := NewValue(reporter, []interface{}{1, 2})
+
+		other := NewValue(reporter, []interface{}{1, 2, 3})
+		other.chain.setFailed()
+
+		value.PatchTo(other).chain.assertFailed(t)
+		value.chain.assertFailed(t)
+	})
+}
+
+func TestValue_IsEqualWith(t *testing.T) {
+	reporter := newMockReporter(t)
+
+	value := NewValue(reporter, map[string]interface{}{
+		"data": map[string]interface{}{
+			"id":        "b8e1",
+			"createdAt": "2024-01-01T00:00:00Z",
+			"score":     0.333,
+		},
+	})
+
+	expected := map[string]interface{}{
+		"data": map[string]interface{}{
+			"score": 1.0 / 3,
+		},
+	}
+
+	value.IsEqualWith(expected,
+		IgnorePaths("$.data.id", "$..createdAt"), Tolerance("$..score", 0.001))
+	value.chain.assertNotFailed(t)
+	value.chain.clearFailed()
+
+	value.NotEqualWith(expected,
+		IgnorePaths("$.data.id", "$..createdAt"), Tolerance("$..score", 0.001))
+	value.chain.assertFailed(t)
+	value.chain.clearFailed()
+
+	value.IsEqualWith(expected, IgnorePaths("$.data.id", "$..createdAt"))
+	value.chain.assertFailed(t)
+	value.chain.clearFailed()
+
+	value.NotEqualWith(expected, IgnorePaths("$.data.id", "$..createdAt"))
+	value.chain.assertNotFailed(t)
+	value.chain.clearFailed()
+
+	value.IsEqualWith(expected, Tolerance("$..score", -1))
+	value.chain.assertFailed(t)
+	value.chain.clearFailed()
+
+	value.IsEqualWith(func() {})
+	value.chain.assertFailed(t)
+	value.chain.clearFailed()
+}
+
 func TestValue_IsEqual(t *testing.T) {
 	reporter := newMockReporter(t)
 
@@ -954,6 +1216,165 @@ func TestValue_PathExpressions(t *testing.T) {
 	})
 }
 
+func TestValue_PathAll(t *testing.T) {
+	data := map[string]interface{}{
+		"users": []interface{}{
+			map[string]interface{}{"name": "john", "age": 30},
+			map[string]interface{}{"name": "bob", "age": 17},
+			map[string]interface{}{"name": "alice", "age": 25},
+		},
+	}
+
+	t.Run("match", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		value := NewValue(reporter, data)
+
+		value.PathAll("$.users[*].name").
+			IsEqual([]interface{}{"john", "bob", "alice"}).
+			chain.assertNotFailed(t)
+
+		value.PathAll("$.users[?@.age >= 18].name").
+			IsEqual([]interface{}{"john", "alice"}).
+			chain.assertNotFailed(t)
+
+		value.PathAll("$.users[0].name").
+			IsEqual([]interface{}{"john"}).
+			chain.assertNotFailed(t)
+
+		value.PathAll("$.users[?length(@.name) > 10]").
+			IsEmpty().
+			chain.assertNotFailed(t)
+
+		value.PathAll("$.missing").
+			IsEmpty().
+			chain.assertNotFailed(t)
+
+		value.chain.assertNotFailed(t)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		for _, path := range []string{"", "$[", "$[?@.a]]", "$.users[?length(@)]"} {
+			value := NewValue(reporter, data)
+
+			arr := value.PathAll(path)
+			assert.NotNil(t, arr)
+			assert.Nil(t, arr.Raw())
+
+			value.chain.assertFailed(t)
+		}
+	})
+
+	t.Run("normalized paths", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+		chain := newChainWithConfig("test", Config{
+			AssertionHandler: handler,
+		}.withDefaults())
+
+		newValue(chain, data).PathAll("$.users[?@.age < 26].age").
+			Every(func(_ int, value *Value) {
+				value.Number().Gt(18)
+			})
+
+		require.NotNil(t, handler.failure)
+		assert.Equal(t, []string{
+			"test",
+			`PathAll("$.users[?@.age < 26].age")`,
+			"Every[$['users'][1]['age']]",
+			"Number()",
+			"Gt()",
+		}, handler.ctx.Path)
+
+		handler = &mockAssertionHandler{}
+		chain = newChainWithConfig("test", Config{
+			AssertionHandler: handler,
+		}.withDefaults())
+
+		newValue(chain, data).PathAll("$..name").Value(2).String().IsEqual("bob")
+
+		require.NotNil(t, handler.failure)
+		assert.Equal(t, []string{
+			"test",
+			`PathAll("$..name")`,
+			"Value($['users'][2]['name'])",
+			"String()",
+			"IsEqual()",
+		}, handler.ctx.Path)
+	})
+}
+
+func TestValue_Query(t *testing.T) {
+	data := map[string]interface{}{
+		"items": []interface{}{
+			map[string]interface{}{"id": 3, "status": "active", "tags": []string{"a"}},
+			map[string]interface{}{"id": 1, "status": "active", "tags": []string{"b"}},
+			map[string]interface{}{"id": 2, "status": "deleted", "tags": []string{}},
+		},
+		"total": json.Number("12345678901234567890"),
+	}
+
+	t.Run("match", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		value := NewValue(reporter, data)
+
+		value.Query("items[?status=='active'].id | sort(@)").
+			IsEqual([]interface{}{1, 3}).
+			chain.assertNotFailed(t)
+
+		value.Query("length(items)").Number().
+			IsEqual(3).
+			chain.assertNotFailed(t)
+
+		value.Query("items[0].{key: id, value: status}").Object().
+			IsEqual(map[string]interface{}{"key": 3, "value": "active"}).
+			chain.assertNotFailed(t)
+
+		value.Query("items[].tags[]").Array().
+			IsEqual([]interface{}{"a", "b"}).
+			chain.assertNotFailed(t)
+
+		value.Query("min_by(items, &id).id").Number().
+			IsEqual(1).
+			chain.assertNotFailed(t)
+
+		value.Query("total > `1`").Boolean().
+			IsTrue().
+			chain.assertNotFailed(t)
+
+		value.Query("missing").IsNull().
+			chain.assertNotFailed(t)
+
+		value.chain.assertNotFailed(t)
+	})
+
+	t.Run("invalid expression", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		value := NewValue(reporter, data)
+
+		result := value.Query("items[?")
+		assert.NotNil(t, result)
+		assert.Nil(t, result.Raw())
+
+		value.chain.assertFailed(t)
+	})
+
+	t.Run("evaluation error", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+		chain := newChainWithConfig("test", Config{
+			AssertionHandler: handler,
+		}.withDefaults())
+
+		newValue(chain, data).Query("length(items[0].id)")
+
+		require.NotNil(t, handler.failure)
+		assert.Equal(t, AssertMatchPath, handler.failure.Type)
+	})
+}
+
 func TestValue_Schema(t *testing.T) {
 	reporter := newMockReporter(t)
 
@@ -1009,4 +1430,111 @@ func TestValue_Schema(t *testing.T) {
 
 	NewValue(reporter, data1).Schema("file:///bad/path").chain.assertFailed(t)
 	NewValue(reporter, data1).Schema("{ bad json").chain.assertFailed(t)
+
+	modern := `{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"$defs": {
+			"int": {"type": "integer"}
+		},
+		"properties": {
+			"foo": {"type": "string"},
+			"bar": {"$ref": "#/$defs/int"}
+		},
+		"unevaluatedProperties": false
+	}`
+
+	NewValue(reporter, data1).Schema(modern).chain.assertNotFailed(t)
+	NewValue(reporter, data2).Schema(modern).chain.assertFailed(t)
+
+	NewValue(reporter, data1).Schema([]byte(modern)).chain.assertNotFailed(t)
+	NewValue(reporter, data2).Schema([]byte(modern)).chain.assertFailed(t)
+
+	data3 := map[string]interface{}{
+		"foo": "a",
+		"baz": 1,
+	}
+
+	NewValue(reporter, data3).Schema(schema).chain.assertNotFailed(t)
+	NewValue(reporter, data3).Schema(modern).chain.assertFailed(t)
+
+	NewValue(reporter, data1).Schema(`{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"$ref": "#/$defs/missing"
+	}`).chain.assertFailed(t)
+}
+
+func TestValue_MatchesType(t *testing.T) {
+	type Address struct {
+		City string `json:"city" validate:"required"`
+	}
+
+	type User struct {
+		ID      int64             `json:"id"`
+		Name    string            `json:"name"`
+		Email   *string           `json:"email,omitempty"`
+		Address *Address          `json:"address"`
+		Tags    []string          `json:"tags"`
+		Attrs   map[string]string `json:"attrs,omitempty"`
+	}
+
+	t.Run("match", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		NewValue(reporter, map[string]interface{}{
+			"id":      1,
+			"name":    "John",
+			"email":   "john@example.com",
+			"address": map[string]interface{}{"city": "Paris"},
+			"tags":    []interface{}{"a"},
+		}).MatchesType(User{}).chain.assertNotFailed(t)
+
+		NewValue(reporter, map[string]interface{}{
+			"id":      1,
+			"name":    "John",
+			"address": nil,
+			"tags":    nil,
+		}).MatchesType(&User{}).chain.assertNotFailed(t)
+
+		NewValue(reporter, []interface{}{}).
+			MatchesType([]User{}).chain.assertNotFailed(t)
+	})
+
+	t.Run("mismatch", func(t *testing.T) {
+		handler := &mockAssertionHandler{}
+		chain := newChainWithConfig("test", Config{
+			AssertionHandler: handler,
+		}.withDefaults())
+
+		newValue(chain, map[string]interface{}{
+			"id":      1.5,
+			"address": map[string]interface{}{"city": ""},
+			"tags":    []interface{}{1},
+			"extra":   true,
+		}).MatchesType(User{})
+
+		require.NotNil(t, handler.failure)
+		assert.Equal(t, AssertMatchSchema, handler.failure.Type)
+
+		var messages []string
+		for _, err := range handler.failure.Errors[1:] {
+			messages = append(messages, err.Error())
+		}
+
+		require.Equal(t, 5, len(messages))
+		assert.Contains(t, messages[0], `at $: required: missing property "name"`)
+		assert.Contains(t, messages[1], "at $.address.city: minLength:")
+		assert.Contains(t, messages[2], "at $.id: type:")
+		assert.Contains(t, messages[3], "at $.tags[0]: type:")
+		assert.Contains(t, messages[4], "at $.extra: false:")
+	})
+
+	t.Run("invalid argument", func(t *testing.T) {
+		reporter := newMockReporter(t)
+
+		NewValue(reporter, 1).MatchesType(nil).chain.assertFailed(t)
+		NewValue(reporter, 1).MatchesType(func() {}).chain.assertFailed(t)
+		NewValue(reporter, 1).MatchesType(struct {
+			C chan int `json:"c"`
+		}{}).chain.assertFailed(t)
+	})
 }
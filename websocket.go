@@ -94,6 +94,30 @@ func (ws *Websocket) Alias(name string) *Websocket {
 	return ws
 }
 
+// Chain returns public handle to assertion chain of Websocket, which may be
+// used to implement custom matchers. See Chain for details.
+func (ws *Websocket) Chain() *Chain {
+	return &Chain{chain: ws.chain}
+}
+
+// AsWarning is similar to Value.AsWarning.
+func (ws *Websocket) AsWarning() *Websocket {
+	opChain := ws.chain.enter("AsWarning()")
+	defer opChain.leave()
+
+	warnChain := ws.chain.clone()
+	warnChain.setWarning()
+
+	return &Websocket{
+		config:       ws.config,
+		chain:        warnChain,
+		conn:         ws.conn,
+		readTimeout:  ws.readTimeout,
+		writeTimeout: ws.writeTimeout,
+		isClosed:     ws.isClosed,
+	}
+}
+
 // WithReadTimeout sets timeout duration for WebSocket connection reads.
 //
 // By default no timeout is used.
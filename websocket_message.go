@@ -94,6 +94,28 @@ func (wm *WebsocketMessage) Alias(name string) *WebsocketMessage {
 	return wm
 }
 
+// Chain returns public handle to assertion chain of WebsocketMessage, which may be
+// used to implement custom matchers. See Chain for details.
+func (wm *WebsocketMessage) Chain() *Chain {
+	return &Chain{chain: wm.chain}
+}
+
+// AsWarning is similar to Value.AsWarning.
+func (wm *WebsocketMessage) AsWarning() *WebsocketMessage {
+	opChain := wm.chain.enter("AsWarning()")
+	defer opChain.leave()
+
+	warnChain := wm.chain.clone()
+	warnChain.setWarning()
+
+	return &WebsocketMessage{
+		chain:     warnChain,
+		typ:       wm.typ,
+		content:   wm.content,
+		closeCode: wm.closeCode,
+	}
+}
+
 // CloseMessage is a shorthand for m.Type(websocket.CloseMessage).
 func (wm *WebsocketMessage) CloseMessage() *WebsocketMessage {
 	opChain := wm.chain.enter("CloseMessage()")